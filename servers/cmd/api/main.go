@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	_ "net/http/pprof"
@@ -10,7 +12,16 @@ import (
 
 	"github.com/MatusOllah/slogcolor"
 	"github.com/go-chi/chi/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/non_prioritized"
+	prioritized "github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/prioritzed"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/shipper"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/backends"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/inmem"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/emitter"
 )
 
 var (
@@ -18,6 +29,25 @@ var (
 	httpRequestTimeout = shared.EnvDuration("HTTP_REQUEST_TIMEOUT", 30*time.Second)
 	shutdownTimeout    = shared.EnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second)
 	logger             = slog.New(slogcolor.NewHandler(os.Stdout, slogcolor.DefaultOptions))
+
+	statsEventsEnabled = shared.EnvString("STATS_EVENTS_ENABLED", "true")
+	statsBufferSize    = shared.EnvInt("STATS_EMIT_BUFFER_SIZE", 10000)
+	statsBatchSize     = shared.EnvInt("STATS_EMIT_BATCH_SIZE", 100)
+	statsFlushInterval = shared.EnvDuration("STATS_EMIT_FLUSH_INTERVAL", 1*time.Second)
+	statsPolicy        = shared.EnvString("STATS_EMIT_POLICY", string(emitter.DropNewest))
+	statsBlockTimeout  = shared.EnvDuration("STATS_EMIT_BLOCK_TIMEOUT", 0)
+	statsUserIDHeader  = shared.EnvString("STATS_USER_ID_HEADER", "X-User-ID")
+	statsStreamMaxLen  = shared.EnvInt("STATS_STREAM_MAX_LEN", 1000000)
+	// statsBroker and loggingBroker must match the backends the stats and logging services consume
+	statsBroker   = shared.EnvString("STATS_BROKER", string(broker.KindRedis))
+	loggingBroker = shared.EnvString("LOGGING_BROKER", string(broker.KindRedis))
+
+	// logShipper ships application logs to the logging server: none, grpc or stream
+	logShipper     = shared.EnvString("LOG_SHIPPER", "none")
+	logShipperTee  = shared.EnvString("LOG_SHIPPER_TEE", "false")
+	logServerAddr  = shared.EnvString("LOG_SERVER_ADDR", "localhost:8082")
+	logServiceType = shared.EnvString("LOG_SERVICE_TYPE", "OUTGAME")
+	environment    = shared.EnvString("ENVIRONMENT", "local")
 )
 
 func main() {
@@ -26,8 +56,23 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	ship, err := newLogShipper(ctx)
+	if err != nil {
+		logger.Error("failed to create log shipper", "error", err)
+		os.Exit(1)
+	}
+	if ship != nil {
+		logger = slog.New(ship)
+	}
+
+	events, eventsPublisher, err := newStatsEmitter(ctx)
+	if err != nil {
+		logger.Error("failed to create stats emitter", "error", err)
+		os.Exit(1)
+	}
+
 	r := chi.NewRouter()
-	s := NewServer(ctx, r, logger, httpRequestTimeout)
+	s := NewServer(ctx, r, logger, httpRequestTimeout, events)
 
 	// Mount pprof for profiling
 	r.Mount("/debug", http.DefaultServeMux)
@@ -40,8 +85,129 @@ func main() {
 
 	if err := shared.WaitForGracefulExit(ctx, shutdownTimeout, closer); err != nil {
 		logger.Error("graceful exit error", "error", err)
+		closePublisher(eventsPublisher)
+		closeLogShipper(ship)
 		os.Exit(1)
 	}
 
 	logger.Info("API server stopped gracefully")
+	closePublisher(eventsPublisher)
+	closeLogShipper(ship)
+}
+
+// newLogShipper creates the handler shipping logs to the logging server.
+// It returns nil when LOG_SHIPPER is none. The transports report their own
+// failures through the stdout logger so a failing send cannot recurse.
+func newLogShipper(ctx context.Context) (*shipper.Handler, error) {
+	var transport shipper.Transport
+	switch logShipper {
+	case "none":
+		return nil, nil
+	case "grpc":
+		transport = shipper.NewGRPCTransport(non_prioritized.NewLoggerClient(logServerAddr, logger))
+	case "stream":
+		kind, err := broker.ParseKind(loggingBroker)
+		if err != nil {
+			return nil, err
+		}
+		var redisClient *redis.Client
+		if kind == broker.KindRedis {
+			if redisClient = inmem.GetClient(ctx, inmem.LoggingKey); redisClient == nil {
+				return nil, errors.New("LOG_SHIPPER=stream requires LOGGING_REDIS_URL")
+			}
+		}
+		publisher, err := newPublisher(kind, redisClient, 0)
+		if err != nil {
+			return nil, fmt.Errorf("LOGGING_BROKER: %w", err)
+		}
+		transport = shipper.NewStreamTransport(prioritized.NewLoggerClient(publisher, logger))
+	default:
+		return nil, fmt.Errorf("unknown LOG_SHIPPER %q (expected none, grpc or stream)", logShipper)
+	}
+
+	return shipper.NewHandler(transport, shipper.Config{
+		ServiceName: "api",
+		ServiceType: logServiceType,
+		Environment: environment,
+		Tee:         logShipperTee == "true",
+	}), nil
+}
+
+// closeLogShipper sends the buffered logs
+func closeLogShipper(ship *shipper.Handler) {
+	if ship == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := ship.Close(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to flush shipped logs: %v\n", err)
+	}
+}
+
+// newStatsEmitter creates the emitter publishing api_call events to the stats stream
+// on the STATS_BROKER backend. It returns nil when STATS_EVENTS_ENABLED is false,
+// or when the backend is Redis and Redis is unavailable. The publisher is returned
+// so it can be closed once the emitter stopped.
+func newStatsEmitter(ctx context.Context) (*emitter.Emitter, broker.Publisher, error) {
+	if statsEventsEnabled != "true" {
+		logger.Info("stats events disabled")
+		return nil, nil, nil
+	}
+
+	policy, err := emitter.ParseOverflowPolicy(statsPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kind, err := broker.ParseKind(statsBroker)
+	if err != nil {
+		return nil, nil, err
+	}
+	var redisClient *redis.Client
+	if kind == broker.KindRedis {
+		if redisClient = inmem.GetClient(ctx, inmem.CacheKey); redisClient == nil {
+			logger.Warn("Redis client unavailable; api_call stats events are disabled")
+			return nil, nil, nil
+		}
+	}
+	publisher, err := newPublisher(kind, redisClient, int64(statsStreamMaxLen))
+	if err != nil {
+		return nil, nil, fmt.Errorf("STATS_BROKER: %w", err)
+	}
+
+	events := emitter.New(logger, publisher, emitter.Config{
+		BufferSize:    statsBufferSize,
+		BatchSize:     statsBatchSize,
+		FlushInterval: statsFlushInterval,
+		Policy:        policy,
+		BlockTimeout:  statsBlockTimeout,
+	})
+	go events.Run(ctx)
+	return events, publisher, nil
+}
+
+// closePublisher closes a publisher owning its connection, such as the NATS one
+func closePublisher(publisher broker.Publisher) {
+	closer, ok := publisher.(interface{ Close() error })
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		logger.Error("failed to close stats publisher", "error", err)
+	}
+}
+
+// newPublisher creates a publisher for streams consumed by another service.
+// Redis streams are trimmed to about maxLen entries; zero keeps every entry.
+// The memory backend only reaches consumers in the same process, so it is rejected.
+func newPublisher(kind broker.Kind, redisClient *redis.Client, maxLen int64) (broker.Publisher, error) {
+	switch kind {
+	case broker.KindRedis:
+		return redisstream.NewCappedPublisher(redisClient, maxLen), nil
+	case broker.KindMemory:
+		return nil, errors.New("the memory backend only reaches consumers in the same process; use redis or nats")
+	default:
+		return backends.NewPublisher(kind, redisClient)
+	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/user_profile"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
 	sharedMiddleware "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/middleware"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/emitter"
 )
 
 type Server struct {
@@ -21,6 +22,8 @@ type Server struct {
 	logger             *slog.Logger
 	httpRequestTimeout time.Duration
 	httpServer         *http.Server
+	// events publishes api_call stats events; nil disables them
+	events *emitter.Emitter
 }
 
 func NewServer(
@@ -28,12 +31,14 @@ func NewServer(
 	router *chi.Mux,
 	logger *slog.Logger,
 	httpRequestTimeout time.Duration,
+	events *emitter.Emitter,
 ) *Server {
 	s := &Server{
 		ctx:                ctx,
 		router:             router,
 		logger:             logger,
 		httpRequestTimeout: httpRequestTimeout,
+		events:             events,
 	}
 
 	s.setupMiddleware()
@@ -47,6 +52,11 @@ func (s *Server) setupMiddleware() {
 	s.router.Use(middleware.RealIP)
 	s.router.Use(middleware.Logger)
 	s.router.Use(middleware.Recoverer)
+	if s.events != nil {
+		s.router.Use(emitter.APICalls(s.events, emitter.MiddlewareConfig{
+			UserID: emitter.UserIDFromHeader(statsUserIDHeader),
+		}))
+	}
 	s.router.Use(middleware.Timeout(s.httpRequestTimeout))
 	s.router.Use(sharedMiddleware.MeasureExecTime(s.logger))
 	s.router.Use(sharedMiddleware.ApiVersionWith("v1"))
@@ -73,7 +83,15 @@ type apiServerCloser struct {
 
 func (asc *apiServerCloser) Close(ctx context.Context) error {
 	asc.server.logger.Info("Shutting down API server...")
-	return asc.httpServer.Shutdown(ctx)
+	if err := asc.httpServer.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	// Publish the api_call events of the drained requests
+	if asc.server.events != nil {
+		return asc.server.events.Close(ctx)
+	}
+	return nil
 }
 
 func (s *Server) Start(ctx context.Context, port string) (shared.Closer, error) {
@@ -2,20 +2,63 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/MatusOllah/slogcolor"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/log_query"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/catalog"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/event"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/policy"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/redact"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/store"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/tail"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/supabase_postgres"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/inmem"
 )
 
 var (
 	port            = shared.EnvString("PORT", ":8082")
 	shutdownTimeout = shared.EnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second)
 	logger          = slog.New(slogcolor.NewHandler(os.Stdout, slogcolor.DefaultOptions))
+
+	logSinks          = shared.EnvString("LOG_SINKS", "stdout")
+	logStoragePath    = shared.EnvString("LOG_STORAGE_PATH", "./logs")
+	logRotationSizeMB = shared.EnvInt("LOG_ROTATION_SIZE", 100)
+	logRetentionDays  = shared.EnvInt("LOG_RETENTION_DAYS", 30)
+	sinkQueueSize     = shared.EnvInt("LOG_SINK_QUEUE_SIZE", 10000)
+	sinkBatchSize     = shared.EnvInt("LOG_SINK_BATCH_SIZE", 500)
+	sinkFlushInterval = shared.EnvDuration("LOG_SINK_FLUSH_INTERVAL", 1*time.Second)
+
+	// httpPort serves the live tail, and the log search API when the postgres sink is enabled
+	httpPort          = shared.EnvString("LOG_HTTP_PORT", ":8083")
+	tailBuffer        = shared.EnvInt("LOG_TAIL_BUFFER", 256)
+	retentionInterval = shared.EnvDuration("LOG_RETENTION_INTERVAL", 1*time.Hour)
+
+	// redactionRules is a YAML file of per-environment redaction rules; redaction is disabled when unset
+	redactionRules = shared.EnvString("LOG_REDACTION_RULES", "")
+	redactionKey   = shared.EnvString("LOG_REDACTION_KEY", "")
+
+	// logPolicies is a YAML file of sampling and rate limit policies; nothing is dropped when unset
+	logPolicies          = shared.EnvString("LOG_POLICIES", "")
+	policyReportInterval = shared.EnvDuration("LOG_POLICY_REPORT_INTERVAL", 1*time.Minute)
+
+	// eventCatalogMode is off, tag or quarantine; eventCatalog overrides the embedded catalog
+	eventCatalogMode = shared.EnvString("LOG_EVENT_CATALOG_MODE", "tag")
+	eventCatalog     = shared.EnvString("LOG_EVENT_CATALOG", "")
+	// quarantinePath keeps quarantined entries in files; they are only logged when unset
+	quarantinePath = shared.EnvString("LOG_QUARANTINE_PATH", "")
 )
 
 func main() {
@@ -24,7 +67,49 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	s := logging.NewServer(logger)
+	sinks, pool, err := newSinks()
+	if err != nil {
+		logger.Error("failed to create log sinks", "error", err)
+		os.Exit(1)
+	}
+	hub := tail.NewHub()
+	sinks = append(sinks, hub)
+
+	var querier store.Querier
+	if pool != nil {
+		// Partitions must exist before the first entries are written
+		retention := store.NewRetention(logger, pool, logRetentionDays)
+		if _, _, err := retention.Apply(ctx, time.Now()); err != nil {
+			logger.Error("failed to prepare log partitions", "error", err)
+			os.Exit(1)
+		}
+		go retention.Run(ctx, retentionInterval)
+		querier = store.NewPostgresStore(pool)
+	}
+
+	stages, err := newStages()
+	if err != nil {
+		logger.Error("failed to create log pipeline stages", "error", err)
+		os.Exit(1)
+	}
+	pipeline := sink.NewPipeline(logger, sink.Config{
+		Stages:        stages.list,
+		QueueSize:     sinkQueueSize,
+		BatchSize:     sinkBatchSize,
+		FlushInterval: sinkFlushInterval,
+	}, sinks...)
+	if stages.limiter != nil {
+		go stages.limiter.Run(ctx, policyReportInterval)
+	}
+	go serveHTTP(querier, hub, pipeline, stages)
+
+	// The logging Redis carries the prioritized stream; the memory and nats brokers work without it
+	redisClient := inmem.GetClient(ctx, inmem.LoggingKey)
+	if redisClient == nil {
+		logger.Warn("logging Redis unavailable; only the memory and nats brokers can be used")
+	}
+
+	s := logging.NewServer(logger, pipeline, redisClient)
 
 	closer, err := s.Start(ctx, port)
 	if err != nil {
@@ -37,5 +122,164 @@ func main() {
 		os.Exit(1)
 	}
 
+	if stages.quarantine != nil {
+		closeCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := stages.quarantine.Close(closeCtx); err != nil {
+			logger.Error("failed to close log quarantine", "error", err)
+		}
+	}
+
 	logger.Info("Logging server stopped gracefully")
 }
+
+// pipelineStages are the stages applied to every entry before the sinks, with
+// the ones main reports on or closes; those are nil when disabled
+type pipelineStages struct {
+	list       []sink.Stage
+	limiter    *policy.Limiter
+	checker    *catalog.Checker
+	redactor   *redact.Redactor
+	quarantine catalog.Quarantine
+}
+
+// newStages creates the pipeline stages. The policy limiter runs first so
+// dropped entries are never checked or redacted, the catalog checker before
+// redaction changes payloads, and the quarantine after it so quarantined
+// entries are redacted too.
+func newStages() (pipelineStages, error) {
+	var stages pipelineStages
+	if logPolicies != "" {
+		policies, err := policy.LoadPolicies(logPolicies)
+		if err != nil {
+			return stages, err
+		}
+		stages.limiter = policy.NewLimiter(logger, policies)
+		stages.list = append(stages.list, stages.limiter)
+		logger.Info("log policies enabled", "policies", len(policies))
+	}
+
+	switch eventCatalogMode {
+	case "off":
+	case "tag", "quarantine":
+		c := event.Default()
+		if eventCatalog != "" {
+			var err error
+			if c, err = event.LoadCatalog(eventCatalog); err != nil {
+				return stages, err
+			}
+		}
+		stages.checker = catalog.NewChecker(c)
+		stages.list = append(stages.list, stages.checker)
+		logger.Info("event catalog enabled", "mode", eventCatalogMode, "events", len(c.Events()))
+	default:
+		return stages, fmt.Errorf("unknown event catalog mode %q (expected off, tag or quarantine)", eventCatalogMode)
+	}
+
+	if redactionRules != "" {
+		rules, err := redact.LoadRules(redactionRules)
+		if err != nil {
+			return stages, err
+		}
+		if redactionKey == "" {
+			logger.Warn("LOG_REDACTION_KEY is unset; hashed values are not keyed")
+		}
+		stages.redactor = redact.NewRedactor(rules, []byte(redactionKey))
+		stages.list = append(stages.list, stages.redactor)
+		logger.Info("log redaction enabled", "environments", len(rules))
+	}
+
+	if eventCatalogMode == "quarantine" {
+		stages.quarantine = catalog.NewLogQuarantine(logger)
+		if quarantinePath != "" {
+			fileSink, err := sink.NewFileSink(sink.FileConfig{
+				Dir:     quarantinePath,
+				MaxSize: int64(logRotationSizeMB) << 20,
+				MaxAge:  time.Duration(logRetentionDays) * 24 * time.Hour,
+			})
+			if err != nil {
+				return stages, err
+			}
+			stages.quarantine = catalog.NewSinkQuarantine(logger, fileSink)
+		}
+		stages.list = append(stages.list, stages.checker.QuarantineStage(stages.quarantine))
+	}
+	return stages, nil
+}
+
+// metricsResponse is the payload served by /metrics
+type metricsResponse struct {
+	Sinks     []sink.Stats   `json:"sinks"`
+	Policies  []policy.Stats `json:"policies"`
+	Events    *catalog.Stats `json:"events,omitempty"`
+	Redaction *redact.Stats  `json:"redaction,omitempty"`
+}
+
+// serveHTTP serves the log search and tail APIs and the pipeline metrics;
+// querier is nil without Postgres
+func serveHTTP(querier store.Querier, hub *tail.Hub, pipeline *sink.Pipeline, stages pipelineStages) {
+	r := chi.NewRouter()
+	r.Get("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		metrics := metricsResponse{Sinks: pipeline.Stats(), Policies: []policy.Stats{}}
+		if stages.limiter != nil {
+			metrics.Policies = stages.limiter.Stats()
+		}
+		if stages.checker != nil {
+			events := stages.checker.Stats()
+			metrics.Events = &events
+		}
+		if stages.redactor != nil {
+			redaction := stages.redactor.Stats()
+			metrics.Redaction = &redaction
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(metrics); err != nil {
+			logger.Error("failed to encode metrics", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+	})
+	log_query.MapRoutes(r, "v1", querier, hub, tailBuffer)
+	r.Mount("/debug", http.DefaultServeMux)
+
+	logger.Info("HTTP server listening", "port", httpPort)
+	if err := http.ListenAndServe(httpPort, r); err != nil {
+		logger.Error("HTTP server error", "error", err)
+	}
+}
+
+// newSinks creates the sinks listed in LOG_SINKS: stdout, file and postgres.
+// The returned pool is nil unless the postgres sink is enabled.
+func newSinks() ([]sink.Sink, *pgxpool.Pool, error) {
+	var sinks []sink.Sink
+	var pool *pgxpool.Pool
+	for name := range strings.SplitSeq(logSinks, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+			continue
+		case "stdout":
+			sinks = append(sinks, sink.NewJSONSink("stdout", os.Stdout))
+		case "file":
+			fileSink, err := sink.NewFileSink(sink.FileConfig{
+				Dir:     logStoragePath,
+				MaxSize: int64(logRotationSizeMB) << 20,
+				MaxAge:  time.Duration(logRetentionDays) * 24 * time.Hour,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			sinks = append(sinks, fileSink)
+		case "postgres":
+			pooler := supabase_postgres.GetDBPooler()
+			if pooler == nil {
+				return nil, nil, fmt.Errorf("postgres log sink selected but the database is unavailable")
+			}
+			pool = pooler.Pool
+			sinks = append(sinks, sink.NewPostgresSink(pool))
+		default:
+			return nil, nil, fmt.Errorf("unknown log sink %q (expected stdout, file or postgres)", name)
+		}
+	}
+	logger.Info("log sinks", "sinks", logSinks)
+	return sinks, pool, nil
+}
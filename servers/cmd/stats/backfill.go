@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/MatusOllah/slogcolor"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/supabase_postgres"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/inmem"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/backfill"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/consumer"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/snapshot"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// parseDay accepts a stream ID, an RFC3339 time or a date and returns the start of its UTC day
+func parseDay(s string) (time.Time, error) {
+	if t, err := redisstream.IDTime(s); err == nil {
+		return store.Day.Truncate(t), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return store.Day.Truncate(t), nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a stream ID, an RFC3339 time nor a date", s)
+}
+
+// runBackfill recomputes rollups from the event stream or an archive without
+// touching the live consumer group.
+//
+// With Postgres storage the days in [-from, -to) are rebuilt in a separate schema
+// and swapped into the live tables in one transaction; -to defaults to today so
+// the live consumer keeps the current day. With memory storage the whole state is
+// rebuilt from -from and saved as the snapshot the server restores on its next start.
+func runBackfill(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	from := fs.String("from", "", "first day to recompute: stream ID, RFC3339 time or date (required)")
+	to := fs.String("to", "", "day to stop before (postgres only, default today)")
+	archive := fs.String("archive", "", "read a JSON lines export (streamctl tail) instead of "+stats.EventStreamKey)
+	namespace := fs.String("namespace", "stats_backfill_default", "Postgres schema holding the recomputed rollups; must start with "+backfill.NamespacePrefix)
+	swap := fs.Bool("swap", true, "swap the recomputed rollups in; otherwise keep them in the namespace for inspection")
+	force := fs.Bool("force", false, "replace the live rollups even when the source is empty or starts after -from")
+	fs.Parse(args)
+
+	if *from == "" {
+		return errors.New("-from is required")
+	}
+	window := backfill.Window{}
+	var err error
+	if window.From, err = parseDay(*from); err != nil {
+		return err
+	}
+
+	var source backfill.Source
+	if *archive != "" {
+		source = backfill.NewArchiveSource(*archive)
+	} else {
+		redisClient := inmem.GetClient(ctx, inmem.CacheKey)
+		if redisClient == nil {
+			return errors.New("Redis is unavailable; use -archive to backfill from an export")
+		}
+		source = backfill.NewStreamSource(redisClient, stats.EventStreamKey, redisstream.IDFromTime(window.From))
+	}
+
+	// Per-event logs of the replay would drown the summary
+	quiet := slog.New(slogcolor.NewHandler(os.Stdout, &slogcolor.Options{
+		Level:       slog.LevelWarn,
+		TimeFormat:  time.DateTime,
+		SrcFileMode: slogcolor.ShortFile,
+	}))
+
+	switch statsStorage {
+	case "postgres":
+		window.To = store.Day.Truncate(time.Now())
+		if *to != "" {
+			if window.To, err = parseDay(*to); err != nil {
+				return err
+			}
+		}
+		if !window.From.Before(window.To) {
+			return fmt.Errorf("empty range [%s, %s)", window.From.Format(time.DateOnly), window.To.Format(time.DateOnly))
+		}
+		return backfillPostgres(ctx, quiet, source, window, *namespace, *swap, *force)
+	case "memory":
+		if *to != "" {
+			return errors.New("-to is not supported with memory storage; the snapshot covers the stream up to its end")
+		}
+		return backfillMemory(ctx, quiet, source, window, *force)
+	default:
+		return fmt.Errorf("unknown stats storage %q (expected memory or postgres)", statsStorage)
+	}
+}
+
+// backfillPostgres replays into a fresh namespace and swaps its days into the live rollups.
+// Funnel and retention cohorts are not recomputed.
+func backfillPostgres(ctx context.Context, quiet *slog.Logger, source backfill.Source, window backfill.Window, namespace string, swap, force bool) error {
+	pooler := supabase_postgres.GetDBPooler()
+	if pooler == nil {
+		return errors.New("postgres stats storage selected but the database is unavailable")
+	}
+
+	ns, err := backfill.OpenNamespace(ctx, pooler.Pool, namespace)
+	if err != nil {
+		return err
+	}
+
+	rollups := store.NewPostgresStore(quiet, ns.Pool(), store.PostgresConfig{
+		BatchSize:      flushBatchSize,
+		IdempotencyTTL: idempotencyTTL,
+	})
+	processor := consumer.NewEventProcessor(quiet, nil, rollups, nil, nil)
+
+	logger.Info("replaying stats events",
+		"from", window.From.Format(time.DateOnly),
+		"to", window.To.Format(time.DateOnly),
+		"namespace", namespace)
+	res, err := backfill.Replay(ctx, source, processor, rollups, window)
+	if err != nil {
+		ns.Close(ctx, false)
+		return fmt.Errorf("failed to replay events: %w", err)
+	}
+	logger.Info("replay finished", "result", res)
+
+	if !swap {
+		logger.Info("recomputed rollups kept for inspection", "namespace", namespace)
+		return ns.Close(ctx, false)
+	}
+	if err := checkCoverage(ctx, source, window, res, force); err != nil {
+		ns.Close(ctx, false)
+		return err
+	}
+
+	rows, err := ns.Swap(ctx, window.From, window.To)
+	if err != nil {
+		ns.Close(ctx, false)
+		return err
+	}
+	logger.Info("swapped recomputed rollups in", "rows", rows)
+	return ns.Close(ctx, true)
+}
+
+// backfillMemory rebuilds the in-memory state and saves it as the snapshot restored
+// on the next start. Stop the server first, or its shutdown snapshot overwrites it.
+func backfillMemory(ctx context.Context, quiet *slog.Logger, source backfill.Source, window backfill.Window, force bool) error {
+	snapshots, err := newSnapshotStore(inmem.GetClient(ctx, inmem.CacheKey))
+	if err != nil {
+		return err
+	}
+	if snapshots == nil {
+		return errors.New("memory storage is backfilled into a snapshot; set STATS_SNAPSHOT_STORE")
+	}
+
+	tracker, err := newBehaviorTracker()
+	if err != nil {
+		return err
+	}
+	rollups := store.NewMemoryStore()
+	processor := consumer.NewEventProcessor(quiet, nil, rollups, nil, tracker)
+
+	logger.Info("replaying stats events", "from", window.From.Format(time.DateOnly))
+	res, err := backfill.Replay(ctx, source, processor, rollups, window)
+	if err != nil {
+		return fmt.Errorf("failed to replay events: %w", err)
+	}
+	logger.Info("replay finished", "result", res)
+
+	if err := checkCoverage(ctx, source, window, res, force); err != nil {
+		return err
+	}
+	return snapshot.NewRunner(logger, snapshots, processor).Save(ctx)
+}
+
+// checkCoverage refuses to replace live data with an incomplete replay unless forced
+func checkCoverage(ctx context.Context, source backfill.Source, window backfill.Window, res backfill.Result, force bool) error {
+	err := backfill.CheckCoverage(ctx, source, window, res)
+	if err == nil {
+		return nil
+	}
+	if force {
+		logger.Warn("replacing live stats with an incomplete replay", "reason", err)
+		return nil
+	}
+	return fmt.Errorf("refusing to replace live stats: %w (use -force to override)", err)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	_ "net/http/pprof"
@@ -10,8 +11,19 @@ import (
 
 	"github.com/MatusOllah/slogcolor"
 	"github.com/go-chi/chi/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/supabase_postgres"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/inmem"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/alert"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/snapshot"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
 )
 
 var (
@@ -25,9 +37,51 @@ var (
 		TimeFormat:  time.DateTime,
 		SrcFileMode: slogcolor.ShortFile,
 	}))
+
+	idempotencyStore      = shared.EnvString("IDEMPOTENCY_STORE", "redis")
+	idempotencyTTL        = shared.EnvDuration("IDEMPOTENCY_TTL", idempotency.DefaultTTL)
+	idempotencyPurgeEvery = shared.EnvDuration("IDEMPOTENCY_PURGE_INTERVAL", 1*time.Hour)
+
+	statsStorage    = shared.EnvString("STATS_STORAGE", "memory")
+	flushInterval   = shared.EnvDuration("STATS_FLUSH_INTERVAL", 5*time.Second)
+	flushBatchSize  = shared.EnvInt("STATS_FLUSH_BATCH_SIZE", 1000)
+	flushAttempts   = shared.EnvInt("STATS_FLUSH_MAX_ATTEMPTS", 0)
+	minuteRetention = shared.EnvDuration("STATS_MINUTE_RETENTION", 48*time.Hour)
+	hourRetention   = shared.EnvDuration("STATS_HOUR_RETENTION", 90*24*time.Hour)
+	funnelsFile     = shared.EnvString("STATS_FUNNELS_FILE", "")
+
+	snapshotStore    = shared.EnvString("STATS_SNAPSHOT_STORE", "none")
+	snapshotPath     = shared.EnvString("STATS_SNAPSHOT_PATH", "./stats-snapshot.json")
+	snapshotKey      = shared.EnvString("STATS_SNAPSHOT_KEY", "stats:snapshot")
+	snapshotInterval = shared.EnvDuration("STATS_SNAPSHOT_INTERVAL", 1*time.Minute)
+
+	inspectStreams        = shared.EnvString("INSPECT_STREAMS", stats.EventStreamKey+"="+stats.EventConsumerGroup)
+	inspectLoggingStreams = shared.EnvString("INSPECT_LOGGING_STREAMS", "")
+	inspectInterval       = shared.EnvDuration("INSPECT_INTERVAL", 15*time.Second)
+	idleConsumerAfter     = shared.EnvDuration("INSPECT_IDLE_CONSUMER_AFTER", 1*time.Minute)
+	deadConsumerAfter     = shared.EnvDuration("INSPECT_DEAD_CONSUMER_AFTER", 1*time.Hour)
+	maxConsumerLag        = shared.EnvInt("INSPECT_MAX_LAG", 1000)
+	maxPendingAge         = shared.EnvDuration("INSPECT_MAX_PENDING_AGE", 5*time.Minute)
+
+	alertRulesFile      = shared.EnvString("ALERT_RULES_FILE", "")
+	alertEvalInterval   = shared.EnvDuration("ALERT_EVAL_INTERVAL", 15*time.Second)
+	alertWebhookURL     = shared.EnvString("ALERT_WEBHOOK_URL", "")
+	alertWebhookTimeout = shared.EnvDuration("ALERT_WEBHOOK_TIMEOUT", 5*time.Second)
+	alertStreamMaxLen   = shared.EnvInt("ALERT_STREAM_MAX_LEN", 100000)
+
+	quarantineStreamMaxLen = shared.EnvInt("QUARANTINE_STREAM_MAX_LEN", 100000)
 )
 
 func main() {
+	// "stats backfill" recomputes rollups and exits instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err := runBackfill(context.Background(), os.Args[2:]); err != nil {
+			logger.Error("backfill failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	logger.Info("Starting Stats server", "port", port)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -35,20 +89,90 @@ func main() {
 
 	// Initialize Redis client
 	// Note: Using GetClient with CacheKey for stats consumer
+	// The client is optional when STATS_BROKER selects the memory or nats backend
 	redisClient := inmem.GetClient(ctx, inmem.CacheKey)
 	if redisClient == nil {
-		logger.Error("failed to initialize Redis client")
+		logger.Warn("Redis client unavailable; only the memory and nats brokers can be used")
+	} else {
+		logger.Info("connected to Redis")
+	}
+
+	idemStore, err := newIdempotencyStore(ctx, redisClient)
+	if err != nil {
+		logger.Error("failed to create idempotency store", "error", err)
+		os.Exit(1)
+	}
+
+	rollups, err := newRollupStore(ctx)
+	if err != nil {
+		logger.Error("failed to create stats rollup store", "error", err)
+		os.Exit(1)
+	}
+
+	tracker, err := newBehaviorTracker()
+	if err != nil {
+		logger.Error("failed to create funnel and retention tracker", "error", err)
+		os.Exit(1)
+	}
+
+	registry := health.NewRegistry()
+	s, err := NewServer(ctx, logger, redisClient, registry, idemStore, rollups, tracker)
+	if err != nil {
+		logger.Error("failed to create stats server", "error", err)
 		os.Exit(1)
 	}
-	logger.Info("connected to Redis")
 
-	s := NewServer(ctx, logger, redisClient)
+	snapshots, err := newSnapshotStore(redisClient)
+	if err != nil {
+		logger.Error("failed to create stats snapshot store", "error", err)
+		os.Exit(1)
+	}
+	if snapshots != nil {
+		if err := s.StartSnapshots(ctx, snapshots, snapshotInterval); err != nil {
+			logger.Error("failed to restore stats snapshot", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Inspect consumer lag of the stats stream and, when reachable, the logging stream
+	if redisClient != nil {
+		if err := addInspector(s, redisClient, inspectStreams); err != nil {
+			logger.Error("invalid INSPECT_STREAMS", "error", err)
+			os.Exit(1)
+		}
+	}
+	if inspectLoggingStreams != "" {
+		if loggingClient := inmem.GetClient(ctx, inmem.LoggingKey); loggingClient != nil {
+			if err := addInspector(s, loggingClient, inspectLoggingStreams); err != nil {
+				logger.Error("invalid INSPECT_LOGGING_STREAMS", "error", err)
+				os.Exit(1)
+			}
+		} else {
+			logger.Warn("logging Redis unavailable; skipping logging stream inspection")
+		}
+	}
+
+	// Alerting is disabled unless a rules file is configured
+	if alertRulesFile != "" {
+		rules, err := alert.LoadRules(alertRulesFile)
+		if err != nil {
+			logger.Error("failed to load alert rules", "error", err)
+			os.Exit(1)
+		}
+		if err := s.StartAlerts(ctx, rules, alertEvalInterval, alertWebhookURL, alertWebhookTimeout); err != nil {
+			logger.Error("failed to start alerting", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("alerting enabled", "rules", len(rules), "interval", alertEvalInterval)
+	}
 
 	// Start HTTP server for metrics and health checks
 	go func() {
 		r := chi.NewRouter()
 		r.Get("/health", s.handleHealth)
 		r.Get("/metrics", s.handleMetrics)
+		r.Get("/alerts", s.handleAlerts)
+		stats_query.MapRoutes(r, "v1", rollups, tracker)
 		r.Mount("/debug", http.DefaultServeMux)
 
 		logger.Info("HTTP server listening", "port", port)
@@ -62,6 +186,8 @@ func main() {
 		logger.Error("failed to start consumer", "error", err)
 		os.Exit(1)
 	}
+	// Inspectors start once the consumer created its group, so a fresh stream is not reported unhealthy
+	s.StartInspectors(ctx)
 
 	if err := shared.WaitForGracefulExit(ctx, shutdownTimeout, s); err != nil {
 		logger.Error("graceful exit error", "error", err)
@@ -70,3 +196,128 @@ func main() {
 
 	logger.Info("Stats server stopped gracefully")
 }
+
+func addInspector(s *Server, redisClient *redis.Client, targets string) error {
+	parsed, err := redisstream.ParseTargets(targets)
+	if err != nil {
+		return err
+	}
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	s.AddStreamInspector(redisClient, redisstream.InspectorConfig{
+		Targets:           parsed,
+		Interval:          inspectInterval,
+		IdleConsumerAfter: idleConsumerAfter,
+		DeadConsumerAfter: deadConsumerAfter,
+		MaxLag:            int64(maxConsumerLag),
+		MaxPendingAge:     maxPendingAge,
+	})
+	return nil
+}
+
+// newIdempotencyStore creates the store selected by IDEMPOTENCY_STORE: redis, postgres, memory or none
+func newIdempotencyStore(ctx context.Context, redisClient *redis.Client) (idempotency.Store, error) {
+	logger.Info("idempotency store", "store", idempotencyStore, "ttl", idempotencyTTL)
+
+	switch idempotencyStore {
+	case "redis":
+		if redisClient == nil {
+			logger.Warn("Redis client unavailable; falling back to in-memory idempotency store")
+			return idempotency.NewMemoryStore(idempotencyTTL), nil
+		}
+		return idempotency.NewRedisStore(redisClient, idempotencyTTL), nil
+	case "postgres":
+		pooler := supabase_postgres.GetDBPooler()
+		if pooler == nil {
+			return nil, fmt.Errorf("postgres idempotency store selected but the database is unavailable")
+		}
+		pgStore := idempotency.NewPostgresStore(pooler.Pool, idempotencyTTL)
+		go pgStore.PurgeLoop(ctx, logger, idempotencyPurgeEvery)
+		return pgStore, nil
+	case "memory":
+		return idempotency.NewMemoryStore(idempotencyTTL), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown idempotency store %q (expected redis, postgres, memory or none)", idempotencyStore)
+	}
+}
+
+// newRollupStore creates the rollup store selected by STATS_STORAGE: memory or postgres
+func newRollupStore(ctx context.Context) (store.Store, error) {
+	logger.Info("stats storage", "storage", statsStorage)
+
+	switch statsStorage {
+	case "memory":
+		return store.NewMemoryStore(), nil
+	case "postgres":
+		pooler := supabase_postgres.GetDBPooler()
+		if pooler == nil {
+			return nil, fmt.Errorf("postgres stats storage selected but the database is unavailable")
+		}
+		rollups := store.NewPostgresStore(logger, pooler.Pool, store.PostgresConfig{
+			FlushInterval:    flushInterval,
+			BatchSize:        flushBatchSize,
+			MinuteRetention:  minuteRetention,
+			HourRetention:    hourRetention,
+			IdempotencyTTL:   idempotencyTTL,
+			MaxFlushAttempts: flushAttempts,
+		})
+		go rollups.Run(ctx)
+		return rollups, nil
+	default:
+		return nil, fmt.Errorf("unknown stats storage %q (expected memory or postgres)", statsStorage)
+	}
+}
+
+// newBehaviorTracker creates the funnel and retention tracker on the storage selected by
+// STATS_STORAGE. Funnels are read from STATS_FUNNELS_FILE; retention is tracked without it.
+func newBehaviorTracker() (*behavior.Tracker, error) {
+	var funnels []behavior.Funnel
+	if funnelsFile != "" {
+		loaded, err := behavior.LoadFunnels(funnelsFile)
+		if err != nil {
+			return nil, err
+		}
+		funnels = loaded
+	}
+	logger.Info("funnels loaded", "funnels", len(funnels))
+
+	switch statsStorage {
+	case "postgres":
+		pooler := supabase_postgres.GetDBPooler()
+		if pooler == nil {
+			return nil, fmt.Errorf("postgres stats storage selected but the database is unavailable")
+		}
+		return behavior.NewTracker(logger, behavior.NewPostgresStore(pooler.Pool), funnels), nil
+	default:
+		return behavior.NewTracker(logger, behavior.NewMemoryStore(), funnels), nil
+	}
+}
+
+// newSnapshotStore creates the snapshot store selected by STATS_SNAPSHOT_STORE: file, redis or none.
+// Snapshots only apply to the memory storage; Postgres rollups survive restarts on their own.
+func newSnapshotStore(redisClient *redis.Client) (snapshot.Store, error) {
+	if snapshotStore == "none" {
+		return nil, nil
+	}
+	if statsStorage != "memory" {
+		logger.Warn("stats snapshots only apply to memory storage; ignoring STATS_SNAPSHOT_STORE", "storage", statsStorage)
+		return nil, nil
+	}
+	logger.Info("stats snapshots", "store", snapshotStore, "interval", snapshotInterval)
+
+	switch snapshotStore {
+	case "file":
+		return snapshot.NewFileStore(snapshotPath), nil
+	case "redis":
+		if redisClient == nil {
+			return nil, fmt.Errorf("redis snapshot store selected but Redis is unavailable")
+		}
+		return snapshot.NewRedisStore(redisClient, snapshotKey), nil
+	default:
+		return nil, fmt.Errorf("unknown snapshot store %q (expected file, redis or none)", snapshotStore)
+	}
+}
@@ -5,9 +5,19 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/alert"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/consumer"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/schema"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/snapshot"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
 )
 
 type Server struct {
@@ -16,15 +26,44 @@ type Server struct {
 	redisClient   *redis.Client
 	eventConsumer *consumer.EventConsumer
 	processor     *consumer.EventProcessor
+	rollups       store.Store
+	registry      *health.Registry
+	inspectors    []*redisstream.Inspector
+	alerts        *alert.Engine
+	snapshots     *snapshot.Runner
+}
+
+// metricsResponse is the payload served by /metrics
+type metricsResponse struct {
+	stats.MetricsSummary
+	Streams []redisstream.GroupStats `json:"streams"`
+	Schemas []schema.Key             `json:"schemas"`
 }
 
 func NewServer(
 	ctx context.Context,
 	logger *slog.Logger,
 	redisClient *redis.Client,
-) *Server {
-	processor := consumer.NewEventProcessor(logger)
-	eventConsumer := consumer.NewEventConsumer(logger, redisClient, processor)
+	registry *health.Registry,
+	idempotencyStore idempotency.Store,
+	rollups store.Store,
+	tracker *behavior.Tracker,
+) (*Server, error) {
+	// Quarantined events are kept in a stream next to the events when Redis is available
+	var quarantine schema.Quarantine
+	if redisClient != nil {
+		quarantine = schema.NewStreamQuarantine(redisstream.NewCappedPublisher(redisClient, int64(quarantineStreamMaxLen)))
+	}
+
+	processor := consumer.NewEventProcessor(logger, idempotencyStore, rollups, quarantine, tracker)
+	if err := processor.Restore(ctx); err != nil {
+		logger.Warn("failed to restore stats totals; starting from zero", "error", err)
+	}
+
+	eventConsumer, err := consumer.NewEventConsumer(logger, redisClient, processor, registry)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Server{
 		ctx:           ctx,
@@ -32,7 +71,61 @@ func NewServer(
 		redisClient:   redisClient,
 		eventConsumer: eventConsumer,
 		processor:     processor,
+		rollups:       rollups,
+		registry:      registry,
+	}, nil
+}
+
+// AddStreamInspector registers an inspector for the given streams on redisClient
+func (s *Server) AddStreamInspector(redisClient *redis.Client, config redisstream.InspectorConfig) {
+	s.inspectors = append(s.inspectors,
+		redisstream.NewInspector(s.logger, redisClient, s.registry, config))
+}
+
+// StartAlerts evaluates rules every interval against the rollups and inspected streams.
+// Notifications are logged, published to the alerts stream when Redis is available
+// and posted to webhookURL when set.
+func (s *Server) StartAlerts(ctx context.Context, rules []alert.Rule, interval time.Duration, webhookURL string, webhookTimeout time.Duration) error {
+	notifiers := map[string]alert.Notifier{
+		"log": alert.NewLogNotifier(s.logger),
+	}
+	if s.redisClient != nil {
+		notifiers["stream"] = alert.NewStreamNotifier(redisstream.NewCappedPublisher(s.redisClient, int64(alertStreamMaxLen)))
 	}
+	if webhookURL != "" {
+		notifiers["webhook"] = alert.NewWebhookNotifier(webhookURL, webhookTimeout)
+	}
+
+	source := alert.NewStatsSource(s.rollups, s.streamStats)
+	engine, err := alert.NewEngine(s.logger, source, rules, notifiers)
+	if err != nil {
+		return err
+	}
+	s.alerts = engine
+
+	go engine.Run(ctx, interval)
+	return nil
+}
+
+// StartSnapshots restores the latest snapshot of the in-memory state, then saves
+// one every interval and on shutdown. Call it before StartConsumer.
+func (s *Server) StartSnapshots(ctx context.Context, store snapshot.Store, interval time.Duration) error {
+	state, ok, err := snapshot.Load(ctx, store)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := s.processor.RestoreSnapshot(ctx, state); err != nil {
+			return err
+		}
+		s.logger.Info("restored stats snapshot",
+			"taken_at", state.TakenAt,
+			"position", state.Position)
+	}
+
+	s.snapshots = snapshot.NewRunner(s.logger, store, s.processor)
+	go s.snapshots.Run(ctx, interval)
+	return nil
 }
 
 func (s *Server) StartConsumer(ctx context.Context) error {
@@ -40,6 +133,13 @@ func (s *Server) StartConsumer(ctx context.Context) error {
 	return s.eventConsumer.Start(ctx)
 }
 
+// StartInspectors runs all registered stream inspectors in the background
+func (s *Server) StartInspectors(ctx context.Context) {
+	for _, inspector := range s.inspectors {
+		go inspector.Run(ctx)
+	}
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("shutting down stats server")
 
@@ -48,11 +148,26 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return err
 	}
 
-	if err := s.redisClient.Close(); err != nil {
-		s.logger.Error("failed to close Redis client", "error", err)
+	// Write rollups still buffered after the last processed events
+	if err := s.rollups.Flush(ctx); err != nil {
+		s.logger.Error("failed to flush stats rollups", "error", err)
 		return err
 	}
 
+	if s.snapshots != nil {
+		if err := s.snapshots.Save(ctx); err != nil {
+			s.logger.Error("failed to save stats snapshot", "error", err)
+			return err
+		}
+	}
+
+	if s.redisClient != nil {
+		if err := s.redisClient.Close(); err != nil {
+			s.logger.Error("failed to close Redis client", "error", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -62,13 +177,16 @@ func (s *Server) Close(ctx context.Context) error {
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"status":"healthy"}`))
+	s.registry.Handler(w, r)
 }
 
 func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
-	metrics := s.processor.GetMetrics()
+	metrics := metricsResponse{
+		MetricsSummary: s.processor.GetMetrics(),
+		Streams:        make([]redisstream.GroupStats, 0),
+		Schemas:        s.processor.Schemas(),
+	}
+	metrics.Streams = append(metrics.Streams, s.streamStats()...)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(metrics); err != nil {
@@ -77,3 +195,38 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// alertsResponse is the payload served by /alerts
+type alertsResponse struct {
+	Enabled bool          `json:"enabled"`
+	Rules   []alert.Rule  `json:"rules"`
+	Active  []alert.Alert `json:"active"`
+}
+
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	resp := alertsResponse{
+		Rules:  make([]alert.Rule, 0),
+		Active: make([]alert.Alert, 0),
+	}
+	if s.alerts != nil {
+		resp.Enabled = true
+		resp.Rules = s.alerts.Rules()
+		resp.Active = s.alerts.Active()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.logger.Error("failed to encode alerts", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// streamStats returns the latest consumer group stats of all inspectors
+func (s *Server) streamStats() []redisstream.GroupStats {
+	var groups []redisstream.GroupStats
+	for _, inspector := range s.inspectors {
+		groups = append(groups, inspector.Snapshot()...)
+	}
+	return groups
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+)
+
+var errUsage = errors.New("invalid arguments, run streamctl -h for usage")
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// parsePosition accepts a stream ID, a special ID ("-", "+", "$", "0") or an RFC3339 time
+func parsePosition(s string) (string, error) {
+	switch s {
+	case "-", "+", "$", "0":
+		return s, nil
+	}
+
+	if _, _, err := redisstream.ParseID(s); err == nil {
+		return s, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", fmt.Errorf("%q is neither a stream ID nor an RFC3339 time", s)
+	}
+	return redisstream.IDFromTime(t), nil
+}
+
+func cmdStreams(ctx context.Context, client *redis.Client, args []string) error {
+	fs := flag.NewFlagSet("streams", flag.ExitOnError)
+	match := fs.String("match", "*", "key pattern")
+	fs.Parse(args)
+
+	keys, err := redisstream.NewAdmin(client).ListStreams(ctx, *match)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		fmt.Println(key)
+	}
+	return nil
+}
+
+func cmdGroups(ctx context.Context, client *redis.Client, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	consumers, groups, err := redisstream.NewAdmin(client).Groups(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	type groupView struct {
+		redis.XInfoGroup
+		ConsumerList []redis.XInfoConsumer `json:"ConsumerList"`
+	}
+
+	views := make([]groupView, 0, len(groups))
+	for _, g := range groups {
+		views = append(views, groupView{XInfoGroup: g, ConsumerList: consumers[g.Name]})
+	}
+	return printJSON(views)
+}
+
+func cmdTail(ctx context.Context, client *redis.Client, args []string) error {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	from := fs.String("from", "$", "start after this ID or time ($ = new entries only)")
+	decoderName := fs.String("decode", "", "decoder to apply ("+strings.Join(redisstream.Decoders(), ", ")+")")
+	limit := fs.Int("n", 0, "stop after N entries (0 = follow forever)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	fromID, err := parsePosition(*from)
+	if err != nil {
+		return err
+	}
+
+	var decode redisstream.DecodeFunc
+	if *decoderName != "" {
+		d, ok := redisstream.Decoder(*decoderName)
+		if !ok {
+			return fmt.Errorf("unknown decoder %q (available: %s)", *decoderName, strings.Join(redisstream.Decoders(), ", "))
+		}
+		decode = d
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	return redisstream.NewAdmin(client).Tail(ctx, fs.Arg(0), fromID, *limit, func(msg redis.XMessage) error {
+		entry := map[string]any{"id": msg.ID}
+		if decode == nil {
+			entry["values"] = msg.Values
+		} else if decoded, err := decode(msg); err != nil {
+			entry["values"] = msg.Values
+			entry["decode_error"] = err.Error()
+		} else {
+			entry["decoded"] = decoded
+		}
+		return enc.Encode(entry)
+	})
+}
+
+func cmdReplay(ctx context.Context, client *redis.Client, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	start := fs.String("start", "-", "first ID or time (inclusive)")
+	end := fs.String("end", "+", "last ID or time (inclusive)")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+
+	startID, err := parsePosition(*start)
+	if err != nil {
+		return err
+	}
+	endID, err := parsePosition(*end)
+	if err != nil {
+		return err
+	}
+
+	copied, err := redisstream.NewAdmin(client).Replay(ctx, fs.Arg(0), fs.Arg(1), startID, endID)
+	fmt.Printf("replayed %d entries from %s to %s\n", copied, fs.Arg(0), fs.Arg(1))
+	return err
+}
+
+func cmdResetGroup(ctx context.Context, client *redis.Client, args []string) error {
+	if len(args) != 3 {
+		return errUsage
+	}
+
+	position, err := parsePosition(args[2])
+	if err != nil {
+		return err
+	}
+	admin := redisstream.NewAdmin(client)
+
+	var at time.Time
+	switch position {
+	case "$":
+		if err := admin.SkipGroup(ctx, args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("group %s on %s skipped to the end of the stream\n", args[1], args[0])
+		return nil
+	case "-", "0":
+		// The zero time redelivers the whole stream
+	case "+":
+		return errors.New(`"+" is not a group position; use "$" to skip to the end of the stream`)
+	default:
+		if at, err = redisstream.IDTime(position); err != nil {
+			return err
+		}
+	}
+
+	id, err := admin.ResetGroup(ctx, args[0], args[1], at)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("group %s on %s reset to %s\n", args[1], args[0], id)
+	return nil
+}
+
+func cmdDeadLetter(ctx context.Context, client *redis.Client, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	admin := redisstream.NewAdmin(client)
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("dlq list", flag.ExitOnError)
+		count := fs.Int64("n", 100, "maximum number of entries")
+		fs.Parse(args[1:])
+		if fs.NArg() != 1 {
+			return errUsage
+		}
+
+		msgs, err := admin.DeadLetters(ctx, fs.Arg(0), *count)
+		if err != nil {
+			return err
+		}
+		return printJSON(msgs)
+
+	case "requeue":
+		if len(args) < 2 {
+			return errUsage
+		}
+
+		requeued, err := admin.RequeueDeadLetters(ctx, args[1], args[2:])
+		fmt.Printf("requeued %d entries into %s\n", requeued, args[1])
+		return err
+
+	case "purge":
+		if len(args) != 2 {
+			return errUsage
+		}
+
+		purged, err := admin.PurgeDeadLetters(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("purged %d entries from %s\n", purged, redisstream.DeadLetterKey(args[1]))
+		return nil
+
+	default:
+		return errUsage
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/inmem"
+
+	// Register stream decoders usable with "tail -decode"
+	_ "github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/prioritzed"
+	_ "github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/consumer"
+)
+
+const usage = `streamctl - Redis stream administration
+
+Usage:
+  streamctl [-redis cache|logging|ranking|question] <command> [flags] [args]
+
+Commands:
+  streams      [-match pattern]                       list stream keys
+  groups       <stream>                               list consumer groups and consumers
+  tail         [-from id|time] [-decode name] [-n N]  follow a stream
+               <stream>
+  replay       [-start id|time] [-end id|time]        copy a range into another stream
+               <src-stream> <dst-stream>
+  reset-group  <stream> <group> <id|time|0|$>         redeliver entries from a point in time,
+                                                      from the start (0) or skip to the end ($)
+  dlq list     [-n N] <stream>                        show dead-lettered entries
+  dlq requeue  <stream> [id...]                       move dead-lettered entries back
+  dlq purge    <stream>                               delete the dead-letter stream
+
+Times are RFC3339 (e.g. 2025-01-02T15:04:05Z). Redis URLs are read from
+CACHE_REDIS_URL, LOGGING_REDIS_URL, RANKING_REDIS_URL and QUESTION_REDIS_URL.
+`
+
+var redisKinds = map[string]int{
+	"cache":    inmem.CacheKey,
+	"logging":  inmem.LoggingKey,
+	"ranking":  inmem.RankingKey,
+	"question": inmem.QuestionKey,
+}
+
+func main() {
+	fs := flag.NewFlagSet("streamctl", flag.ExitOnError)
+	redisKind := fs.String("redis", "cache", "redis connection to use (cache, logging, ranking, question)")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	fs.Parse(os.Args[1:])
+
+	args := fs.Args()
+	if len(args) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	kind, ok := redisKinds[*redisKind]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown redis connection %q\n", *redisKind)
+		os.Exit(2)
+	}
+
+	client := inmem.GetClient(ctx, kind)
+	if client == nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to %s redis\n", *redisKind)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	if err := run(ctx, client, args[0], args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, client *redis.Client, command string, args []string) error {
+	switch command {
+	case "streams":
+		return cmdStreams(ctx, client, args)
+	case "groups":
+		return cmdGroups(ctx, client, args)
+	case "tail":
+		return cmdTail(ctx, client, args)
+	case "replay":
+		return cmdReplay(ctx, client, args)
+	case "reset-group":
+		return cmdResetGroup(ctx, client, args)
+	case "dlq":
+		return cmdDeadLetter(ctx, client, args)
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+	}
+}
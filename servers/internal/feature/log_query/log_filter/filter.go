@@ -0,0 +1,38 @@
+package log_filter
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+)
+
+// ParseSeverities reads comma separated severity names, case-insensitively
+func ParseSeverities(v string) ([]string, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	var severities []string
+	for severity := range strings.SplitSeq(v, ",") {
+		severity = strings.ToUpper(strings.TrimSpace(severity))
+		if _, ok := pb.LogSeverity_value[severity]; !ok {
+			return nil, fmt.Errorf("unknown severity %q", severity)
+		}
+		severities = append(severities, severity)
+	}
+	return severities, nil
+}
+
+// ParseServiceType reads a ServerType name, case-insensitively
+func ParseServiceType(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+
+	serviceType := strings.ToUpper(v)
+	if _, ok := pb.ServerType_value[serviceType]; !ok {
+		return "", fmt.Errorf("unknown service_type %q", v)
+	}
+	return serviceType, nil
+}
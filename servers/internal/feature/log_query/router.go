@@ -0,0 +1,24 @@
+package log_query
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/log_query/search_logs"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/log_query/tail_logs"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/store"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/tail"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/middleware"
+)
+
+// MapRoutes maps the log endpoints. Search is only mapped when querier is set;
+// tail subscribers get tailBuffer entries of room each.
+func MapRoutes(r chi.Router, apiVersion string, querier store.Querier, hub *tail.Hub, tailBuffer int) {
+	r.Route("/"+apiVersion+"/logs", func(r chi.Router) {
+		r.Use(middleware.ApiVersionWith(apiVersion))
+
+		if querier != nil {
+			r.Get("/", search_logs.Map(querier))
+		}
+		r.Get("/tail", tail_logs.MapSSE(hub, tailBuffer))
+		r.Get("/tail/ws", tail_logs.MapWebSocket(hub, tailBuffer))
+	})
+}
@@ -0,0 +1,13 @@
+package search_logs
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SearchLogsResponse struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Entries    []json.RawMessage `json:"entries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
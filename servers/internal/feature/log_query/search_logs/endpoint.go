@@ -0,0 +1,105 @@
+package search_logs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/log_query/log_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/store"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+)
+
+// DefaultRange is searched when from is omitted
+const DefaultRange = time.Hour
+
+// Map serves stored log entries, newest first, a page at a time
+func Map(querier store.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, err := parseQuery(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		page, err := querier.Search(c.Ctx(), q)
+		if store.IsInvalidCursor(err) {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to search logs")
+			return
+		}
+
+		httputil.Ok(c, SearchLogsResponse{
+			From:       q.From,
+			To:         q.To,
+			Entries:    page.Entries,
+			NextCursor: page.NextCursor,
+		})
+	}
+}
+
+// parseQuery reads the search parameters:
+//
+//	from, to      RFC 3339 timestamps of receipt, defaulting to the last hour
+//	severity      comma separated severities, e.g. ERROR,CRITICAL
+//	service_name, service_type, event_type, user_id, session_id, request_id
+//	q             full-text search over data, in web search syntax
+//	cursor        next_cursor of the previous page
+//	limit         page size, up to 1000
+func parseQuery(r *http.Request, now time.Time) (store.Query, error) {
+	params := r.URL.Query()
+	q := store.Query{
+		To:          now,
+		ServiceName: params.Get("service_name"),
+		EventType:   params.Get("event_type"),
+		UserID:      params.Get("user_id"),
+		SessionID:   params.Get("session_id"),
+		RequestID:   params.Get("request_id"),
+		Text:        strings.TrimSpace(params.Get("q")),
+		Cursor:      params.Get("cursor"),
+	}
+
+	if v := params.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return store.Query{}, fmt.Errorf("invalid to: expected an RFC 3339 timestamp")
+		}
+		q.To = to
+	}
+	q.From = q.To.Add(-DefaultRange)
+	if v := params.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return store.Query{}, fmt.Errorf("invalid from: expected an RFC 3339 timestamp")
+		}
+		q.From = from
+	}
+	if !q.From.Before(q.To) {
+		return store.Query{}, fmt.Errorf("from must be before to")
+	}
+
+	var err error
+	if q.Severities, err = log_filter.ParseSeverities(params.Get("severity")); err != nil {
+		return store.Query{}, err
+	}
+	if q.ServiceType, err = log_filter.ParseServiceType(params.Get("service_type")); err != nil {
+		return store.Query{}, err
+	}
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > store.MaxLimit {
+			return store.Query{}, fmt.Errorf("invalid limit: expected a number between 1 and %d", store.MaxLimit)
+		}
+		q.Limit = limit
+	}
+
+	return q, nil
+}
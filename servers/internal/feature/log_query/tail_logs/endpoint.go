@@ -0,0 +1,173 @@
+package tail_logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/log_query/log_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/tail"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+)
+
+// reportInterval is how often the dropped count is reported, or a keep-alive sent
+const reportInterval = 5 * time.Second
+
+// writeTimeout bounds each WebSocket write
+const writeTimeout = 10 * time.Second
+
+var upgrader = websocket.Upgrader{}
+
+// MapSSE streams matching entries as server-sent events: "log" events carry
+// an entry and "dropped" events the number of entries skipped so far
+func MapSSE(hub *tail.Hub, buffer int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, err := parseFilter(r)
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		sub := hub.Subscribe(filter, buffer)
+		defer sub.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		stream(r.Context(), sub, func(event string, payload any) error {
+			if payload == nil {
+				_, err := fmt.Fprint(w, ": keep-alive\n\n")
+				flusher.Flush()
+				return err
+			}
+			data, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		})
+	}
+}
+
+// MapWebSocket streams matching entries as JSON messages:
+// {"type":"log","entry":{...}} and {"type":"dropped","dropped":n}
+func MapWebSocket(hub *tail.Hub, buffer int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, err := parseFilter(r)
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		sub := hub.Subscribe(filter, buffer)
+		defer sub.Close()
+
+		// Viewers only read; a failed read means the connection is gone
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		stream(ctx, sub, func(event string, payload any) error {
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			switch payload.(type) {
+			case nil:
+				return conn.WriteMessage(websocket.PingMessage, nil)
+			case droppedEvent:
+				return conn.WriteJSON(payload)
+			default:
+				return conn.WriteJSON(logMessage{Type: event, Entry: payload})
+			}
+		})
+	}
+}
+
+// droppedEvent reports the entries a subscriber has missed
+type droppedEvent struct {
+	Type    string `json:"type"`
+	Dropped int64  `json:"dropped"`
+}
+
+type logMessage struct {
+	Type  string `json:"type"`
+	Entry any    `json:"entry"`
+}
+
+// stream sends entries until the subscription ends, ctx is done or send fails.
+// A nil payload asks for a keep-alive.
+func stream(ctx context.Context, sub *tail.Subscription, send func(event string, payload any) error) {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	var reported int64
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case entry, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			err = send("log", entry)
+		case <-ticker.C:
+			if dropped := sub.Dropped(); dropped != reported {
+				reported = dropped
+				err = send("dropped", droppedEvent{Type: "dropped", Dropped: dropped})
+			} else {
+				err = send("", nil)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parseFilter reads the tail parameters:
+//
+//	severity      comma separated severities, e.g. ERROR,CRITICAL
+//	service_name, service_type, user_id
+func parseFilter(r *http.Request) (tail.Filter, error) {
+	params := r.URL.Query()
+	filter := tail.Filter{
+		ServiceName: params.Get("service_name"),
+		UserID:      params.Get("user_id"),
+	}
+
+	var err error
+	if filter.Severities, err = log_filter.ParseSeverities(params.Get("severity")); err != nil {
+		return tail.Filter{}, err
+	}
+	if filter.ServiceType, err = log_filter.ParseServiceType(params.Get("service_type")); err != nil {
+		return tail.Filter{}, err
+	}
+	return filter, nil
+}
@@ -0,0 +1,14 @@
+package get_event_counts
+
+import (
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+type GetEventCountsResponse struct {
+	From        time.Time          `json:"from"`
+	To          time.Time          `json:"to"`
+	Granularity store.Granularity  `json:"granularity"`
+	Points      []store.CountPoint `json:"points"`
+}
@@ -0,0 +1,36 @@
+package get_event_counts
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/query_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// Map serves event counts per bucket and event type, optionally for a single user
+func Map(querier store.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, err := query_filter.Parse(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		points, err := querier.EventCounts(c.Ctx(), q)
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to query event counts")
+			return
+		}
+
+		httputil.Ok(c, GetEventCountsResponse{
+			From:        q.From,
+			To:          q.To,
+			Granularity: q.Granularity,
+			Points:      points,
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package get_funnel
+
+import (
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+)
+
+type GetFunnelResponse struct {
+	behavior.FunnelReport
+}
@@ -0,0 +1,37 @@
+package get_funnel
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/query_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+)
+
+// Map serves the step conversion of a funnel for users entering it between from and to
+func Map(querier behavior.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := query_filter.ParseDays(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		report, err := querier.Funnel(c.Ctx(), chi.URLParam(r, "name"), from, to)
+		if errors.Is(err, behavior.ErrUnknownFunnel) {
+			httputil.NotFound(c, err.Error())
+			return
+		}
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to query funnel")
+			return
+		}
+
+		httputil.Ok(c, GetFunnelResponse{FunnelReport: report})
+	}
+}
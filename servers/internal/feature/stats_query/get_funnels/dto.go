@@ -0,0 +1,11 @@
+package get_funnels
+
+type Funnel struct {
+	Name   string   `json:"name"`
+	Steps  []string `json:"steps"`
+	Window string   `json:"window"`
+}
+
+type GetFunnelsResponse struct {
+	Funnels []Funnel `json:"funnels"`
+}
@@ -0,0 +1,23 @@
+package get_funnels
+
+import (
+	"net/http"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+)
+
+// Map serves the configured funnels
+func Map(querier behavior.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c := httputil.NewHttpUtilContext(w, r)
+
+		funnels := querier.Funnels()
+		resp := GetFunnelsResponse{Funnels: make([]Funnel, len(funnels))}
+		for i, f := range funnels {
+			resp.Funnels[i] = Funnel{Name: f.Name, Steps: f.Steps, Window: f.Window.String()}
+		}
+
+		httputil.Ok(c, resp)
+	}
+}
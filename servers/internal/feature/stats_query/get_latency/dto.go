@@ -0,0 +1,15 @@
+package get_latency
+
+import (
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+type GetLatencyResponse struct {
+	From        time.Time            `json:"from"`
+	To          time.Time            `json:"to"`
+	Granularity store.Granularity    `json:"granularity"`
+	Dimension   string               `json:"dimension,omitempty"`
+	Latency     []store.LatencyValue `json:"latency"`
+}
@@ -0,0 +1,57 @@
+package get_latency
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/query_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// Map serves latency percentiles and error rates. Without a dimension it returns
+// the overall latency; with dimension=endpoint or dimension=operation it returns
+// the busiest values, or only the one given by value.
+func Map(querier store.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := query_filter.Unsupported(r, "user_id", "event_type"); err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		q, err := query_filter.Parse(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+
+		dimension := r.URL.Query().Get("dimension")
+		switch dimension {
+		case "", store.DimensionEndpoint, store.DimensionOperation:
+		default:
+			httputil.BadRequestRaw(w, r, fmt.Sprintf("unknown dimension %q (expected endpoint or operation)", dimension))
+			return
+		}
+		q.Value = r.URL.Query().Get("value")
+		if q.Value != "" && dimension == "" {
+			httputil.BadRequestRaw(w, r, "value requires a dimension")
+			return
+		}
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		latency, err := querier.Latency(c.Ctx(), q, dimension)
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to query latency")
+			return
+		}
+
+		httputil.Ok(c, GetLatencyResponse{
+			From:        q.From,
+			To:          q.To,
+			Granularity: q.Granularity,
+			Dimension:   dimension,
+			Latency:     latency,
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package get_retention
+
+import (
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+)
+
+type GetRetentionResponse struct {
+	behavior.RetentionReport
+}
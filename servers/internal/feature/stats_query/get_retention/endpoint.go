@@ -0,0 +1,50 @@
+package get_retention
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/query_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+)
+
+const (
+	// DefaultDays is the number of days after first seen reported by default
+	DefaultDays = 30
+	// MaxDays bounds the days parameter
+	MaxDays = 90
+)
+
+// Map serves daily retention of the users first seen between from and to,
+// up to days days after their first-seen date
+func Map(querier behavior.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := query_filter.ParseDays(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+
+		days := DefaultDays
+		if v := r.URL.Query().Get("days"); v != "" {
+			days, err = strconv.Atoi(v)
+			if err != nil || days < 0 || days > MaxDays {
+				httputil.BadRequestRaw(w, r, fmt.Sprintf("invalid days: expected a number between 0 and %d", MaxDays))
+				return
+			}
+		}
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		report, err := querier.Retention(c.Ctx(), from, to, days)
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to query retention")
+			return
+		}
+
+		httputil.Ok(c, GetRetentionResponse{RetentionReport: report})
+	}
+}
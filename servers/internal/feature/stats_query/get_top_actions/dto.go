@@ -0,0 +1,14 @@
+package get_top_actions
+
+import (
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+type GetTopActionsResponse struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Granularity store.Granularity `json:"granularity"`
+	Actions     []store.TopValue  `json:"actions"`
+}
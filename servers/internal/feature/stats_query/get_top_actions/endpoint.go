@@ -0,0 +1,42 @@
+package get_top_actions
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/query_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// Map serves the most frequent user actions in the range
+func Map(querier store.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := query_filter.Unsupported(r, "user_id", "event_type"); err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		q, err := query_filter.Parse(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		q.EventType = stats.EventTypeUserAction
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		values, err := querier.TopValues(c.Ctx(), q, store.DimensionAction)
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to query top user actions")
+			return
+		}
+
+		httputil.Ok(c, GetTopActionsResponse{
+			From:        q.From,
+			To:          q.To,
+			Granularity: q.Granularity,
+			Actions:     values,
+		})
+	}
+}
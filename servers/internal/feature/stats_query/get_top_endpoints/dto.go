@@ -0,0 +1,14 @@
+package get_top_endpoints
+
+import (
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+type GetTopEndpointsResponse struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Granularity store.Granularity `json:"granularity"`
+	Endpoints   []store.TopValue  `json:"endpoints"`
+}
@@ -0,0 +1,42 @@
+package get_top_endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/query_filter"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/httputil"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// Map serves the most frequent API endpoints in the range
+func Map(querier store.Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := query_filter.Unsupported(r, "user_id", "event_type"); err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		q, err := query_filter.Parse(r, time.Now())
+		if err != nil {
+			httputil.BadRequestRaw(w, r, err.Error())
+			return
+		}
+		q.EventType = stats.EventTypeAPICall
+
+		c := httputil.NewHttpUtilContext(w, r)
+
+		values, err := querier.TopValues(c.Ctx(), q, store.DimensionEndpoint)
+		if err != nil {
+			httputil.ErrWithMsg(c, err, "failed to query top API endpoints")
+			return
+		}
+
+		httputil.Ok(c, GetTopEndpointsResponse{
+			From:        q.From,
+			To:          q.To,
+			Granularity: q.Granularity,
+			Endpoints:   values,
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package query_filter
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+const (
+	// DefaultRange is queried when from is omitted
+	DefaultRange = 24 * time.Hour
+	// MaxBuckets bounds the points a query may return per event type
+	MaxBuckets = 10000
+	// MaxLimit bounds the limit parameter
+	MaxLimit = 100
+	// DefaultDays is queried when from is omitted from a date range
+	DefaultDays = 30
+	// MaxDays bounds date ranges
+	MaxDays = 366
+)
+
+var bucketSize = map[store.Granularity]time.Duration{
+	store.Minute: time.Minute,
+	store.Hour:   time.Hour,
+	store.Day:    24 * time.Hour,
+}
+
+// Parse reads the query parameters shared by the stats endpoints:
+//
+//	from, to     RFC 3339 timestamps, defaulting to the last 24 hours
+//	granularity  minute, hour or day, defaulting to the finest one that fits the range
+//	event_type   api_call, user_action, error or performance
+//	user_id      a user to count events of
+//	limit        number of values to return, up to 100
+func Parse(r *http.Request, now time.Time) (store.Query, error) {
+	params := r.URL.Query()
+	q := store.Query{
+		To:     now,
+		UserID: params.Get("user_id"),
+	}
+
+	if v := params.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return store.Query{}, fmt.Errorf("invalid to: expected an RFC 3339 timestamp")
+		}
+		q.To = to
+	}
+	q.From = q.To.Add(-DefaultRange)
+	if v := params.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return store.Query{}, fmt.Errorf("invalid from: expected an RFC 3339 timestamp")
+		}
+		q.From = from
+	}
+	if !q.From.Before(q.To) {
+		return store.Query{}, fmt.Errorf("from must be before to")
+	}
+
+	q.Granularity = store.AutoGranularity(q.From, q.To)
+	if v := params.Get("granularity"); v != "" {
+		g, err := store.ParseGranularity(v)
+		if err != nil {
+			return store.Query{}, err
+		}
+		q.Granularity = g
+	}
+	if buckets := q.To.Sub(q.From) / bucketSize[q.Granularity]; buckets > MaxBuckets {
+		return store.Query{}, fmt.Errorf("range spans %d %s buckets (max %d); use a coarser granularity",
+			buckets, q.Granularity, MaxBuckets)
+	}
+
+	if v := params.Get("event_type"); v != "" {
+		switch t := stats.EventType(v); t {
+		case stats.EventTypeAPICall, stats.EventTypeUserAction, stats.EventTypeError, stats.EventTypePerformance:
+			q.EventType = t
+		default:
+			return store.Query{}, fmt.Errorf("unknown event_type %q", v)
+		}
+	}
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return store.Query{}, fmt.Errorf("invalid limit: expected a number between 1 and %d", MaxLimit)
+		}
+		q.Limit = limit
+	}
+
+	return q, nil
+}
+
+// ParseDays reads a range of whole UTC days for the cohort endpoints:
+//
+//	from, to  YYYY-MM-DD dates, both included, defaulting to the last 30 days
+//
+// The returned to is the day after the last one, so the range is [from, to).
+func ParseDays(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	params := r.URL.Query()
+	last := store.Day.Truncate(now)
+
+	if v := params.Get("to"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: expected a YYYY-MM-DD date")
+		}
+		last = day
+	}
+	first := last.AddDate(0, 0, 1-DefaultDays)
+	if v := params.Get("from"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: expected a YYYY-MM-DD date")
+		}
+		first = day
+	}
+
+	if first.After(last) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must not be after to")
+	}
+	to := last.AddDate(0, 0, 1)
+	if days := int(to.Sub(first) / (24 * time.Hour)); days > MaxDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("range spans %d days (max %d)", days, MaxDays)
+	}
+	return first, to, nil
+}
+
+// Unsupported returns an error naming the first of the given parameters that is set on r
+func Unsupported(r *http.Request, names ...string) error {
+	params := r.URL.Query()
+	for _, name := range names {
+		if params.Has(name) {
+			return fmt.Errorf("%s is not supported by this endpoint", name)
+		}
+	}
+	return nil
+}
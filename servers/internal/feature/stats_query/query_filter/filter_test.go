@@ -0,0 +1,92 @@
+package query_filter
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name    string
+		query   string
+		want    store.Query
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  store.Query{From: now.Add(-DefaultRange), To: now, Granularity: store.Hour},
+		},
+		{
+			name:  "explicit range and filters",
+			query: "from=2026-10-18T10:00:00Z&to=2026-10-18T11:00:00Z&event_type=api_call&user_id=u1&limit=100",
+			want: store.Query{
+				From:        time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+				To:          time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC),
+				Granularity: store.Minute,
+				EventType:   stats.EventTypeAPICall,
+				UserID:      "u1",
+				Limit:       100,
+			},
+		},
+		{name: "inverted range", query: "from=2026-10-18T11:00:00Z&to=2026-10-18T10:00:00Z", wantErr: true},
+		{name: "empty range", query: "from=2026-10-18T11:00:00Z&to=2026-10-18T11:00:00Z", wantErr: true},
+		{name: "invalid from", query: "from=yesterday", wantErr: true},
+		{name: "invalid to", query: "to=2026-10-18", wantErr: true},
+		{name: "bad granularity", query: "granularity=week", wantErr: true},
+		{name: "too many buckets", query: "from=2026-09-01T00:00:00Z&granularity=minute", wantErr: true},
+		{name: "unknown event type", query: "event_type=click", wantErr: true},
+		{name: "limit below range", query: "limit=0", wantErr: true},
+		{name: "limit above range", query: "limit=101", wantErr: true},
+		{name: "limit not a number", query: "limit=ten", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := Parse(httptest.NewRequest("GET", "/?"+c.query, nil), now)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: error = %v, want error %v", c.name, err, c.wantErr)
+			continue
+		}
+		if !c.wantErr && got != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	day := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	cases := []struct {
+		query    string
+		from, to time.Time
+		wantErr  bool
+	}{
+		{query: "", from: day("2026-09-19"), to: day("2026-10-19")},
+		{query: "from=2026-10-01&to=2026-10-01", from: day("2026-10-01"), to: day("2026-10-02")},
+		{query: "from=2026-10-02&to=2026-10-01", wantErr: true},
+		{query: "from=2025-01-01&to=2026-10-01", wantErr: true},
+		{query: "from=2026-10-01T00:00:00Z", wantErr: true},
+	}
+
+	for _, c := range cases {
+		from, to, err := ParseDays(httptest.NewRequest("GET", "/?"+c.query, nil), now)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseDays(%q) error = %v, want error %v", c.query, err, c.wantErr)
+			continue
+		}
+		if !c.wantErr && (!from.Equal(c.from) || !to.Equal(c.to)) {
+			t.Errorf("ParseDays(%q) = [%s, %s), want [%s, %s)", c.query, from, to, c.from, c.to)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package stats_query
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_event_counts"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_funnel"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_funnels"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_latency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_retention"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_top_actions"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_top_endpoints"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/middleware"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+func MapRoutes(r chi.Router, apiVersion string, querier store.Querier, cohorts behavior.Querier) {
+	r.Route("/"+apiVersion+"/stats", func(r chi.Router) {
+		r.Use(middleware.ApiVersionWith(apiVersion))
+
+		r.Get("/events", get_event_counts.Map(querier))
+		r.Get("/endpoints/top", get_top_endpoints.Map(querier))
+		r.Get("/actions/top", get_top_actions.Map(querier))
+		r.Get("/latency", get_latency.Map(querier))
+		r.Get("/funnels", get_funnels.Map(cohorts))
+		r.Get("/funnels/{name}", get_funnel.Map(cohorts))
+		r.Get("/retention", get_retention.Map(cohorts))
+	})
+}
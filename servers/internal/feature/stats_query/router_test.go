@@ -0,0 +1,181 @@
+package stats_query
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_event_counts"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_funnel"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_funnels"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_latency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_retention"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_top_actions"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/feature/stats_query/get_top_endpoints"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// newTestRouter serves the stats endpoints from memory stores holding one API
+// call and one user completing the checkout funnel
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+
+	rollups := store.NewMemoryStore()
+	records := []store.Record{
+		{
+			EventID:    "call-1",
+			Type:       stats.EventTypeAPICall,
+			Timestamp:  now,
+			Dimensions: map[string]string{store.DimensionEndpoint: "GET /ping"},
+			Latency:    &store.Latency{Dimension: store.DimensionEndpoint, Value: "GET /ping", Duration: 20 * time.Millisecond},
+		},
+		{
+			EventID:    "action-1",
+			Type:       stats.EventTypeUserAction,
+			Timestamp:  now,
+			Dimensions: map[string]string{store.DimensionAction: "view"},
+		},
+		{
+			EventID:    "action-2",
+			Type:       stats.EventTypeUserAction,
+			Timestamp:  now,
+			Dimensions: map[string]string{store.DimensionAction: "buy"},
+		},
+	}
+	for _, rec := range records {
+		if err := rollups.Record(ctx, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	funnels := []behavior.Funnel{{Name: "checkout", Steps: []string{"view", "buy"}, Window: time.Hour}}
+	tracker := behavior.NewTracker(slog.New(slog.DiscardHandler), behavior.NewMemoryStore(), funnels)
+	for _, action := range []string{"view", "buy"} {
+		if err := tracker.Track(ctx, "u1", action, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := chi.NewRouter()
+	MapRoutes(r, "v1", rollups, tracker)
+	return r
+}
+
+// get serves a request and decodes the data of the response into data
+func get(t *testing.T, router http.Handler, target string, wantStatus int, data any) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	if w.Code != wantStatus {
+		t.Fatalf("GET %s: expected status %d, got %d: %s", target, wantStatus, w.Code, w.Body)
+	}
+	if data == nil {
+		return
+	}
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body.Data, data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetEventCounts(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_event_counts.GetEventCountsResponse
+	get(t, router, "/v1/stats/events?event_type=user_action", http.StatusOK, &resp)
+	var total int64
+	for _, p := range resp.Points {
+		if p.EventType != stats.EventTypeUserAction {
+			t.Errorf("expected only user actions, got %s", p.EventType)
+		}
+		total += p.Count
+	}
+	if total != 2 {
+		t.Errorf("expected 2 user actions, got %d", total)
+	}
+
+	get(t, router, "/v1/stats/events?granularity=week", http.StatusBadRequest, nil)
+}
+
+func TestGetTopEndpoints(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_top_endpoints.GetTopEndpointsResponse
+	get(t, router, "/v1/stats/endpoints/top", http.StatusOK, &resp)
+	if len(resp.Endpoints) != 1 || resp.Endpoints[0] != (store.TopValue{Value: "GET /ping", Count: 1}) {
+		t.Errorf("unexpected endpoints %+v", resp.Endpoints)
+	}
+
+	get(t, router, "/v1/stats/endpoints/top?user_id=u1", http.StatusBadRequest, nil)
+}
+
+func TestGetTopActions(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_top_actions.GetTopActionsResponse
+	get(t, router, "/v1/stats/actions/top?limit=1", http.StatusOK, &resp)
+	if len(resp.Actions) != 1 || resp.Actions[0].Count != 1 {
+		t.Errorf("expected the single top action, got %+v", resp.Actions)
+	}
+}
+
+func TestGetLatency(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_latency.GetLatencyResponse
+	get(t, router, "/v1/stats/latency?dimension=endpoint", http.StatusOK, &resp)
+	if len(resp.Latency) != 1 || resp.Latency[0].Value != "GET /ping" || resp.Latency[0].Count != 1 {
+		t.Errorf("unexpected latency %+v", resp.Latency)
+	}
+
+	get(t, router, "/v1/stats/latency?value=GET+/ping", http.StatusBadRequest, nil)
+}
+
+func TestGetFunnels(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_funnels.GetFunnelsResponse
+	get(t, router, "/v1/stats/funnels", http.StatusOK, &resp)
+	if len(resp.Funnels) != 1 || resp.Funnels[0].Name != "checkout" || resp.Funnels[0].Window != "1h0m0s" {
+		t.Errorf("unexpected funnels %+v", resp.Funnels)
+	}
+}
+
+func TestGetFunnel(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_funnel.GetFunnelResponse
+	get(t, router, "/v1/stats/funnels/checkout", http.StatusOK, &resp)
+	if len(resp.Steps) != 2 || resp.Steps[0].Users != 1 || resp.Steps[1].Users != 1 {
+		t.Errorf("expected the user to complete both steps, got %+v", resp.Steps)
+	}
+
+	get(t, router, "/v1/stats/funnels/signup", http.StatusNotFound, nil)
+}
+
+func TestGetRetention(t *testing.T) {
+	router := newTestRouter(t)
+
+	var resp get_retention.GetRetentionResponse
+	get(t, router, "/v1/stats/retention?days=1", http.StatusOK, &resp)
+	if len(resp.Cohorts) != 1 || resp.Cohorts[0].Users != 1 {
+		t.Errorf("expected one cohort of one user, got %+v", resp.Cohorts)
+	}
+
+	get(t, router, "/v1/stats/retention?days=-1", http.StatusBadRequest, nil)
+}
@@ -0,0 +1,49 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/event"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+type memoryQuarantine struct {
+	entries []sink.Entry
+}
+
+func (q *memoryQuarantine) Quarantine(entry sink.Entry) {
+	q.entries = append(q.entries, entry)
+}
+
+func (q *memoryQuarantine) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestChecker(t *testing.T) {
+	checker := NewChecker(event.Default())
+	q := &memoryQuarantine{}
+	quarantine := checker.QuarantineStage(q)
+
+	apply := func(eventType, data string) (*sink.Entry, bool) {
+		entry := &sink.Entry{LogMessage: model.LogMessage{EventType: eventType, Data: data}}
+		return entry, checker.Apply(entry) && quarantine.Apply(entry)
+	}
+
+	if entry, kept := apply(event.EventTypeAppLog, `{"msg":"hello"}`); !kept || entry.EventIssue != "" {
+		t.Fatalf("expected a valid entry to be kept untagged, got %q", entry.EventIssue)
+	}
+	if entry, kept := apply("Mystery_Event", ``); kept || !strings.HasPrefix(entry.EventIssue, string(event.ReasonUnknownType)) {
+		t.Fatalf("expected an unknown event to be quarantined, got %q", entry.EventIssue)
+	}
+	if entry, kept := apply(event.EventTypeLuckySpinClaim, `{"reward_id":"r1"}`); kept || !strings.HasPrefix(entry.EventIssue, string(event.ReasonInvalidPayload)) {
+		t.Fatalf("expected an invalid payload to be quarantined, got %q", entry.EventIssue)
+	}
+
+	stats := checker.Stats()
+	if stats.Checked != 3 || stats.UnknownType != 1 || stats.InvalidPayload != 1 || stats.Quarantined != 2 || len(q.entries) != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+// Quarantine keeps entries that do not conform to the event catalog, for
+// inspection. Quarantine must not block.
+type Quarantine interface {
+	Quarantine(entry sink.Entry)
+	Close(ctx context.Context) error
+}
+
+// Compile-time checks to ensure the quarantines implement Quarantine
+var (
+	_ Quarantine = (*SinkQuarantine)(nil)
+	_ Quarantine = (*LogQuarantine)(nil)
+)
+
+// SinkQuarantine writes quarantined entries to a sink through its own pipeline,
+// typically a FileSink apart from the regular logs
+type SinkQuarantine struct {
+	pipeline *sink.Pipeline
+}
+
+// NewSinkQuarantine starts a pipeline writing to s
+func NewSinkQuarantine(logger *slog.Logger, s sink.Sink) *SinkQuarantine {
+	return &SinkQuarantine{pipeline: sink.NewPipeline(logger, sink.Config{}, s)}
+}
+
+func (q *SinkQuarantine) Quarantine(entry sink.Entry) {
+	q.pipeline.Publish(entry)
+}
+
+// Close flushes the queued entries and closes the sink
+func (q *SinkQuarantine) Close(ctx context.Context) error {
+	return q.pipeline.Close(ctx)
+}
+
+// LogQuarantine only logs quarantined entries, for setups without a place to keep them
+type LogQuarantine struct {
+	logger *slog.Logger
+}
+
+// NewLogQuarantine creates a quarantine that logs entries at warn level
+func NewLogQuarantine(logger *slog.Logger) *LogQuarantine {
+	return &LogQuarantine{logger: logger}
+}
+
+func (q *LogQuarantine) Quarantine(entry sink.Entry) {
+	q.logger.Warn("quarantined log",
+		"log_id", entry.LogID,
+		"event_type", entry.EventType,
+		"service_name", entry.ServiceName,
+		"issue", entry.EventIssue,
+		"data", entry.Data)
+}
+
+func (q *LogQuarantine) Close(ctx context.Context) error {
+	return nil
+}
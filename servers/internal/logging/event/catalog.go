@@ -0,0 +1,277 @@
+// Package event defines the event types of analytics logs. The catalog in
+// catalog.yaml declares every event type with the fields of its data payload;
+// the constants in type.go are generated from it.
+package event
+
+//go:generate go run ./gen
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalog.yaml
+var catalogYAML []byte
+
+// FieldType is the JSON type of a payload field
+type FieldType string
+
+const (
+	FieldString  FieldType = "string"
+	FieldInteger FieldType = "integer"
+	FieldNumber  FieldType = "number"
+	FieldBoolean FieldType = "boolean"
+	FieldObject  FieldType = "object"
+	FieldArray   FieldType = "array"
+)
+
+// Field is a top-level key of the data payload
+type Field struct {
+	Name     string    `yaml:"name"`
+	Type     FieldType `yaml:"type"`
+	Required bool      `yaml:"required"`
+}
+
+// Event is a catalog entry
+type Event struct {
+	Name        string  `yaml:"name"`
+	Const       string  `yaml:"const"`
+	Description string  `yaml:"description"`
+	Fields      []Field `yaml:"fields"`
+	// Aliases are former constant names, generated as deprecated aliases of Const
+	Aliases []string `yaml:"aliases"`
+}
+
+// Reason explains why a log does not conform to the catalog
+type Reason string
+
+const (
+	ReasonUnknownType    Reason = "unknown_type"
+	ReasonInvalidPayload Reason = "invalid_payload"
+)
+
+// ValidationError is returned by Validate for logs that do not conform to the catalog
+type ValidationError struct {
+	Reason Reason
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// AsValidationError reports whether err is a catalog validation error
+func AsValidationError(err error) (*ValidationError, bool) {
+	var verr *ValidationError
+	ok := errors.As(err, &verr)
+	return verr, ok
+}
+
+// Catalog is a parsed event catalog
+type Catalog struct {
+	events []Event
+	byName map[string]int
+}
+
+type catalogFile struct {
+	Events []Event `yaml:"events"`
+}
+
+var defaultCatalog = sync.OnceValue(func() *Catalog {
+	c, err := ParseCatalog(catalogYAML)
+	if err != nil {
+		panic("event: invalid embedded catalog: " + err.Error())
+	}
+	return c
+})
+
+// Default returns the catalog embedded from catalog.yaml
+func Default() *Catalog {
+	return defaultCatalog()
+}
+
+// LoadCatalog reads a catalog from a YAML file
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event catalog: %w", err)
+	}
+	return ParseCatalog(data)
+}
+
+// ParseCatalog parses and checks a YAML catalog. Keys other than events may
+// hold YAML anchors shared by several events.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var file catalogFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse event catalog: %w", err)
+	}
+
+	c := &Catalog{events: file.Events, byName: make(map[string]int, len(file.Events))}
+	consts := make(map[string]bool, len(file.Events))
+	for i := range c.events {
+		e := &c.events[i]
+		if err := e.check(); err != nil {
+			return nil, fmt.Errorf("invalid event %d: %w", i, err)
+		}
+		if _, ok := c.byName[e.Name]; ok {
+			return nil, fmt.Errorf("duplicate event %q", e.Name)
+		}
+		if consts[e.Const] {
+			return nil, fmt.Errorf("duplicate constant %s for event %q", e.Const, e.Name)
+		}
+		c.byName[e.Name] = i
+		consts[e.Const] = true
+		for _, alias := range e.Aliases {
+			if consts[alias] {
+				return nil, fmt.Errorf("duplicate constant %s for event %q", alias, e.Name)
+			}
+			consts[alias] = true
+		}
+	}
+	return c, nil
+}
+
+// check validates the event and derives its constant name
+func (e *Event) check() error {
+	if e.Name == "" {
+		return errors.New("name is required")
+	}
+	if e.Const == "" {
+		e.Const = constName(e.Name)
+	}
+	if !token.IsIdentifier(e.Const) || !token.IsExported(e.Const) {
+		return fmt.Errorf("constant %q of event %q is not an exported identifier", e.Const, e.Name)
+	}
+	for _, alias := range e.Aliases {
+		if !token.IsIdentifier(alias) || !token.IsExported(alias) {
+			return fmt.Errorf("alias %q of event %q is not an exported identifier", alias, e.Name)
+		}
+	}
+
+	names := make(map[string]bool, len(e.Fields))
+	for _, f := range e.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("event %q has a field without a name", e.Name)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("event %q has a duplicate field %q", e.Name, f.Name)
+		}
+		names[f.Name] = true
+
+		switch f.Type {
+		case FieldString, FieldInteger, FieldNumber, FieldBoolean, FieldObject, FieldArray:
+		default:
+			return fmt.Errorf("field %q of event %q has unknown type %q", f.Name, e.Name, f.Type)
+		}
+	}
+	return nil
+}
+
+// constName derives a constant from an event name: CoinPack2_Purchase becomes
+// EventTypeCoinPack2Purchase and app_log EventTypeAppLog
+func constName(name string) string {
+	var b strings.Builder
+	b.WriteString("EventType")
+	for part := range strings.FieldsFuncSeq(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// Events lists the catalog entries in declaration order
+func (c *Catalog) Events() []Event {
+	return c.events
+}
+
+// Lookup returns the entry of an event type
+func (c *Catalog) Lookup(name string) (Event, bool) {
+	i, ok := c.byName[name]
+	if !ok {
+		return Event{}, false
+	}
+	return c.events[i], true
+}
+
+// Validate checks that the event type is in the catalog and that data, a JSON
+// object or empty, carries its required fields with the declared types.
+// Failures are reported as *ValidationError.
+func (c *Catalog) Validate(eventType, data string) error {
+	e, ok := c.Lookup(eventType)
+	if !ok {
+		return &ValidationError{Reason: ReasonUnknownType, Err: fmt.Errorf("event type %q is not in the catalog", eventType)}
+	}
+
+	payload := map[string]any{}
+	if data != "" {
+		decoder := json.NewDecoder(strings.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&payload); err != nil || payload == nil {
+			return &ValidationError{Reason: ReasonInvalidPayload, Err: errors.New("data is not a JSON object")}
+		}
+		// Decode stops after the first value; anything but whitespace may follow it
+		if _, err := decoder.Token(); err != io.EOF {
+			return &ValidationError{Reason: ReasonInvalidPayload, Err: errors.New("data has trailing content after the JSON object")}
+		}
+	}
+
+	var errs []error
+	for _, f := range e.Fields {
+		value, ok := payload[f.Name]
+		if !ok || value == nil {
+			if f.Required {
+				errs = append(errs, fmt.Errorf("missing field %q", f.Name))
+			}
+			continue
+		}
+		if !f.Type.matches(value) {
+			errs = append(errs, fmt.Errorf("field %q is not of type %s", f.Name, f.Type))
+		}
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Reason: ReasonInvalidPayload, Err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+// matches reports whether a value decoded with UseNumber has the type
+func (t FieldType) matches(value any) bool {
+	switch t {
+	case FieldString:
+		_, ok := value.(string)
+		return ok
+	case FieldInteger:
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case FieldNumber:
+		_, ok := value.(json.Number)
+		return ok
+	case FieldBoolean:
+		_, ok := value.(bool)
+		return ok
+	case FieldObject:
+		_, ok := value.(map[string]any)
+		return ok
+	case FieldArray:
+		_, ok := value.([]any)
+		return ok
+	}
+	return false
+}
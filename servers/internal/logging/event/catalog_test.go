@@ -0,0 +1,73 @@
+package event
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestTypesAreGenerated(t *testing.T) {
+	source, err := Generate(Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("type.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, current) {
+		t.Fatal("type.go is out of date with catalog.yaml; run go generate ./internal/logging/event")
+	}
+	if EventTypeCoinPack2Purchase != "CoinPack2_Purchase" {
+		t.Fatalf("unexpected constant %q", EventTypeCoinPack2Purchase)
+	}
+	// Former names stay available as deprecated aliases
+	if EventDailyRewardsClaim != EventTypeDailyRewardsClaim || Eventtypecoinpack2Purchase != EventTypeCoinPack2Purchase {
+		t.Fatal("expected the former constant names to alias the generated ones")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := Default()
+	tests := []struct {
+		eventType string
+		data      string
+		reason    Reason
+	}{
+		{EventTypeCoinPack1Purchase, `{"product_id":"coin_1","transaction_id":"t1","price":0.99,"currency":"USD","extra":1}`, ""},
+		{EventTypeProfileSave, ``, ""},
+		{"Unknown_Event", `{}`, ReasonUnknownType},
+		{EventTypeCoinPack1Purchase, `{"product_id":"coin_1","price":"0.99","currency":"USD"}`, ReasonInvalidPayload},
+		{EventTypeClassicPlay, `{"stage":1.5}`, ReasonInvalidPayload},
+		{EventTypeClassicPlay, `{"stage":null}`, ReasonInvalidPayload},
+		{EventTypeClassicPlay, `[1]`, ReasonInvalidPayload},
+		{EventTypeClassicPlay, `{"stage":3,"score":null}`, ""},
+		{EventTypeClassicPlay, "{\"stage\":3}\n", ""},
+		{EventTypeClassicPlay, `{"stage":3} garbage`, ReasonInvalidPayload},
+		{EventTypeClassicPlay, `{"stage":3} {}`, ReasonInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		err := c.Validate(tt.eventType, tt.data)
+		verr, ok := AsValidationError(err)
+		switch {
+		case tt.reason == "" && err != nil:
+			t.Errorf("%s %s: unexpected error %v", tt.eventType, tt.data, err)
+		case tt.reason != "" && (!ok || verr.Reason != tt.reason):
+			t.Errorf("%s %s: expected %s, got %v", tt.eventType, tt.data, tt.reason, err)
+		}
+	}
+}
+
+func TestParseCatalogRejectsInvalidEvents(t *testing.T) {
+	for _, data := range []string{
+		"events: [{name: A}, {name: A}]",
+		"events: [{name: A_B}, {name: AB}]",
+		"events: [{name: A, fields: [{name: x, type: date}]}]",
+		"events: [{name: A, const: lower}]",
+	} {
+		if _, err := ParseCatalog([]byte(data)); err == nil {
+			t.Errorf("expected an error for %s", data)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Command gen writes type.go from catalog.yaml. It runs from the event
+// package directory through go generate.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/event"
+)
+
+func main() {
+	catalog, err := event.LoadCatalog("catalog.yaml")
+	if err != nil {
+		log.Fatal(err)
+	}
+	source, err := event.Generate(catalog)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("type.go", source, 0o644); err != nil {
+		log.Fatalf("failed to write type.go: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+)
+
+// Generate renders the Go source of the event type constants in a catalog
+func Generate(c *Catalog) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by go generate from catalog.yaml; DO NOT EDIT.\n\n")
+	b.WriteString("package event\n\n")
+	b.WriteString("const (\n")
+	for _, e := range c.Events() {
+		if e.Description != "" {
+			fmt.Fprintf(&b, "\t// %s\n", e.Description)
+		}
+		fmt.Fprintf(&b, "\t%s = %s\n", e.Const, strconv.Quote(e.Name))
+		for _, alias := range e.Aliases {
+			fmt.Fprintf(&b, "\t// Deprecated: use %s.\n", e.Const)
+			fmt.Fprintf(&b, "\t%s = %s\n", alias, e.Const)
+		}
+	}
+	b.WriteString(")\n")
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format event types: %w", err)
+	}
+	return source, nil
+}
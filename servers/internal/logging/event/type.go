@@ -1,28 +1,60 @@
+// Code generated by go generate from catalog.yaml; DO NOT EDIT.
+
 package event
 
 const (
-	EventTypeCoinPack1Purchase     = "CoinPack1_Purchase"
-	Eventtypecoinpack2Purchase     = "CoinPack2_Purchase"
-	EventTypeCoinPack3Purchase     = "CoinPack3_Purchase"
-	EventTypeCoinPack4Purchase     = "CoinPack4_Purchase"
-	EventTypeCoinPack5Purchase     = "CoinPack5_Purchase"
-	EventTypeCoinPack6Purchase     = "CoinPack6_Purchase"
-	EventTypeGemPack1Purchase      = "GemPack1_Purchase"
-	EventTypeGemPack2Purchase      = "GemPack2_Purchase"
-	EventTypeGemPack3Purchase      = "GemPack3_Purchase"
-	EventTypeGemPack4Purchase      = "GemPack4_Purchase"
-	EventTypeGemPack5Purchase      = "GemPack5_Purchase"
-	EventTypeGemPack6Purchase      = "GemPack6_Purchase"
-	EventTypeAddictPackPurchase    = "AddictPack_Purchase"
-	EventTypeBOGO1Purchase         = "BOGO1_Purchase"
-	EventTypeBOGO2Purchase         = "BOGO2_Purchase"
+	// Application log shipped by the slog shipper
+	EventTypeAppLog = "app_log"
+	// Coin pack 1 bought in the shop
+	EventTypeCoinPack1Purchase = "CoinPack1_Purchase"
+	// Coin pack 2 bought in the shop
+	EventTypeCoinPack2Purchase = "CoinPack2_Purchase"
+	// Deprecated: use EventTypeCoinPack2Purchase.
+	Eventtypecoinpack2Purchase = EventTypeCoinPack2Purchase
+	// Coin pack 3 bought in the shop
+	EventTypeCoinPack3Purchase = "CoinPack3_Purchase"
+	// Coin pack 4 bought in the shop
+	EventTypeCoinPack4Purchase = "CoinPack4_Purchase"
+	// Coin pack 5 bought in the shop
+	EventTypeCoinPack5Purchase = "CoinPack5_Purchase"
+	// Coin pack 6 bought in the shop
+	EventTypeCoinPack6Purchase = "CoinPack6_Purchase"
+	// Gem pack 1 bought in the shop
+	EventTypeGemPack1Purchase = "GemPack1_Purchase"
+	// Gem pack 2 bought in the shop
+	EventTypeGemPack2Purchase = "GemPack2_Purchase"
+	// Gem pack 3 bought in the shop
+	EventTypeGemPack3Purchase = "GemPack3_Purchase"
+	// Gem pack 4 bought in the shop
+	EventTypeGemPack4Purchase = "GemPack4_Purchase"
+	// Gem pack 5 bought in the shop
+	EventTypeGemPack5Purchase = "GemPack5_Purchase"
+	// Gem pack 6 bought in the shop
+	EventTypeGemPack6Purchase = "GemPack6_Purchase"
+	// Addict pack bought in the shop
+	EventTypeAddictPackPurchase = "AddictPack_Purchase"
+	// Buy-one-get-one offer 1 bought in the shop
+	EventTypeBOGO1Purchase = "BOGO1_Purchase"
+	// Buy-one-get-one offer 2 bought in the shop
+	EventTypeBOGO2Purchase = "BOGO2_Purchase"
+	// Special offer 1 bought in the shop
 	EventTypeSpecialOffer1Purchase = "SpecialOffer1_Purchase"
-	EventTypeLuckySpinSpin         = "LuckySpin_Spin"
-	EventTypeLuckySpinClaim        = "LuckySpin_Claim"
-	EventTypeClassicPlay           = "Classic_Play"
-	EventTypeClassicClaim          = "Classic_Claim"
-	EventTypeTrueFalsePlay         = "TrueFalse_Play"
-	EventTypeTrueFalseClaim        = "TrueFalse_Claim"
-	EventDailyRewardsClaim         = "DailyRewards_Claim"
-	EventTypeProfileSave           = "Profile_Save"
+	// Lucky spin wheel spun
+	EventTypeLuckySpinSpin = "LuckySpin_Spin"
+	// Lucky spin reward claimed
+	EventTypeLuckySpinClaim = "LuckySpin_Claim"
+	// Classic mode stage played
+	EventTypeClassicPlay = "Classic_Play"
+	// Classic mode stage reward claimed
+	EventTypeClassicClaim = "Classic_Claim"
+	// True/false mode stage played
+	EventTypeTrueFalsePlay = "TrueFalse_Play"
+	// True/false mode stage reward claimed
+	EventTypeTrueFalseClaim = "TrueFalse_Claim"
+	// Daily reward claimed
+	EventTypeDailyRewardsClaim = "DailyRewards_Claim"
+	// Deprecated: use EventTypeDailyRewardsClaim.
+	EventDailyRewardsClaim = EventTypeDailyRewardsClaim
+	// User profile saved
+	EventTypeProfileSave = "Profile_Save"
 )
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// field pairs a LogRequest field with the LogMessage field of the same name
+type field struct {
+	name  string
+	index int
+	desc  protoreflect.FieldDescriptor
+}
+
+// fields covers every LogRequest field. Building it panics when the proto and
+// LogMessage diverge, so a field added to one but not the other fails at startup.
+var fields = buildFields()
+
+func buildFields() []field {
+	typ := reflect.TypeFor[LogMessage]()
+	byName := make(map[string]int, typ.NumField())
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		byName[name] = i
+	}
+
+	descs := (&pb.LogRequest{}).ProtoReflect().Descriptor().Fields()
+	out := make([]field, 0, descs.Len())
+	for i := range descs.Len() {
+		desc := descs.Get(i)
+		name := string(desc.Name())
+		index, ok := byName[name]
+		if !ok {
+			panic(fmt.Sprintf("model: LogRequest field %s has no LogMessage field", name))
+		}
+		delete(byName, name)
+		out = append(out, field{name: name, index: index, desc: desc})
+	}
+
+	if len(byName) > 0 {
+		missing := make([]string, 0, len(byName))
+		for name := range byName {
+			missing = append(missing, name)
+		}
+		slices.Sort(missing)
+		panic(fmt.Sprintf("model: LogMessage fields without a LogRequest field: %s", strings.Join(missing, ", ")))
+	}
+	return out
+}
+
+// FieldNames lists the field names in proto order
+func FieldNames() []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// StringField returns the string field of m with the given name, or false
+// when there is no such field or it is not a string
+func (m *LogMessage) StringField(name string) (*string, bool) {
+	for _, f := range fields {
+		if f.name != name {
+			continue
+		}
+		fv := reflect.ValueOf(m).Elem().Field(f.index)
+		if fv.Kind() != reflect.String {
+			return nil, false
+		}
+		return fv.Addr().Interface().(*string), true
+	}
+	return nil, false
+}
@@ -0,0 +1,56 @@
+// Package model is the canonical log model shared by the gRPC and stream
+// ingestion paths. Converters to and from pb.LogRequest and Redis stream
+// fields are driven by a field table built from the protobuf descriptor.
+package model
+
+import "time"
+
+// LogMessage is a log entry. Every field mirrors the LogRequest field named by
+// its json tag, which is also its Redis stream field; enums hold value names.
+type LogMessage struct {
+	// Common fields (1-15)
+	LogID          string    `json:"log_id,omitempty"`
+	RequestID      string    `json:"request_id,omitempty"`
+	SessionID      string    `json:"session_id,omitempty"`
+	ServerID       string    `json:"server_id,omitempty"`
+	EventType      string    `json:"event_type,omitempty"`
+	Severity       string    `json:"severity,omitempty"` // "DEBUG", "INFO", "WARNING", "ERROR", "CRITICAL"
+	ServerTime     time.Time `json:"server_time,omitzero"`
+	ServiceType    string    `json:"service_type,omitempty"` // "INGAME", "QUESTION", "RANKING", "OUTGAME", "STATS", "LOGGING"
+	ServiceName    string    `json:"service_name,omitempty"`
+	ServiceVersion string    `json:"service_version,omitempty"`
+	Environment    string    `json:"environment,omitempty"`
+	ServerIP       string    `json:"server_ip,omitempty"`
+	DurationMs     int64     `json:"duration_ms,omitempty"`
+	StatusCode     int32     `json:"status_code,omitempty"`
+	Method         string    `json:"method,omitempty"`
+
+	// Server fields (16-99)
+	Endpoint          string  `json:"endpoint,omitempty"`
+	MemoryUsageMb     int64   `json:"memory_usage_mb,omitempty"`
+	CPUUsagePercent   float32 `json:"cpu_usage_percent,omitempty"`
+	GoroutineCount    int32   `json:"goroutine_count,omitempty"`
+	ActiveConnections int32   `json:"active_connections,omitempty"`
+	GameMode          string  `json:"game_mode,omitempty"`
+	RoomID            string  `json:"room_id,omitempty"`
+	Data              string  `json:"data,omitempty"`
+
+	// Client fields (100-199)
+	ClientIP            string  `json:"client_ip,omitempty"`
+	UserID              string  `json:"user_id,omitempty"`
+	DeviceID            string  `json:"device_id,omitempty"`
+	GameVersion         string  `json:"game_version,omitempty"`
+	Platform            string  `json:"platform,omitempty"`
+	OSVersion           string  `json:"os_version,omitempty"`
+	DeviceModel         string  `json:"device_model,omitempty"`
+	CountryCode         string  `json:"country_code,omitempty"`
+	Language            string  `json:"language,omitempty"`
+	Timezone            string  `json:"timezone,omitempty"`
+	Region              string  `json:"region,omitempty"`
+	NetworkType         string  `json:"network_type,omitempty"`
+	PingMs              string  `json:"ping_ms,omitempty"`
+	FPS                 int32   `json:"fps,omitempty"`
+	ClientMemoryUsageMb int64   `json:"client_memory_usage_mb,omitempty"`
+	ClientCPUUsageCount float32 `json:"client_cpu_usage_count,omitempty"`
+	ClientData          string  `json:"client_data,omitempty"`
+}
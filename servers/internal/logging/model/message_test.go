@@ -0,0 +1,145 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// populated returns a message with every field set to a distinct non-zero value
+func populated(t *testing.T) LogMessage {
+	t.Helper()
+
+	var m LogMessage
+	v := reflect.ValueOf(&m).Elem()
+	for i := range v.NumField() {
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString("value-" + v.Type().Field(i).Name)
+		case reflect.Int32, reflect.Int64:
+			fv.SetInt(int64(i + 1))
+		case reflect.Float32:
+			fv.SetFloat(float64(i) + 0.25)
+		case reflect.Struct:
+			fv.Set(reflect.ValueOf(time.Date(2026, 10, 18, 12, 30, 15, 123456789, time.UTC)))
+		default:
+			t.Fatalf("no test value for field %s of kind %s", v.Type().Field(i).Name, fv.Kind())
+		}
+	}
+	m.Severity = "ERROR"
+	m.ServiceType = "LOGGING"
+	return m
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	m := populated(t)
+	req := m.ToProto()
+
+	descs := req.ProtoReflect().Descriptor().Fields()
+	for i := range descs.Len() {
+		if !req.ProtoReflect().Has(descs.Get(i)) {
+			t.Errorf("field %s was not set on the proto", descs.Get(i).Name())
+		}
+	}
+	if got := FromProto(req); !reflect.DeepEqual(got, m) {
+		t.Fatalf("round trip lost fields:\n got %+v\nwant %+v", got, m)
+	}
+}
+
+func TestFromProtoKeepsEveryField(t *testing.T) {
+	req := &pb.LogRequest{}
+	msg := req.ProtoReflect()
+	descs := msg.Descriptor().Fields()
+	for i := range descs.Len() {
+		desc := descs.Get(i)
+		switch desc.Kind() {
+		case protoreflect.StringKind:
+			msg.Set(desc, protoreflect.ValueOfString(string(desc.Name())))
+		case protoreflect.Int32Kind:
+			msg.Set(desc, protoreflect.ValueOfInt32(int32(i+1)))
+		case protoreflect.Int64Kind:
+			msg.Set(desc, protoreflect.ValueOfInt64(int64(i+1)))
+		case protoreflect.FloatKind:
+			msg.Set(desc, protoreflect.ValueOfFloat32(float32(i)+0.5))
+		case protoreflect.EnumKind:
+			msg.Set(desc, protoreflect.ValueOfEnum(1))
+		case protoreflect.MessageKind:
+			msg.Set(desc, protoreflect.ValueOfMessage(timestamppb.New(time.Unix(1760000000, 42)).ProtoReflect()))
+		default:
+			t.Fatalf("no test value for field %s of kind %s", desc.Name(), desc.Kind())
+		}
+	}
+
+	if got := FromProto(req).ToProto(); !proto.Equal(got, req) {
+		t.Fatalf("round trip lost fields:\n got %v\nwant %v", got, req)
+	}
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	m := populated(t)
+	values := m.StreamValues()
+
+	if len(values) != len(FieldNames()) {
+		t.Fatalf("expected %d stream fields, got %d", len(FieldNames()), len(values))
+	}
+	got, err := FromStreamValues(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Fatalf("round trip lost fields:\n got %+v\nwant %+v", got, m)
+	}
+}
+
+func TestEnumNames(t *testing.T) {
+	req := LogMessage{Severity: "warning", ServiceType: "STATS"}.ToProto()
+	if req.GetSeverity() != pb.LogSeverity_WARNING || req.GetServiceType() != pb.ServerType_STATS {
+		t.Fatalf("unexpected enums %v %v", req.GetSeverity(), req.GetServiceType())
+	}
+	if unknown := (LogMessage{Severity: "LOUD"}).ToProto(); unknown.GetSeverity() >= 0 {
+		t.Fatalf("expected an invalid severity for an unknown name, got %v", unknown.GetSeverity())
+	}
+
+	// Zero enums are named so they survive a trip through the stream
+	if got := FromProto(&pb.LogRequest{}); got.Severity != "DEBUG" || got.ServiceType != "UNKNOWN" {
+		t.Fatalf("expected zero enum names, got %q %q", got.Severity, got.ServiceType)
+	}
+}
+
+func TestFromStreamValuesRejectsMalformedNumbers(t *testing.T) {
+	if _, err := FromStreamValues(map[string]any{"fps": "sixty"}); err == nil {
+		t.Fatal("expected an error for a malformed fps")
+	}
+	m, err := FromStreamValues(map[string]any{"event_type": "Classic_Play", "server_time": "2026-10-18T12:00:00Z", "unknown": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.EventType != "Classic_Play" || m.ServerTime.IsZero() {
+		t.Fatalf("unexpected message %+v", m)
+	}
+}
+
+func TestStringField(t *testing.T) {
+	m := LogMessage{ClientIP: "10.0.0.7"}
+	ip, ok := m.StringField("client_ip")
+	if !ok || *ip != "10.0.0.7" {
+		t.Fatalf("expected client_ip, got %v %v", ip, ok)
+	}
+	*ip = ""
+	if m.ClientIP != "" {
+		t.Fatal("expected the field to be set through the pointer")
+	}
+
+	if _, ok := m.StringField("fps"); ok {
+		t.Fatal("expected no string field for fps")
+	}
+	if _, ok := m.StringField("missing"); ok {
+		t.Fatal("expected no field for an unknown name")
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto converts m to a LogRequest. Enum names are matched case-insensitively;
+// an unknown name becomes an out-of-range value that validation rejects.
+func (m LogMessage) ToProto() *pb.LogRequest {
+	req := &pb.LogRequest{}
+	msg := req.ProtoReflect()
+	v := reflect.ValueOf(m)
+
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		if fv.IsZero() {
+			continue
+		}
+
+		switch f.desc.Kind() {
+		case protoreflect.EnumKind:
+			msg.Set(f.desc, protoreflect.ValueOfEnum(enumNumber(f.desc, fv.String())))
+		case protoreflect.MessageKind:
+			ts := timestamppb.New(fv.Interface().(time.Time))
+			msg.Set(f.desc, protoreflect.ValueOfMessage(ts.ProtoReflect()))
+		default:
+			msg.Set(f.desc, protoreflect.ValueOf(fv.Interface()))
+		}
+	}
+	return req
+}
+
+// FromProto converts a LogRequest. Enums are always set, to their value name,
+// or to the number when it has no name.
+func FromProto(req *pb.LogRequest) LogMessage {
+	var m LogMessage
+	msg := req.ProtoReflect()
+	v := reflect.ValueOf(&m).Elem()
+
+	for _, f := range fields {
+		fv := v.Field(f.index)
+
+		if f.desc.Kind() == protoreflect.EnumKind {
+			number := msg.Get(f.desc).Enum()
+			if value := f.desc.Enum().Values().ByNumber(number); value != nil {
+				fv.SetString(string(value.Name()))
+			} else {
+				fv.SetString(strconv.Itoa(int(number)))
+			}
+			continue
+		}
+		if !msg.Has(f.desc) {
+			continue
+		}
+
+		value := msg.Get(f.desc)
+		if f.desc.Kind() == protoreflect.MessageKind {
+			ts := value.Message().Interface().(*timestamppb.Timestamp)
+			fv.Set(reflect.ValueOf(ts.AsTime()))
+			continue
+		}
+		fv.Set(reflect.ValueOf(value.Interface()))
+	}
+	return m
+}
+
+func enumNumber(desc protoreflect.FieldDescriptor, name string) protoreflect.EnumNumber {
+	if value := desc.Enum().Values().ByName(protoreflect.Name(strings.ToUpper(name))); value != nil {
+		return value.Number()
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		return protoreflect.EnumNumber(n)
+	}
+	return -1
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// StreamValues encodes m as Redis stream fields, omitting zero values
+func (m LogMessage) StreamValues() map[string]any {
+	v := reflect.ValueOf(m)
+	values := make(map[string]any, len(fields))
+
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		if fv.IsZero() {
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.String:
+			values[f.name] = fv.String()
+		case reflect.Int32, reflect.Int64:
+			values[f.name] = strconv.FormatInt(fv.Int(), 10)
+		case reflect.Float32:
+			values[f.name] = strconv.FormatFloat(fv.Float(), 'g', -1, 32)
+		case reflect.Struct:
+			values[f.name] = fv.Interface().(time.Time).Format(time.RFC3339Nano)
+		}
+	}
+	return values
+}
+
+// FromStreamValues decodes Redis stream fields. Missing fields stay zero and
+// unknown fields are ignored; a malformed number or time is an error.
+func FromStreamValues(values map[string]any) (LogMessage, error) {
+	var m LogMessage
+	v := reflect.ValueOf(&m).Elem()
+
+	for _, f := range fields {
+		raw, ok := values[f.name]
+		if !ok {
+			continue
+		}
+		s, ok := raw.(string)
+		if !ok {
+			s = fmt.Sprint(raw)
+		}
+		if s == "" {
+			continue
+		}
+
+		fv := v.Field(f.index)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(s)
+		case reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+			if err != nil {
+				return m, fmt.Errorf("invalid %s: %w", f.name, err)
+			}
+			fv.SetInt(n)
+		case reflect.Float32:
+			n, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				return m, fmt.Errorf("invalid %s: %w", f.name, err)
+			}
+			fv.SetFloat(n)
+		case reflect.Struct:
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return m, fmt.Errorf("invalid %s: %w", f.name, err)
+			}
+			fv.Set(reflect.ValueOf(t))
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,147 @@
+package non_prioritized
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+)
+
+// AsyncConfig configures an AsyncSender
+type AsyncConfig struct {
+	// BatchSize sends a batch as soon as this many logs are queued
+	BatchSize int
+	// FlushInterval is the maximum time a log waits for its batch to fill
+	FlushInterval time.Duration
+	// QueueSize bounds the logs waiting to be sent; newer logs are dropped beyond it
+	QueueSize int
+	// SendTimeout bounds each SendLogBatch call
+	SendTimeout time.Duration
+}
+
+// AsyncSender queues logs and sends them with SendLogBatch, by size and time,
+// so callers never wait on the logging server
+type AsyncSender struct {
+	client *LoggerClient
+	config AsyncConfig
+	queue  chan *pb.LogRequest
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	dropped atomic.Int64
+	failed  atomic.Int64
+}
+
+// Async starts a buffered sender on the client
+func (c *LoggerClient) Async(config AsyncConfig) *AsyncSender {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 10000
+	}
+	if config.SendTimeout <= 0 {
+		config.SendTimeout = 5 * time.Second
+	}
+
+	s := &AsyncSender{
+		client: c,
+		config: config,
+		queue:  make(chan *pb.LogRequest, config.QueueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Send queues a log and reports whether it was accepted. It never blocks.
+func (s *AsyncSender) Send(in *pb.LogRequest) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false
+	}
+
+	select {
+	case s.queue <- in:
+		return true
+	default:
+		s.dropped.Add(1)
+		return false
+	}
+}
+
+// Dropped returns the number of logs dropped because the queue was full
+func (s *AsyncSender) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Failed returns the number of logs in batches the server did not receive
+func (s *AsyncSender) Failed() int64 {
+	return s.failed.Load()
+}
+
+// Close sends the queued logs and stops the sender
+func (s *AsyncSender) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *AsyncSender) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*pb.LogRequest, 0, s.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.send(batch)
+		batch = make([]*pb.LogRequest, 0, s.config.BatchSize)
+	}
+
+	for {
+		select {
+		case in, ok := <-s.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, in)
+			if len(batch) >= s.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *AsyncSender) send(batch []*pb.LogRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.SendTimeout)
+	defer cancel()
+
+	if _, err := s.client.SendLogBatch(ctx, &pb.LogBatchRequest{Logs: batch}); err != nil {
+		s.failed.Add(int64(len(batch)))
+	}
+}
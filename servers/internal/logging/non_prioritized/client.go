@@ -35,11 +35,8 @@ func (c *LoggerClient) Close(ctx context.Context) error {
 	return nil
 }
 
-func (c *LoggerClient) SendLog(
-	ctx context.Context,
-	in *pb.LogRequest,
-	callOpts ...grpc.CallOption,
-) (*pb.LogResponse, error) {
+// client returns a Logger client on a pooled connection
+func (c *LoggerClient) client() (pb.LoggerClient, error) {
 	dialOpts := make([]grpc.DialOption, 0, len(protobufext.DefaultDialOpts))
 	dialOpts = append(dialOpts, protobufext.DefaultDialOpts...)
 
@@ -48,7 +45,18 @@ func (c *LoggerClient) SendLog(
 		c.InternalLogger.Error("failed to get connection out of the connection pool!", "error", err)
 		return nil, err
 	}
-	client := pb.NewLoggerClient(conn)
+	return pb.NewLoggerClient(conn), nil
+}
+
+func (c *LoggerClient) SendLog(
+	ctx context.Context,
+	in *pb.LogRequest,
+	callOpts ...grpc.CallOption,
+) (*pb.LogResponse, error) {
+	client, err := c.client()
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := client.SendLog(ctx, in, callOpts...)
 	if err != nil {
@@ -59,3 +67,38 @@ func (c *LoggerClient) SendLog(
 	c.InternalLogger.Info("SendLog succeed.", "response", resp)
 	return resp, nil
 }
+
+// SendLogBatch sends several logs in one RPC. Invalid logs are reported in the
+// response rather than failing the batch.
+func (c *LoggerClient) SendLogBatch(
+	ctx context.Context,
+	in *pb.LogBatchRequest,
+	callOpts ...grpc.CallOption,
+) (*pb.LogBatchResponse, error) {
+	client, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.SendLogBatch(ctx, in, callOpts...)
+	if err != nil {
+		c.InternalLogger.Error("failed to send the log batch to the server!", "error", err)
+		return nil, err
+	}
+
+	logs := in.GetLogs()
+	for _, rejected := range resp.GetRejected() {
+		index := rejected.GetIndex()
+		if int(index) >= len(logs) {
+			c.InternalLogger.Warn("server rejected a log index outside the batch",
+				"index", index,
+				"batch_size", len(logs),
+				"reason", rejected.GetMessage())
+			continue
+		}
+		c.InternalLogger.Warn("log rejected by the server",
+			"event_type", logs[index].GetEventType(),
+			"reason", rejected.GetMessage())
+	}
+	return resp, nil
+}
@@ -0,0 +1,143 @@
+package non_prioritized
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"google.golang.org/grpc"
+)
+
+type countingSink struct {
+	mu      sync.Mutex
+	entries int
+}
+
+func (s *countingSink) Name() string { return "counting" }
+
+func (s *countingSink) Write(ctx context.Context, entries []sink.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries += len(entries)
+	return nil
+}
+
+func (s *countingSink) Close(ctx context.Context) error { return nil }
+
+// startServer serves a LogHandler on a loopback port and returns a client for it
+func startServer(t *testing.T) (*LoggerClient, *sink.Pipeline, *countingSink) {
+	t.Helper()
+	logger := slog.New(slog.DiscardHandler)
+
+	counter := &countingSink{}
+	pipeline := sink.NewPipeline(logger, sink.Config{FlushInterval: 10 * time.Millisecond}, counter)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterLoggerServer(server, NewLogHandler(logger, pipeline))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client := NewLoggerClient(listener.Addr().String(), logger)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	return client, pipeline, counter
+}
+
+func TestSendLogBatchReportsRejected(t *testing.T) {
+	client, _, _ := startServer(t)
+
+	resp, err := client.SendLogBatch(context.Background(), &pb.LogBatchRequest{Logs: []*pb.LogRequest{
+		{EventType: "Classic_Play"},
+		{},
+		{EventType: "Classic_Claim"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAccepted() != 2 || len(resp.GetRejected()) != 1 || resp.GetRejected()[0].GetIndex() != 1 {
+		t.Fatalf("unexpected response %v", resp)
+	}
+}
+
+// outOfRangeServer rejects an index past the end of every batch
+type outOfRangeServer struct {
+	pb.UnimplementedLoggerServer
+}
+
+func (outOfRangeServer) SendLogBatch(ctx context.Context, in *pb.LogBatchRequest) (*pb.LogBatchResponse, error) {
+	return &pb.LogBatchResponse{Rejected: []*pb.LogRejection{{Index: uint32(len(in.GetLogs())), Message: "bogus"}}}, nil
+}
+
+func TestSendLogBatchIgnoresOutOfRangeRejections(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterLoggerServer(server, outOfRangeServer{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client := NewLoggerClient(listener.Addr().String(), slog.New(slog.DiscardHandler))
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	resp, err := client.SendLogBatch(context.Background(), &pb.LogBatchRequest{Logs: []*pb.LogRequest{{EventType: "Classic_Play"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetRejected()) != 1 {
+		t.Fatalf("unexpected response %v", resp)
+	}
+}
+
+func TestStreamAndAsyncSenderDeliverEveryLog(t *testing.T) {
+	client, pipeline, counter := startServer(t)
+	ctx := context.Background()
+
+	stream, err := client.OpenStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 500 {
+		if err := stream.Send(ctx, &pb.LogRequest{EventType: "Classic_Play"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.Send(ctx, &pb.LogRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if stream.Rejected() != 1 {
+		t.Fatalf("expected 1 rejected log, got %d", stream.Rejected())
+	}
+
+	sender := client.Async(AsyncConfig{BatchSize: 7, FlushInterval: 10 * time.Millisecond})
+	for range 50 {
+		if !sender.Send(&pb.LogRequest{EventType: "Classic_Claim"}) {
+			t.Fatal("expected the sender to accept the log")
+		}
+	}
+	if err := sender.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if sender.Failed() != 0 || sender.Dropped() != 0 {
+		t.Fatalf("failed %d, dropped %d", sender.Failed(), sender.Dropped())
+	}
+
+	if err := pipeline.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if counter.entries != 550 {
+		t.Fatalf("expected 550 stored logs, got %d", counter.entries)
+	}
+}
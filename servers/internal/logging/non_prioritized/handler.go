@@ -2,22 +2,42 @@ package non_prioritized
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"time"
 
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
 	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// maxBatchLogs bounds the logs of a single SendLogBatch call
+	maxBatchLogs = shared.EnvInt("LOG_MAX_BATCH", 1000)
+	// streamWindow is the number of unacknowledged StreamLogs messages a client may have in flight
+	streamWindow = shared.EnvInt("LOG_STREAM_WINDOW", 256)
 )
 
 type LogHandler struct {
 	pb.UnimplementedLoggerServer
-	logger *slog.Logger
+	logger   *slog.Logger
+	pipeline *sink.Pipeline
+	server   *grpc.Server
 }
 
-func NewLogHandler(logger *slog.Logger) *LogHandler {
-	return &LogHandler{logger: logger}
+func NewLogHandler(logger *slog.Logger, pipeline *sink.Pipeline) *LogHandler {
+	return &LogHandler{logger: logger, pipeline: pipeline}
 }
 
+// Start listens on port and serves in the background until Stop
 func (l *LogHandler) Start(port string) error {
 	listener, err := net.Listen("tcp", port)
 	if err != nil {
@@ -28,29 +48,131 @@ func (l *LogHandler) Start(port string) error {
 		return err
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterLoggerServer(s, l)
+	l.server = grpc.NewServer()
+	pb.RegisterLoggerServer(l.server, l)
 
 	l.logger.Info("listening grpc server", "address", listener.Addr())
-	if err := s.Serve(listener); err != nil {
-		l.logger.Error("failed to serve",
-			"error", err,
-			"address", listener.Addr())
-		return err
-	}
+	go func() {
+		if err := l.server.Serve(listener); err != nil {
+			l.logger.Error("failed to serve",
+				"error", err,
+				"address", listener.Addr())
+		}
+	}()
 	return nil
 }
 
+// Stop waits for in-flight RPCs, or cancels them when ctx is done
+func (l *LogHandler) Stop(ctx context.Context) {
+	if l.server == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		l.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		l.server.Stop()
+	}
+}
+
+// SendLog validates and enriches a log, then hands it to the sinks
 func (l *LogHandler) SendLog(ctx context.Context, in *pb.LogRequest) (*pb.LogResponse, error) {
-	resp, err := l.SendLog(ctx, in)
+	entry, err := l.ingest(ctx, in, time.Now())
 	if err != nil {
-		l.logger.Error("error> nonPrLogger.SendLog failed", "error", err)
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pb.LogResponse{Success: true, Message: entry.LogID}, nil
+}
+
+// SendLogBatch ingests every valid log of the batch and reports the rejected ones
+func (l *LogHandler) SendLogBatch(ctx context.Context, in *pb.LogBatchRequest) (*pb.LogBatchResponse, error) {
+	if len(in.GetLogs()) > maxBatchLogs {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d logs exceeds %d", len(in.GetLogs()), maxBatchLogs)
 	}
 
-	l.logger.Info("response> nonPrLogger.SendLog done",
-		"is_success", resp.Success,
-		"response", resp.GetMessage(),
-	)
+	resp := &pb.LogBatchResponse{}
+	now := time.Now()
+	for i, log := range in.GetLogs() {
+		if _, err := l.ingest(ctx, log, now); err != nil {
+			resp.Rejected = append(resp.Rejected, &pb.LogRejection{Index: uint32(i), Message: err.Error()})
+			continue
+		}
+		resp.Accepted++
+	}
 	return resp, nil
 }
+
+// StreamLogs announces the window, then ingests and acknowledges each message in
+// order. The next message is only read once the previous one is acknowledged, so
+// a client honoring the window never has more than window messages buffered.
+func (l *LogHandler) StreamLogs(stream pb.Logger_StreamLogsServer) error {
+	ctx := stream.Context()
+	if err := stream.Send(&pb.LogAck{Success: true, Window: uint32(streamWindow)}); err != nil {
+		return err
+	}
+
+	var last uint64
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ack := &pb.LogAck{Sequence: req.GetSequence(), Success: true, Window: uint32(streamWindow)}
+		if req.GetSequence() <= last {
+			ack.Success = false
+			ack.Message = fmt.Sprintf("sequence %d is not after %d", req.GetSequence(), last)
+		} else if entry, err := l.ingest(ctx, req.GetLog(), time.Now()); err != nil {
+			ack.Success = false
+			ack.Message = err.Error()
+			last = req.GetSequence()
+		} else {
+			ack.Message = entry.LogID
+			last = req.GetSequence()
+		}
+
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+	}
+}
+
+// ingest validates and stamps a log with its peer address, then publishes it
+func (l *LogHandler) ingest(ctx context.Context, in *pb.LogRequest, now time.Time) (sink.Entry, error) {
+	if in == nil {
+		return sink.Entry{}, errors.New("log request is empty")
+	}
+	if ts := in.GetServerTime(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return sink.Entry{}, fmt.Errorf("invalid server_time: %w", err)
+		}
+	}
+
+	entry, err := sink.NewEntry(model.FromProto(in), now, peerIP(ctx))
+	if err != nil {
+		return sink.Entry{}, err
+	}
+	l.pipeline.Publish(entry)
+	return entry, nil
+}
+
+// peerIP returns the address of the service that sent the RPC, without its port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,21 @@
+package non_prioritized
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+)
+
+func TestPeerIPStripsPort(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 51234},
+	})
+	if got := peerIP(ctx); got != "10.0.0.7" {
+		t.Fatalf("expected peer IP without port, got %q", got)
+	}
+	if got := peerIP(context.Background()); got != "" {
+		t.Fatalf("expected no peer IP outside an RPC, got %q", got)
+	}
+}
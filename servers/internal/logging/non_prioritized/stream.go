@@ -0,0 +1,123 @@
+package non_prioritized
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"google.golang.org/grpc"
+)
+
+// LogStream sends logs over a StreamLogs call, keeping at most the
+// server's window of messages unacknowledged
+type LogStream struct {
+	client   *LoggerClient
+	stream   grpc.BidiStreamingClient[pb.StreamLogsRequest, pb.LogAck]
+	slots    chan struct{}
+	sequence uint64
+	sendMu   sync.Mutex
+
+	rejected atomic.Int64
+	done     chan struct{}
+	err      error
+}
+
+// OpenStream starts a StreamLogs call and waits for the server's window
+func (c *LoggerClient) OpenStream(ctx context.Context, callOpts ...grpc.CallOption) (*LogStream, error) {
+	client, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.StreamLogs(ctx, callOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log stream: %w", err)
+	}
+	hello, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive log stream window: %w", err)
+	}
+
+	s := &LogStream{
+		client: c,
+		stream: stream,
+		slots:  make(chan struct{}, max(hello.GetWindow(), 1)),
+		done:   make(chan struct{}),
+	}
+	go s.receive()
+	return s, nil
+}
+
+// Send blocks while the window is full, then sends the log
+func (s *LogStream) Send(ctx context.Context, in *pb.LogRequest) error {
+	select {
+	case s.slots <- struct{}{}:
+	case <-s.done:
+		return s.closedErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	s.sequence++
+	if err := s.stream.Send(&pb.StreamLogsRequest{Sequence: s.sequence, Log: in}); err != nil {
+		return fmt.Errorf("failed to send log: %w", err)
+	}
+	return nil
+}
+
+// Rejected returns the number of logs the server acknowledged as invalid
+func (s *LogStream) Rejected() int64 {
+	return s.rejected.Load()
+}
+
+// Close ends the stream once every sent log is acknowledged
+func (s *LogStream) Close(ctx context.Context) error {
+	s.sendMu.Lock()
+	err := s.stream.CloseSend()
+	s.sendMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to close log stream: %w", err)
+	}
+
+	select {
+	case <-s.done:
+		if errors.Is(s.err, io.EOF) {
+			return nil
+		}
+		return s.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// receive frees a window slot per acknowledgement until the stream ends
+func (s *LogStream) receive() {
+	defer close(s.done)
+	for {
+		ack, err := s.stream.Recv()
+		if err != nil {
+			s.err = err
+			return
+		}
+		if !ack.GetSuccess() {
+			s.rejected.Add(1)
+			s.client.InternalLogger.Warn("log rejected by the server",
+				"sequence", ack.GetSequence(),
+				"reason", ack.GetMessage())
+		}
+		<-s.slots
+	}
+}
+
+func (s *LogStream) closedErr() error {
+	if s.err == nil || errors.Is(s.err, io.EOF) {
+		return errors.New("log stream closed")
+	}
+	return fmt.Errorf("log stream failed: %w", s.err)
+}
@@ -0,0 +1,138 @@
+// Package policy samples and rate limits logs on ingest so a noisy service
+// cannot flood the pipeline. Policies match logs by service type, event type
+// and severity; ERROR and CRITICAL logs are never dropped.
+package policy
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"golang.org/x/time/rate"
+)
+
+// Stats are the counters of a policy
+type Stats struct {
+	Policy     string `json:"policy"`
+	Matched    int64  `json:"matched"`
+	SampledOut int64  `json:"sampled_out"`
+	Throttled  int64  `json:"throttled"`
+}
+
+type compiled struct {
+	Policy
+	bucket     *rate.Limiter
+	matched    atomic.Int64
+	sampledOut atomic.Int64
+	throttled  atomic.Int64
+}
+
+func (c *compiled) matches(entry *sink.Entry) bool {
+	return (c.ServiceType == "" || c.ServiceType == entry.ServiceType) &&
+		(c.EventType == "" || c.EventType == entry.EventType) &&
+		(len(c.Severities) == 0 || slices.Contains(c.Severities, entry.Severity))
+}
+
+// Compile-time check to ensure Limiter implements sink.Stage
+var _ sink.Stage = (*Limiter)(nil)
+
+// Limiter is the pipeline stage applying the first policy matching each log
+type Limiter struct {
+	logger   *slog.Logger
+	policies []*compiled
+	// random returns a number in [0, 1) for sampling
+	random func() float64
+}
+
+// NewLimiter applies validated policies in order
+func NewLimiter(logger *slog.Logger, policies []Policy) *Limiter {
+	l := &Limiter{logger: logger, random: rand.Float64}
+	for _, p := range policies {
+		c := &compiled{Policy: p}
+		if p.Rate > 0 {
+			c.bucket = rate.NewLimiter(rate.Limit(p.Rate), p.Burst)
+		}
+		l.policies = append(l.policies, c)
+	}
+	return l
+}
+
+func (l *Limiter) Name() string {
+	return "policy"
+}
+
+// Apply reports whether the entry is kept by the first matching policy
+func (l *Limiter) Apply(entry *sink.Entry) bool {
+	severity := pb.LogSeverity_value[entry.Severity]
+	if protected(severity) {
+		return true
+	}
+
+	for _, c := range l.policies {
+		if !c.matches(entry) {
+			continue
+		}
+		c.matched.Add(1)
+
+		if c.SampleRate != nil && severity <= int32(pb.LogSeverity_INFO) && l.random() >= *c.SampleRate {
+			c.sampledOut.Add(1)
+			return false
+		}
+		if c.bucket != nil && !c.bucket.Allow() {
+			c.throttled.Add(1)
+			return false
+		}
+		return true
+	}
+	return true
+}
+
+// Stats returns the counters of every policy
+func (l *Limiter) Stats() []Stats {
+	stats := make([]Stats, 0, len(l.policies))
+	for _, c := range l.policies {
+		stats = append(stats, Stats{
+			Policy:     c.Name,
+			Matched:    c.matched.Load(),
+			SampledOut: c.sampledOut.Load(),
+			Throttled:  c.throttled.Load(),
+		})
+	}
+	return stats
+}
+
+// Run logs a summary line per policy every interval, for the logs it dropped
+// since the previous summary, until ctx is done
+func (l *Limiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := l.Stats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := l.Stats()
+			for i, stats := range current {
+				sampledOut := stats.SampledOut - last[i].SampledOut
+				throttled := stats.Throttled - last[i].Throttled
+				if sampledOut == 0 && throttled == 0 {
+					continue
+				}
+				l.logger.Info("log policy summary",
+					"policy", stats.Policy,
+					"interval", interval,
+					"matched", stats.Matched-last[i].Matched,
+					"sampled_out", sampledOut,
+					"throttled", throttled)
+			}
+			last = current
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+const policiesYAML = `
+policies:
+  - name: ingame-debug
+    service_type: ingame
+    severity: [debug, info]
+    sample_rate: 0.5
+  - name: ingame
+    service_type: INGAME
+    rate: 1
+    burst: 2
+`
+
+func entry(serviceType, severity string) *sink.Entry {
+	return &sink.Entry{LogMessage: model.LogMessage{ServiceType: serviceType, Severity: severity, EventType: "Classic_Play"}}
+}
+
+func TestLimiter(t *testing.T) {
+	policies, err := ParsePolicies([]byte(policiesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := NewLimiter(slog.New(slog.DiscardHandler), policies)
+
+	// Alternate samples above and below the rate
+	samples := []float64{0.9, 0.1}
+	l.random = func() float64 {
+		v := samples[0]
+		samples = append(samples[1:], v)
+		return v
+	}
+
+	if l.Apply(entry("INGAME", "DEBUG")) || !l.Apply(entry("INGAME", "INFO")) {
+		t.Fatal("expected the first DEBUG log sampled out and the INFO log kept")
+	}
+
+	// WARNING logs skip sampling but share the bucket of the second policy
+	kept := 0
+	for range 5 {
+		if l.Apply(entry("INGAME", "WARNING")) {
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Fatalf("expected the burst of 2 to be kept, got %d", kept)
+	}
+
+	for range 10 {
+		if !l.Apply(entry("INGAME", "ERROR")) || !l.Apply(entry("INGAME", "CRITICAL")) {
+			t.Fatal("ERROR and CRITICAL logs must never be dropped")
+		}
+	}
+	if !l.Apply(entry("RANKING", "DEBUG")) {
+		t.Fatal("expected logs without a matching policy to be kept")
+	}
+
+	stats := l.Stats()
+	if stats[0].SampledOut != 1 || stats[1].Throttled != 3 || stats[1].Matched != 5 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestParsePoliciesRejectsErrorOnlyPolicies(t *testing.T) {
+	if _, err := ParsePolicies([]byte("policies: [{name: errors, severity: [ERROR, CRITICAL], rate: 1}]")); err == nil {
+		t.Fatal("expected an error for a policy matching only protected severities")
+	}
+	if _, err := ParsePolicies([]byte("policies: [{name: a, sample_rate: 2}]")); err == nil {
+		t.Fatal("expected an error for a sample rate above 1")
+	}
+}
@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy limits the logs it matches. Empty match fields match every log.
+type Policy struct {
+	Name        string   `yaml:"name"`
+	ServiceType string   `yaml:"service_type"`
+	EventType   string   `yaml:"event_type"`
+	Severities  []string `yaml:"severity"`
+
+	// SampleRate is the fraction of DEBUG and INFO logs kept; defaults to 1
+	SampleRate *float64 `yaml:"sample_rate"`
+	// Rate is the number of logs below ERROR admitted per second; 0 is unlimited.
+	// All logs matching the policy share the bucket.
+	Rate float64 `yaml:"rate"`
+	// Burst is the bucket size; defaults to the rate, at least 1
+	Burst int `yaml:"burst"`
+}
+
+// Validate checks the policy and normalizes enum names
+func (p *Policy) Validate() error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if p.ServiceType != "" {
+		p.ServiceType = strings.ToUpper(p.ServiceType)
+		if _, ok := pb.ServerType_value[p.ServiceType]; !ok {
+			return fmt.Errorf("unknown service_type %q", p.ServiceType)
+		}
+	}
+
+	limited := len(p.Severities) == 0
+	for i, severity := range p.Severities {
+		severity = strings.ToUpper(severity)
+		value, ok := pb.LogSeverity_value[severity]
+		if !ok {
+			return fmt.Errorf("unknown severity %q", severity)
+		}
+		p.Severities[i] = severity
+		limited = limited || !protected(value)
+	}
+	if !limited {
+		return errors.New("ERROR and CRITICAL logs are never dropped; the policy would have no effect")
+	}
+
+	if p.SampleRate != nil && (*p.SampleRate < 0 || *p.SampleRate > 1) {
+		return fmt.Errorf("sample_rate must be between 0 and 1")
+	}
+	if p.Rate < 0 || p.Burst < 0 {
+		return errors.New("rate and burst must not be negative")
+	}
+	if p.Burst == 0 {
+		p.Burst = max(int(p.Rate), 1)
+	}
+	return nil
+}
+
+// protected reports whether logs of a severity are exempt from every policy
+func protected(severity int32) bool {
+	return severity >= int32(pb.LogSeverity_ERROR)
+}
+
+type policiesFile struct {
+	Policies []Policy `yaml:"policies"`
+}
+
+// LoadPolicies reads policies from a YAML file
+func LoadPolicies(path string) ([]Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log policies: %w", err)
+	}
+	return ParsePolicies(data)
+}
+
+// ParsePolicies parses and validates YAML policies
+func ParsePolicies(data []byte) ([]Policy, error) {
+	var file policiesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse log policies: %w", err)
+	}
+
+	names := make(map[string]bool, len(file.Policies))
+	for i := range file.Policies {
+		policy := &file.Policies[i]
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid log policy %d: %w", i, err)
+		}
+		if names[policy.Name] {
+			return nil, fmt.Errorf("duplicate log policy %q", policy.Name)
+		}
+		names[policy.Name] = true
+	}
+	return file.Policies, nil
+}
@@ -4,30 +4,27 @@ import (
 	"context"
 	"log/slog"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
 )
 
+// LoggerClient adds logs to the priority log stream through a broker publisher
 type LoggerClient struct {
-	redisClient    *redis.Client
+	publisher      broker.Publisher
 	internalLogger *slog.Logger
 }
 
 func NewLoggerClient(
-	redisClient *redis.Client,
+	publisher broker.Publisher,
 	logger *slog.Logger,
 ) *LoggerClient {
 	return &LoggerClient{
-		redisClient:    redisClient,
+		publisher:      publisher,
 		internalLogger: logger,
 	}
 }
 
 func (c *LoggerClient) SendLog(ctx context.Context, message LogMessage) error {
-	streamKey, err := c.redisClient.XAdd(ctx, &redis.XAddArgs{
-		Stream: "log",
-		ID:     "*",
-		Values: message,
-	}).Result()
+	streamKey, err := c.publisher.Publish(ctx, StreamKey, message.StreamValues())
 	if err != nil {
 		c.internalLogger.Error("failed to add to log stream", "error", err)
 		return err
@@ -36,3 +33,38 @@ func (c *LoggerClient) SendLog(ctx context.Context, message LogMessage) error {
 
 	return nil
 }
+
+// SendLogs adds the messages to the log stream, in a single round trip when the publisher supports batches
+func (c *LoggerClient) SendLogs(ctx context.Context, messages []LogMessage) error {
+	err := c.publishLogs(ctx, messages)
+	if err != nil {
+		c.internalLogger.Error("failed to add logs to log stream", "count", len(messages), "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *LoggerClient) publishLogs(ctx context.Context, messages []LogMessage) error {
+	if bp, ok := c.publisher.(broker.BatchPublisher); ok {
+		batch := make([]map[string]interface{}, len(messages))
+		for i, message := range messages {
+			batch[i] = message.StreamValues()
+		}
+		return bp.PublishBatch(ctx, StreamKey, batch)
+	}
+
+	for _, message := range messages {
+		if _, err := c.publisher.Publish(ctx, StreamKey, message.StreamValues()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the publisher when it owns a connection, as broker backends do
+func (c *LoggerClient) Close() error {
+	if closer, ok := c.publisher.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
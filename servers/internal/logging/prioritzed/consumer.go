@@ -6,7 +6,10 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/backends"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/resilience"
 )
 
 var (
@@ -15,30 +18,30 @@ var (
 	retryDelay        = shared.EnvDuration("LOGGING_RETRY_DELAY", 5*time.Second)
 	consumerBlockTime = shared.EnvDuration("LOGGING_CONSUMER_BLOCK_TIME", 3*time.Second)
 	batchSize         = shared.EnvInt("LOGGING_BATCH_SIZE", 100)
+	maxRetryDelay     = shared.EnvDuration("LOGGING_MAX_RETRY_DELAY", 1*time.Minute)
+	breakerThreshold  = shared.EnvInt("LOGGING_BREAKER_THRESHOLD", 5)
+	breakerCooldown   = shared.EnvDuration("LOGGING_BREAKER_COOLDOWN", 30*time.Second)
 	minIdle           = 5 * time.Minute
+	// brokerBackend selects where log messages are consumed from: redis, memory or nats
+	brokerBackend = shared.EnvString("LOGGING_BROKER", string(broker.KindRedis))
 )
 
-// Consumer is an alias for the generic Redis stream consumer
-type Consumer = redisstream.Consumer[LogMessage]
-
-// NewConsumer creates a new logging consumer using the shared redisstream consumer
-// TODO: This consumer currently uses a temporary channel. It should be updated to accept
-// a proper transfer channel when the logging server is fully implemented.
-func NewConsumer(logger *slog.Logger, redisClient *redis.Client) *Consumer {
-	streamKey := "logging:messages"
-
-	// TODO: Replace this temporary channel with a proper transfer channel
-	// when the logging server is fully implemented
-	tempTransferCh := make(chan LogMessage, 100)
-	go func() {
-		// Drain the channel to prevent blocking
-		for range tempTransferCh {
-			// Messages are discarded for now
-		}
-	}()
+// StreamKey is the stream prioritized log messages are published to
+const StreamKey = "logging:messages"
+
+// Consumer is the backend agnostic stream consumer used for log messages
+type Consumer = broker.StreamConsumer
+
+// NewConsumer creates a new logging consumer on the backend selected by LOGGING_BROKER
+// that hands parsed messages to transferCh. redisClient is only required for the redis backend.
+func NewConsumer(logger *slog.Logger, redisClient *redis.Client, transferCh chan<- LogMessage) (Consumer, error) {
+	kind, err := broker.ParseKind(brokerBackend)
+	if err != nil {
+		return nil, err
+	}
 
 	config := redisstream.Config{
-		StreamKey:        streamKey,
+		StreamKey:        StreamKey,
 		ConsumerGroup:    consumerGroup,
 		ConsumerIDPrefix: "logging-consumer",
 		BatchSize:        batchSize,
@@ -46,12 +49,23 @@ func NewConsumer(logger *slog.Logger, redisClient *redis.Client) *Consumer {
 		MaxRetries:       maxRetries,
 		RetryDelay:       retryDelay,
 		MinIdle:          minIdle,
+		Retry: resilience.RetryPolicy{
+			InitialDelay: retryDelay,
+			MaxDelay:     maxRetryDelay,
+			Multiplier:   2,
+			Jitter:       0.2,
+		},
+		Breaker: resilience.BreakerConfig{
+			FailureThreshold: breakerThreshold,
+			Cooldown:         breakerCooldown,
+		},
 	}
 
-	return redisstream.NewConsumer(
+	return backends.NewStreamConsumer(
+		kind,
 		logger,
 		redisClient,
-		tempTransferCh,
+		transferCh,
 		parse, // Use the parse function from parser.go
 		config,
 	)
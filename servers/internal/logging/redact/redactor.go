@@ -0,0 +1,258 @@
+// Package redact scrubs personal data from log entries before they reach any
+// sink. Rules hash, mask, drop or truncate log fields and values inside the
+// data and client_data JSON payloads, and differ per environment.
+package redact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+// Compile-time check to ensure Redactor implements sink.Stage
+var _ sink.Stage = (*Redactor)(nil)
+
+// unparseablePayload replaces payloads the path rules cannot be applied to
+const unparseablePayload = `{"redacted":"unparseable payload"}`
+
+// Stats are the counters of a Redactor
+type Stats struct {
+	Redacted    int64 `json:"redacted"`
+	Unparseable int64 `json:"unparseable"`
+}
+
+// Redactor is the pipeline stage applying the rules of each entry's environment
+type Redactor struct {
+	rules map[string][]Rule
+	key   []byte
+	// redacted counts the values changed by rules
+	redacted atomic.Int64
+	// unparseable counts the payloads replaced because rules could not be applied to them
+	unparseable atomic.Int64
+}
+
+// NewRedactor applies rules keyed by environment, falling back to the
+// DefaultEnvironment rules. Hashes are keyed with key so low-entropy values
+// such as IP addresses cannot be recovered by hashing candidates.
+func NewRedactor(rules map[string][]Rule, key []byte) *Redactor {
+	return &Redactor{rules: rules, key: key}
+}
+
+func (r *Redactor) Name() string {
+	return "redact"
+}
+
+// Redacted returns the number of values changed so far
+func (r *Redactor) Redacted() int64 {
+	return r.redacted.Load()
+}
+
+// Unparseable returns the number of payloads replaced so far
+func (r *Redactor) Unparseable() int64 {
+	return r.unparseable.Load()
+}
+
+// Stats returns the counters of the redactor
+func (r *Redactor) Stats() Stats {
+	return Stats{Redacted: r.Redacted(), Unparseable: r.Unparseable()}
+}
+
+// Apply redacts entry in place; entries are never dropped. A payload that path
+// rules cannot be applied to, such as invalid JSON, is replaced rather than
+// kept as is, so personal data in it cannot leak.
+func (r *Redactor) Apply(entry *sink.Entry) bool {
+	rules, ok := r.rules[entry.Environment]
+	if !ok {
+		rules = r.rules[DefaultEnvironment]
+	}
+
+	// Payloads are decoded once and encoded again only when a rule changed them
+	decoded := make(map[string]any)
+	changed := make(map[string]bool)
+
+	for _, rule := range rules {
+		field, _, _ := strings.Cut(rule.Field, ".")
+		if rule.path == nil {
+			if value := r.field(entry, field); value != nil && *value != "" {
+				*value = r.apply(rule, *value)
+				r.redacted.Add(1)
+			}
+			continue
+		}
+
+		doc, ok := decoded[field]
+		if !ok {
+			payload := r.field(entry, field)
+			doc, ok = decode(*payload)
+			if !ok {
+				*payload = unparseablePayload
+				r.unparseable.Add(1)
+			}
+			decoded[field] = doc
+		}
+		if doc == nil {
+			continue
+		}
+		doc, n := r.redactPath(doc, rule.path, rule)
+		decoded[field] = doc
+		if n > 0 {
+			changed[field] = true
+			r.redacted.Add(int64(n))
+		}
+	}
+
+	for field := range changed {
+		data, err := json.Marshal(decoded[field])
+		if err != nil {
+			data = []byte(unparseablePayload)
+			r.unparseable.Add(1)
+		}
+		*r.field(entry, field) = string(data)
+	}
+	return true
+}
+
+func (r *Redactor) field(entry *sink.Entry, name string) *string {
+	if name == peerIP {
+		return &entry.PeerIP
+	}
+	value, _ := entry.StringField(name)
+	return value
+}
+
+// decode parses a JSON payload, keeping numbers exact. Empty payloads decode to nil;
+// ok is false for invalid ones, including trailing data after the JSON value.
+func decode(payload string) (doc any, ok bool) {
+	if payload == "" {
+		return nil, true
+	}
+	dec := json.NewDecoder(strings.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, false
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, false
+	}
+	return doc, true
+}
+
+// redactPath applies rule to the values at path below node and returns the
+// updated node with the number of values changed
+func (r *Redactor) redactPath(node any, path []string, rule Rule) (any, int) {
+	if len(path) == 0 {
+		return r.applyValue(rule, node)
+	}
+
+	segment, rest := path[0], path[1:]
+	changed := 0
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if segment != "*" && segment != key {
+				continue
+			}
+			if len(rest) == 0 && rule.Action == Drop {
+				delete(v, key)
+				changed++
+				continue
+			}
+			updated, n := r.redactPath(child, rest, rule)
+			v[key] = updated
+			changed += n
+		}
+	case []any:
+		if segment != "*" {
+			return node, 0
+		}
+		kept := v[:0]
+		for _, child := range v {
+			if len(rest) == 0 && rule.Action == Drop {
+				changed++
+				continue
+			}
+			updated, n := r.redactPath(child, rest, rule)
+			kept = append(kept, updated)
+			changed += n
+		}
+		return kept, changed
+	}
+	return node, changed
+}
+
+// applyValue redacts a JSON value; non-string values are redacted as their JSON text
+func (r *Redactor) applyValue(rule Rule, value any) (any, int) {
+	if value == nil {
+		return nil, 0
+	}
+	s, ok := value.(string)
+	if !ok {
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(value); err != nil {
+			return value, 0
+		}
+		s = strings.TrimSuffix(buf.String(), "\n")
+	}
+	return r.apply(rule, s), 1
+}
+
+func (r *Redactor) apply(rule Rule, value string) string {
+	switch rule.Action {
+	case Hash:
+		return r.hash(value)
+	case Mask:
+		return mask(value, rule.Length)
+	case Truncate:
+		return truncate(value, rule.Length)
+	default:
+		return ""
+	}
+}
+
+// hash returns a keyed SHA-256 of value, shortened to 128 bits
+func (r *Redactor) hash(value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(value))
+	return fmt.Sprintf("hash:%s", hex.EncodeToString(mac.Sum(nil)[:16]))
+}
+
+// mask replaces all but the last keep characters with '*'
+func mask(value string, keep int) string {
+	n := utf8.RuneCountInString(value)
+	if keep >= n {
+		return strings.Repeat("*", n)
+	}
+
+	var b strings.Builder
+	i := 0
+	for _, c := range value {
+		if i < n-keep {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(c)
+		}
+		i++
+	}
+	return b.String()
+}
+
+// truncate keeps the first n characters of value
+func truncate(value string, n int) string {
+	i := 0
+	for pos := range value {
+		if i == n {
+			return value[:pos]
+		}
+		i++
+	}
+	return value
+}
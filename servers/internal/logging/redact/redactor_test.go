@@ -0,0 +1,149 @@
+package redact
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+const rulesYAML = `
+environments:
+  default:
+    - field: client_ip
+      action: mask
+      length: 3
+  production:
+    - field: user_id
+      action: hash
+    - field: device_id
+      action: drop
+    - field: peer_ip
+      action: drop
+    - field: data.user.email
+      action: hash
+    - field: data.cards.*.number
+      action: mask
+      length: 4
+    - field: data.token
+      action: drop
+    - field: client_data.note
+      action: truncate
+      length: 5
+`
+
+func newEntry(env string) sink.Entry {
+	return sink.Entry{
+		LogMessage: model.LogMessage{
+			Environment: env,
+			EventType:   "Purchase",
+			ClientIP:    "203.0.113.42",
+			UserID:      "u1",
+			DeviceID:    "device-1",
+			Data:        `{"user":{"email":"a@example.com"},"cards":[{"number":"4111111111111111"}],"token":"secret","amount":12.50}`,
+			ClientData:  `{"note":"hello world"}`,
+		},
+		PeerIP: "10.0.0.7",
+	}
+}
+
+func TestRedactorAppliesEnvironmentRules(t *testing.T) {
+	rules, err := ParseRules([]byte(rulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRedactor(rules, []byte("key"))
+
+	entry := newEntry("production")
+	if !r.Apply(&entry) {
+		t.Fatal("expected the entry to be kept")
+	}
+
+	if !strings.HasPrefix(entry.UserID, "hash:") || entry.DeviceID != "" || entry.PeerIP != "" {
+		t.Fatalf("unexpected fields %q %q %q", entry.UserID, entry.DeviceID, entry.PeerIP)
+	}
+	if entry.ClientIP != "203.0.113.42" {
+		t.Fatalf("default rules must not apply to production, got %q", entry.ClientIP)
+	}
+
+	var data struct {
+		User   struct{ Email string }
+		Cards  []struct{ Number string }
+		Token  *string
+		Amount json.Number
+	}
+	if err := json.Unmarshal([]byte(entry.Data), &data); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(data.User.Email, "hash:") || data.Cards[0].Number != "************1111" ||
+		data.Token != nil || data.Amount != "12.50" {
+		t.Fatalf("unexpected data %s", entry.Data)
+	}
+	if entry.ClientData != `{"note":"hello"}` {
+		t.Fatalf("unexpected client_data %s", entry.ClientData)
+	}
+
+	// Hashes are stable so redacted values can still be correlated
+	again := newEntry("production")
+	r.Apply(&again)
+	if again.UserID != entry.UserID {
+		t.Fatal("expected the same hash for the same value")
+	}
+
+	staging := newEntry("staging")
+	r.Apply(&staging)
+	if staging.ClientIP != "*********.42" || staging.UserID != "u1" {
+		t.Fatalf("expected only the default rules, got %q %q", staging.ClientIP, staging.UserID)
+	}
+}
+
+func TestParseRulesRejectsInvalidRules(t *testing.T) {
+	for _, rules := range []string{
+		"environments: {default: [{field: client_ip, action: scramble}]}",
+		"environments: {default: [{field: event_type, action: drop}]}",
+		"environments: {default: [{field: fps, action: drop}]}",
+		"environments: {default: [{field: data.note, action: truncate}]}",
+		"environments: {}",
+	} {
+		if _, err := ParseRules([]byte(rules)); err == nil {
+			t.Fatalf("expected an error for %s", rules)
+		}
+	}
+}
+
+func TestRedactorReplacesUnparseablePayloads(t *testing.T) {
+	rules, err := ParseRules([]byte(rulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRedactor(rules, []byte("key"))
+
+	for _, data := range []string{
+		`{"user":{"email":"a@example.com"`,
+		`{"token":"secret"} {"user":{"email":"a@example.com"}}`,
+	} {
+		entry := newEntry("production")
+		entry.Data = data
+		r.Apply(&entry)
+
+		if entry.Data != unparseablePayload {
+			t.Errorf("expected %s to be replaced, got %s", data, entry.Data)
+		}
+		if entry.ClientData != `{"note":"hello"}` {
+			t.Errorf("expected client_data to be redacted as usual, got %s", entry.ClientData)
+		}
+	}
+	if r.Unparseable() != 2 {
+		t.Errorf("expected 2 replaced payloads, got %d", r.Unparseable())
+	}
+
+	// Environments without path rules on data keep it as is
+	staging := newEntry("staging")
+	staging.Data = "not json"
+	r.Apply(&staging)
+	if staging.Data != "not json" {
+		t.Errorf("expected data without path rules to be kept, got %s", staging.Data)
+	}
+}
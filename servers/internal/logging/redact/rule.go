@@ -0,0 +1,123 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is what a rule does to a value
+type Action string
+
+const (
+	// Hash replaces the value with a keyed hash, so equal values stay correlatable
+	Hash Action = "hash"
+	// Mask replaces all but the last Length characters with '*'
+	Mask Action = "mask"
+	// Drop removes the value
+	Drop Action = "drop"
+	// Truncate keeps the first Length characters
+	Truncate Action = "truncate"
+)
+
+// DefaultEnvironment holds the rules of environments without their own rules
+const DefaultEnvironment = "default"
+
+// Rule redacts a log field or a JSON path inside data or client_data
+type Rule struct {
+	// Field is a log field (client_ip, user_id, peer_ip, ...) or a dot separated
+	// path into a JSON payload, e.g. data.user.email. A "*" segment matches every
+	// key of an object or element of an array.
+	Field  string `yaml:"field"`
+	Action Action `yaml:"action"`
+	Length int    `yaml:"length"`
+
+	// path is the JSON path below the payload field, empty for whole fields
+	path []string
+}
+
+// payloads are the fields holding JSON that rules may reach into
+var payloads = []string{"data", "client_data"}
+
+// protected fields identify and route entries and are never redacted
+var protected = []string{"log_id", "event_type", "severity", "service_type", "environment"}
+
+// Validate checks the rule and splits its JSON path
+func (r *Rule) Validate() error {
+	switch r.Action {
+	case Hash, Drop:
+	case Mask:
+		if r.Length < 0 {
+			return fmt.Errorf("mask length must not be negative")
+		}
+	case Truncate:
+		if r.Length <= 0 {
+			return fmt.Errorf("truncate requires a positive length")
+		}
+	default:
+		return fmt.Errorf("unknown action %q (expected hash, mask, drop or truncate)", r.Action)
+	}
+
+	field, path, _ := strings.Cut(r.Field, ".")
+	for _, payload := range payloads {
+		if field == payload && path != "" {
+			r.path = strings.Split(path, ".")
+			return nil
+		}
+	}
+
+	for _, name := range protected {
+		if r.Field == name {
+			return fmt.Errorf("field %s cannot be redacted", r.Field)
+		}
+	}
+	if r.Field == peerIP {
+		return nil
+	}
+	var m model.LogMessage
+	if _, ok := m.StringField(r.Field); !ok {
+		return fmt.Errorf("unknown or non-text field %q", r.Field)
+	}
+	return nil
+}
+
+// peerIP is the ingest metadata field that rules may redact besides the log fields
+const peerIP = "peer_ip"
+
+type rulesFile struct {
+	// Environments maps an environment to its rules; DefaultEnvironment applies to the others
+	Environments map[string][]Rule `yaml:"environments"`
+}
+
+// LoadRules reads rules per environment from a YAML file
+func LoadRules(path string) (map[string][]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates YAML rules
+func ParseRules(data []byte) (map[string][]Rule, error) {
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse redaction rules: %w", err)
+	}
+	if len(file.Environments) == 0 {
+		return nil, errors.New("no redaction rules")
+	}
+
+	for env, rules := range file.Environments {
+		for i := range rules {
+			if err := rules[i].Validate(); err != nil {
+				return nil, fmt.Errorf("invalid redaction rule %d of %s: %w", i, env, err)
+			}
+		}
+	}
+	return file.Environments, nil
+}
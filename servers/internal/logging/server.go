@@ -3,26 +3,48 @@ package logging
 import (
 	"context"
 	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/non_prioritized"
 	prioritized "github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/prioritzed"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
 )
 
 type Server struct {
 	logger      *slog.Logger
+	redisClient *redis.Client
+	pipeline    *sink.Pipeline
 	nonPrLogger *non_prioritized.LogHandler
-	prLogger    *prioritized.Consumer
+	prLogger    prioritized.Consumer
+	messageCh   chan prioritized.LogMessage
+	wg          *sync.WaitGroup
+	routed      chan struct{}
 }
 
-func NewServer(logger *slog.Logger) *Server {
-	nonPrLogger := non_prioritized.NewLogHandler(logger)
-	prLogger := prioritized.NewConsumer(logger, nil)
+// NewServer creates a logging server that writes logs received over gRPC and
+// from the prioritized stream to the sinks of pipeline. redisClient is only
+// required for the redis broker; the stream is not consumed when it fails to start.
+func NewServer(logger *slog.Logger, pipeline *sink.Pipeline, redisClient *redis.Client) *Server {
+	nonPrLogger := non_prioritized.NewLogHandler(logger, pipeline)
+
+	messageCh := make(chan prioritized.LogMessage, 100)
+	prLogger, err := prioritized.NewConsumer(logger, redisClient, messageCh)
+	if err != nil {
+		logger.Warn("prioritized log consumer disabled", "error", err)
+	}
 
 	return &Server{
 		logger:      logger,
+		redisClient: redisClient,
+		pipeline:    pipeline,
 		nonPrLogger: nonPrLogger,
 		prLogger:    prLogger,
+		messageCh:   messageCh,
+		wg:          new(sync.WaitGroup),
+		routed:      make(chan struct{}),
 	}
 }
 
@@ -36,6 +58,16 @@ func (lsc *loggingServerCloser) Close(ctx context.Context) error {
 }
 
 func (s *Server) Start(ctx context.Context, port string) (shared.Closer, error) {
+	if s.prLogger != nil {
+		if err := s.prLogger.ConsumeLoop(ctx, s.wg); err != nil {
+			s.logger.Error("failed to start prioritized log consumer", "error", err)
+			return nil, err
+		}
+		go s.routeMessages()
+	} else {
+		close(s.routed)
+	}
+
 	if err := s.nonPrLogger.Start(port); err != nil {
 		s.logger.Error("failed to start logger", "error", err)
 		return nil, err
@@ -46,6 +78,54 @@ func (s *Server) Start(ctx context.Context, port string) (shared.Closer, error)
 	}, nil
 }
 
+// routeMessages publishes stream messages to the sinks until the channel is closed
+func (s *Server) routeMessages() {
+	defer close(s.routed)
+
+	for msg := range s.messageCh {
+		entry, err := sink.NewEntry(msg, time.Now(), "")
+		if err != nil {
+			s.logger.Warn("dropping invalid log message",
+				"log_id", msg.LogID,
+				"event_type", msg.EventType,
+				"error", err)
+			continue
+		}
+		s.pipeline.Publish(entry)
+	}
+}
+
+// Shutdown stops accepting logs, then drains the sinks
 func (s *Server) Shutdown(ctx context.Context) error {
-	return nil
+	s.nonPrLogger.Stop(ctx)
+
+	if s.prLogger != nil {
+		// The channel is closed only once the consumer stopped sending to it;
+		// after a timeout it is left open and the router keeps draining it
+		if err := s.prLogger.Shutdown(ctx, s.wg); err != nil {
+			s.logger.Error("failed to shutdown prioritized log consumer", "error", err)
+		} else {
+			close(s.messageCh)
+		}
+	}
+	select {
+	case <-s.routed:
+	case <-ctx.Done():
+	}
+
+	err := s.pipeline.Close(ctx)
+	for _, stats := range s.pipeline.Stats() {
+		s.logger.Info("log sink stats",
+			"sink", stats.Name,
+			"written", stats.Written,
+			"failed", stats.Failed,
+			"dropped", stats.Dropped)
+	}
+
+	if s.redisClient != nil {
+		if err := s.redisClient.Close(); err != nil {
+			s.logger.Error("failed to close Redis client", "error", err)
+		}
+	}
+	return err
 }
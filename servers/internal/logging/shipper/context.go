@@ -0,0 +1,37 @@
+package shipper
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID attaches a request ID to the logs recorded with ctx
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestID returns the request ID set by WithRequestID or the chi RequestID middleware
+func requestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok && id != "" {
+		return id
+	}
+	return middleware.GetReqID(ctx)
+}
+
+// traceID returns the ID of the OpenTelemetry trace active in ctx
+func traceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		return span.TraceID().String()
+	}
+	return ""
+}
@@ -0,0 +1,445 @@
+// Package shipper provides a slog.Handler that ships application logs to the
+// logging server over gRPC or the Redis priority stream. Records are buffered
+// and sent in batches; records that cannot be shipped are written to a
+// fallback handler on stdout instead of being lost.
+package shipper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MatusOllah/slogcolor"
+	"github.com/google/uuid"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/event"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+)
+
+// LevelCritical is logged with the CRITICAL severity
+const LevelCritical = slog.LevelError + 4
+
+// DefaultEventType is the event_type of records without an event_type attribute
+const DefaultEventType = event.EventTypeAppLog
+
+// maxDataSize keeps the data payload below the server's 64 KiB limit
+const maxDataSize = 60 << 10
+
+// Config configures a Handler
+type Config struct {
+	// Level is the minimum level handled; defaults to INFO
+	Level slog.Leveler
+
+	// Service identity stamped on every log. ServiceType is a ServerType name.
+	ServiceName    string
+	ServiceType    string
+	ServiceVersion string
+	Environment    string
+	// ServerID defaults to the hostname
+	ServerID string
+	// EventType defaults to DefaultEventType
+	EventType string
+
+	// BatchSize sends a batch as soon as this many logs are queued
+	BatchSize int
+	// FlushInterval is the maximum time a log waits for its batch to fill
+	FlushInterval time.Duration
+	// QueueSize bounds the logs waiting to be sent; logs beyond it go to the fallback
+	QueueSize int
+	// SendTimeout bounds each Transport.Send call
+	SendTimeout time.Duration
+
+	// Tee also writes every record to the fallback, for local development
+	Tee bool
+	// Fallback receives records that could not be shipped; defaults to slogcolor on stdout
+	Fallback slog.Handler
+}
+
+// item is a queued log with the fallback handler of the logger that recorded it
+type item struct {
+	message  model.LogMessage
+	record   slog.Record
+	fallback slog.Handler
+}
+
+// core is the queue and sender shared by a Handler and its derived handlers
+type core struct {
+	config    Config
+	transport Transport
+	defaults  map[string]any
+	queue     chan item
+	done      chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	dropped atomic.Int64
+	failed  atomic.Int64
+}
+
+// Compile-time check to ensure Handler implements slog.Handler
+var _ slog.Handler = (*Handler)(nil)
+
+// Handler maps records to logs. Attributes named after a log field (user_id,
+// session_id, event_type, duration_ms, ...) fill that field; the message and
+// all other attributes are encoded as JSON into data.
+type Handler struct {
+	core     *core
+	attrs    []groupedAttr
+	groups   []string
+	fallback slog.Handler
+}
+
+// groupedAttr is an attribute added with WithAttrs under the groups open at the time
+type groupedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+// NewHandler starts shipping records through transport. The transport's clients
+// must not log through the returned handler, or a failing send would recurse.
+func NewHandler(transport Transport, config Config) *Handler {
+	if config.Level == nil {
+		config.Level = slog.LevelInfo
+	}
+	if config.ServerID == "" {
+		config.ServerID, _ = os.Hostname()
+	}
+	if config.EventType == "" {
+		config.EventType = DefaultEventType
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 10000
+	}
+	if config.SendTimeout <= 0 {
+		config.SendTimeout = 5 * time.Second
+	}
+	if config.Fallback == nil {
+		config.Fallback = slogcolor.NewHandler(os.Stdout, &slogcolor.Options{
+			Level:       config.Level,
+			TimeFormat:  time.DateTime,
+			SrcFileMode: slogcolor.ShortFile,
+		})
+	}
+
+	c := &core{
+		config:    config,
+		transport: transport,
+		defaults: map[string]any{
+			"service_name":    config.ServiceName,
+			"service_type":    config.ServiceType,
+			"service_version": config.ServiceVersion,
+			"environment":     config.Environment,
+			"server_id":       config.ServerID,
+			"event_type":      config.EventType,
+		},
+		queue: make(chan item, config.QueueSize),
+		done:  make(chan struct{}),
+	}
+	go c.run()
+
+	return &Handler{core: c, fallback: config.Fallback}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.core.config.Level.Level()
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if h.core.config.Tee {
+		if err := h.fallback.Handle(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	message := h.message(ctx, r)
+	if !h.core.enqueue(item{message: message, record: r.Clone(), fallback: h.fallback}) && !h.core.config.Tee {
+		return h.fallback.Handle(ctx, r)
+	}
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	clone := *h
+	clone.attrs = slices.Clone(h.attrs)
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, groupedAttr{groups: h.groups, attr: attr})
+	}
+	clone.fallback = h.fallback.WithAttrs(attrs)
+	return &clone
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(slices.Clone(h.groups), name)
+	clone.fallback = h.fallback.WithGroup(name)
+	return &clone
+}
+
+// Dropped returns the number of records written to the fallback because the queue was full
+func (h *Handler) Dropped() int64 {
+	return h.core.dropped.Load()
+}
+
+// Failed returns the number of records written to the fallback because sending failed
+func (h *Handler) Failed() int64 {
+	return h.core.failed.Load()
+}
+
+// Close sends the queued records and closes the transport. Records handled
+// afterwards go to the fallback.
+func (h *Handler) Close(ctx context.Context) error {
+	c := h.core
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return c.transport.Close(ctx)
+}
+
+// message maps a record to a log
+func (h *Handler) message(ctx context.Context, r slog.Record) model.LogMessage {
+	fields := make(map[string]any, len(h.core.defaults)+4)
+	for name, value := range h.core.defaults {
+		fields[name] = value
+	}
+	if id := requestID(ctx); id != "" {
+		fields["request_id"] = id
+	}
+
+	data := map[string]any{"msg": r.Message}
+	if id := traceID(ctx); id != "" {
+		data["trace_id"] = id
+	}
+
+	for _, ga := range h.attrs {
+		addAttr(fields, data, ga.groups, ga.attr)
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		addAttr(fields, data, h.groups, attr)
+		return true
+	})
+
+	// Every value in fields was checked by addAttr, so decoding cannot fail
+	message, _ := model.FromStreamValues(fields)
+	message.LogID = uuid.NewString()
+	message.Severity = severity(r.Level)
+	message.ServerTime = r.Time
+	message.Data = encodeData(data)
+	return message
+}
+
+// addAttr sets a log field from a top-level attribute named after it, or adds
+// the attribute to data under its groups. A nil fields adds everything to data.
+func addAttr(fields, data map[string]any, groups []string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	// Groups without a key are inlined
+	if attr.Value.Kind() == slog.KindGroup && attr.Key == "" {
+		for _, member := range attr.Value.Group() {
+			addAttr(fields, data, groups, member)
+		}
+		return
+	}
+
+	if fields != nil && len(groups) == 0 && shippedField(attr.Key) {
+		value := fieldValue(attr.Value)
+		if _, err := model.FromStreamValues(map[string]any{attr.Key: value}); err == nil {
+			fields[attr.Key] = value
+			return
+		}
+	}
+
+	target := data
+	for _, group := range groups {
+		nested, ok := target[group].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			target[group] = nested
+		}
+		target = nested
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		nested := make(map[string]any)
+		for _, member := range attr.Value.Group() {
+			addAttr(nil, nested, nil, member)
+		}
+		target[attr.Key] = nested
+		return
+	}
+	target[attr.Key] = dataValue(attr.Value)
+}
+
+// shippedField reports whether an attribute may set the log field of the same
+// name. The severity, time, ID and payloads are owned by the handler.
+func shippedField(key string) bool {
+	switch key {
+	case "log_id", "severity", "server_time", "data":
+		return false
+	}
+	return slices.Contains(model.FieldNames(), key)
+}
+
+// fieldValue formats a value as a stream field
+func fieldValue(v slog.Value) string {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		return fmt.Sprint(v.Duration().Milliseconds())
+	default:
+		return v.String()
+	}
+}
+
+// dataValue converts a value to one encoding/json can encode
+func dataValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		value := v.Any()
+		if err, ok := value.(error); ok {
+			return err.Error()
+		}
+		if _, err := json.Marshal(value); err != nil {
+			return fmt.Sprint(value)
+		}
+		return value
+	default:
+		return v.Any()
+	}
+}
+
+// encodeData encodes the data payload, keeping only the message when it is too large
+func encodeData(data map[string]any) string {
+	encoded, err := json.Marshal(data)
+	if err == nil && len(encoded) <= maxDataSize {
+		return string(encoded)
+	}
+
+	msg, _ := data["msg"].(string)
+	if len(msg) > maxDataSize/2 {
+		msg = msg[:maxDataSize/2]
+	}
+	encoded, _ = json.Marshal(map[string]any{"msg": msg, "truncated": true})
+	return string(encoded)
+}
+
+// severity maps a level to the nearest severity at or below it
+func severity(level slog.Level) string {
+	switch {
+	case level >= LevelCritical:
+		return pb.LogSeverity_CRITICAL.String()
+	case level >= slog.LevelError:
+		return pb.LogSeverity_ERROR.String()
+	case level >= slog.LevelWarn:
+		return pb.LogSeverity_WARNING.String()
+	case level >= slog.LevelInfo:
+		return pb.LogSeverity_INFO.String()
+	default:
+		return pb.LogSeverity_DEBUG.String()
+	}
+}
+
+// enqueue queues an item and reports whether it was accepted. It never blocks.
+func (c *core) enqueue(it item) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return false
+	}
+
+	select {
+	case c.queue <- it:
+		return true
+	default:
+		c.dropped.Add(1)
+		return false
+	}
+}
+
+func (c *core) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]item, 0, c.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		c.send(batch)
+		batch = make([]item, 0, c.config.BatchSize)
+	}
+
+	for {
+		select {
+		case it, ok := <-c.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, it)
+			if len(batch) >= c.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send ships a batch, writing it to the fallback when the transport fails
+func (c *core) send(batch []item) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.SendTimeout)
+	defer cancel()
+
+	messages := make([]model.LogMessage, len(batch))
+	for i, it := range batch {
+		messages[i] = it.message
+	}
+	if err := c.transport.Send(ctx, messages); err == nil {
+		return
+	}
+
+	c.failed.Add(int64(len(batch)))
+	if c.config.Tee {
+		return
+	}
+	for _, it := range batch {
+		_ = it.fallback.Handle(context.Background(), it.record)
+	}
+}
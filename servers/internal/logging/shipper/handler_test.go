@@ -0,0 +1,106 @@
+package shipper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+)
+
+// recordingTransport keeps sent messages and fails while err is set
+type recordingTransport struct {
+	mu       sync.Mutex
+	err      error
+	messages []model.LogMessage
+}
+
+func (t *recordingTransport) Send(ctx context.Context, messages []model.LogMessage) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return t.err
+	}
+	t.messages = append(t.messages, messages...)
+	return nil
+}
+
+func (t *recordingTransport) Close(ctx context.Context) error { return nil }
+
+func TestHandlerMapsRecords(t *testing.T) {
+	transport := &recordingTransport{}
+	h := NewHandler(transport, Config{
+		ServiceName: "api",
+		ServiceType: "OUTGAME",
+		Environment: "test",
+		Fallback:    slog.DiscardHandler,
+	})
+
+	logger := slog.New(h).With("user_id", "u1").WithGroup("req")
+	ctx := WithRequestID(context.Background(), "req-1")
+	logger.ErrorContext(ctx, "payment failed", "path", "/v1/pay", slog.Int("status_code", 502))
+	slog.New(h).Log(ctx, LevelCritical, "down", "event_type", "Outage", "duration_ms", 1500*time.Millisecond)
+
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(transport.messages))
+	}
+
+	got := transport.messages[0]
+	if got.Severity != "ERROR" || got.ServiceName != "api" || got.ServiceType != "OUTGAME" ||
+		got.UserID != "u1" || got.RequestID != "req-1" || got.EventType != DefaultEventType || got.LogID == "" {
+		t.Fatalf("unexpected message %+v", got)
+	}
+	// Attributes inside a group stay in data even when named after a field
+	var data map[string]any
+	if err := json.Unmarshal([]byte(got.Data), &data); err != nil {
+		t.Fatal(err)
+	}
+	req, _ := data["req"].(map[string]any)
+	if data["msg"] != "payment failed" || req["path"] != "/v1/pay" || req["status_code"] != float64(502) || got.StatusCode != 0 {
+		t.Fatalf("unexpected data %s", got.Data)
+	}
+
+	critical := transport.messages[1]
+	if critical.Severity != "CRITICAL" || critical.EventType != "Outage" || critical.DurationMs != 1500 {
+		t.Fatalf("unexpected message %+v", critical)
+	}
+}
+
+func TestHandlerFallsBackWhenSendFails(t *testing.T) {
+	var out bytes.Buffer
+	transport := &recordingTransport{err: errors.New("unavailable")}
+	h := NewHandler(transport, Config{Fallback: slog.NewTextHandler(&out, nil)})
+
+	slog.New(h).With("component", "billing").Info("kept locally")
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Failed() != 1 || !strings.Contains(out.String(), "kept locally") || !strings.Contains(out.String(), "component=billing") {
+		t.Fatalf("expected the record in the fallback, failed=%d output=%q", h.Failed(), out.String())
+	}
+}
+
+func TestHandlerTee(t *testing.T) {
+	var out bytes.Buffer
+	transport := &recordingTransport{}
+	h := NewHandler(transport, Config{Tee: true, Fallback: slog.NewTextHandler(&out, nil)})
+
+	slog.New(h).Warn("both")
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transport.messages) != 1 || strings.Count(out.String(), "both") != 1 {
+		t.Fatalf("expected the record shipped and written once, got %d shipped, output %q", len(transport.messages), out.String())
+	}
+}
@@ -0,0 +1,64 @@
+package shipper
+
+import (
+	"context"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/non_prioritized"
+	prioritized "github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/prioritzed"
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+)
+
+// Transport delivers batches of logs to the logging server
+type Transport interface {
+	Send(ctx context.Context, messages []model.LogMessage) error
+	Close(ctx context.Context) error
+}
+
+// Compile-time checks to ensure the transports implement Transport
+var (
+	_ Transport = (*GRPCTransport)(nil)
+	_ Transport = (*StreamTransport)(nil)
+)
+
+// GRPCTransport ships logs with SendLogBatch. Logs rejected by the server are
+// reported by the client's internal logger.
+type GRPCTransport struct {
+	client *non_prioritized.LoggerClient
+}
+
+func NewGRPCTransport(client *non_prioritized.LoggerClient) *GRPCTransport {
+	return &GRPCTransport{client: client}
+}
+
+func (t *GRPCTransport) Send(ctx context.Context, messages []model.LogMessage) error {
+	batch := &pb.LogBatchRequest{Logs: make([]*pb.LogRequest, len(messages))}
+	for i, message := range messages {
+		batch.Logs[i] = message.ToProto()
+	}
+	_, err := t.client.SendLogBatch(ctx, batch)
+	return err
+}
+
+// Close closes the client's connections
+func (t *GRPCTransport) Close(ctx context.Context) error {
+	return t.client.Close(ctx)
+}
+
+// StreamTransport ships logs through the priority log stream
+type StreamTransport struct {
+	client *prioritized.LoggerClient
+}
+
+func NewStreamTransport(client *prioritized.LoggerClient) *StreamTransport {
+	return &StreamTransport{client: client}
+}
+
+func (t *StreamTransport) Send(ctx context.Context, messages []model.LogMessage) error {
+	return t.client.SendLogs(ctx, messages)
+}
+
+// Close closes the client's broker connection; a Redis client is owned by the caller
+func (t *StreamTransport) Close(ctx context.Context) error {
+	return t.client.Close()
+}
@@ -0,0 +1,24 @@
+package sink
+
+import (
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+)
+
+// Entry is a validated log with the metadata added on ingest. It encodes to
+// JSON as the log fields plus received_time, peer_ip and event_issue.
+type Entry struct {
+	model.LogMessage
+	ReceivedTime time.Time `json:"received_time"`
+	// PeerIP is the address of the service that sent the log
+	PeerIP string `json:"peer_ip,omitempty"`
+	// EventIssue tags entries that do not conform to the event catalog
+	EventIssue string `json:"event_issue,omitempty"`
+}
+
+// Urgent reports whether the entry is an error or worse, which sinks receive first
+func (e Entry) Urgent() bool {
+	return pb.LogSeverity_value[e.Severity] >= int32(pb.LogSeverity_ERROR)
+}
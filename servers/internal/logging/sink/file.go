@@ -0,0 +1,154 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Compile-time check to ensure FileSink implements Sink
+var _ Sink = (*FileSink)(nil)
+
+const (
+	activeFile = "logs.jsonl"
+	// rotatedLayout names rotated files so they sort by rotation time
+	rotatedLayout = "logs-20060102T150405.000000000.jsonl"
+)
+
+// FileConfig configures a FileSink
+type FileConfig struct {
+	Dir string
+	// MaxSize rotates the active file once it would grow past this many bytes
+	MaxSize int64
+	// MaxAge removes rotated files older than this; zero keeps them forever
+	MaxAge time.Duration
+}
+
+// FileSink appends JSON lines to Dir/logs.jsonl and rotates it by size
+type FileSink struct {
+	config FileConfig
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileSink opens or creates the active file in config.Dir
+func NewFileSink(config FileConfig) (*FileSink, error) {
+	if config.MaxSize <= 0 {
+		config.MaxSize = 100 << 20
+	}
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	s := &FileSink{config: config}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Write(ctx context.Context, entries []Entry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode log entry: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size > 0 && s.size+int64(buf.Len()) > s.config.MaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(buf.Bytes())
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write log file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(filepath.Join(s.config.Dir, activeFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+// rotate renames the active file after the current time, opens a new one
+// and removes rotated files past MaxAge
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rotated := filepath.Join(s.config.Dir, now.Format(rotatedLayout))
+	if err := os.Rename(filepath.Join(s.config.Dir, activeFile), rotated); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	if s.config.MaxAge > 0 {
+		s.prune(now.Add(-s.config.MaxAge))
+	}
+	return nil
+}
+
+// prune removes rotated files rotated before cutoff. Failures are retried on the next rotation.
+func (s *FileSink) prune(cutoff time.Time) {
+	rotated, err := filepath.Glob(filepath.Join(s.config.Dir, "logs-*.jsonl"))
+	if err != nil {
+		return
+	}
+	slices.Sort(rotated)
+
+	for _, path := range rotated {
+		at, err := time.Parse(rotatedLayout, filepath.Base(path))
+		if err != nil {
+			continue
+		}
+		if !at.Before(cutoff) {
+			break
+		}
+		os.Remove(path)
+	}
+}
@@ -0,0 +1,44 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Compile-time check to ensure JSONSink implements Sink
+var _ Sink = (*JSONSink)(nil)
+
+// JSONSink writes entries as JSON lines, typically to stdout
+type JSONSink struct {
+	name string
+	mu   sync.Mutex
+	enc  *json.Encoder
+}
+
+// NewJSONSink writes to w under the given sink name
+func NewJSONSink(name string, w io.Writer) *JSONSink {
+	return &JSONSink{name: name, enc: json.NewEncoder(w)}
+}
+
+func (s *JSONSink) Name() string {
+	return s.name
+}
+
+func (s *JSONSink) Write(ctx context.Context, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range entries {
+		if err := s.enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write log entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *JSONSink) Close(ctx context.Context) error {
+	return nil
+}
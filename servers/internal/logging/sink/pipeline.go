@@ -0,0 +1,270 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sink persists batches of log entries
+type Sink interface {
+	// Name identifies the sink in logs and stats
+	Name() string
+	Write(ctx context.Context, entries []Entry) error
+	Close(ctx context.Context) error
+}
+
+// Stage rewrites entries before they are queued for any sink
+type Stage interface {
+	// Name identifies the stage in logs
+	Name() string
+	// Apply modifies entry in place and reports whether it should be kept
+	Apply(entry *Entry) bool
+}
+
+// Config tunes the per-sink queues of a Pipeline
+type Config struct {
+	// Stages are applied in order by Publish, before the entry is shared between sinks
+	Stages []Stage
+	// QueueSize bounds the entries waiting for each sink; newer entries are dropped beyond it
+	QueueSize int
+	// BatchSize is the maximum number of entries per Write
+	BatchSize int
+	// FlushInterval is the maximum time an entry waits for its batch to fill
+	FlushInterval time.Duration
+	// WriteTimeout bounds each Write
+	WriteTimeout time.Duration
+}
+
+// Stats are the counters of a sink
+type Stats struct {
+	Name    string `json:"name"`
+	Written int64  `json:"written"`
+	Failed  int64  `json:"failed"`
+	Dropped int64  `json:"dropped"`
+}
+
+// Pipeline fans entries out to sinks. Every sink has its own queues and
+// goroutine, so a slow or failing sink never delays or fails the others.
+// Urgent entries have a separate queue that is served first and flushed
+// without waiting for a batch to fill.
+type Pipeline struct {
+	logger  *slog.Logger
+	config  Config
+	workers []*worker
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type worker struct {
+	sink    Sink
+	urgent  chan Entry
+	queue   chan Entry
+	written atomic.Int64
+	failed  atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewPipeline starts a worker per sink
+func NewPipeline(logger *slog.Logger, config Config, sinks ...Sink) *Pipeline {
+	if config.QueueSize <= 0 {
+		config.QueueSize = 10000
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+
+	p := &Pipeline{logger: logger, config: config}
+	for _, s := range sinks {
+		w := &worker{
+			sink:   s,
+			urgent: make(chan Entry, config.QueueSize),
+			queue:  make(chan Entry, config.QueueSize),
+		}
+		p.workers = append(p.workers, w)
+		p.wg.Go(func() { p.run(w) })
+	}
+	return p
+}
+
+// Publish applies the stages, then queues entry for every sink without blocking
+func (p *Pipeline) Publish(entry Entry) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+
+	for _, stage := range p.config.Stages {
+		if !stage.Apply(&entry) {
+			return
+		}
+	}
+
+	urgent := entry.Urgent()
+	for _, w := range p.workers {
+		queue := w.queue
+		if urgent {
+			queue = w.urgent
+		}
+		select {
+		case queue <- entry:
+		default:
+			if w.dropped.Add(1) == 1 {
+				p.logger.Warn("log sink queue full; dropping entries", "sink", w.sink.Name())
+			}
+		}
+	}
+}
+
+// Stats returns the counters of every sink
+func (p *Pipeline) Stats() []Stats {
+	stats := make([]Stats, 0, len(p.workers))
+	for _, w := range p.workers {
+		stats = append(stats, Stats{
+			Name:    w.sink.Name(),
+			Written: w.written.Load(),
+			Failed:  w.failed.Load(),
+			Dropped: w.dropped.Load(),
+		})
+	}
+	return stats
+}
+
+// Close stops accepting entries, drains the queues and closes the sinks
+func (p *Pipeline) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	for _, w := range p.workers {
+		close(w.urgent)
+		close(w.queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain log sinks: %w", ctx.Err())
+	}
+
+	var firstErr error
+	for _, w := range p.workers {
+		if err := w.sink.Close(ctx); err != nil {
+			p.logger.Error("failed to close log sink", "sink", w.sink.Name(), "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// run batches the queues of w until both are closed
+func (p *Pipeline) run(w *worker) {
+	ticker := time.NewTicker(p.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, p.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		p.write(w, batch)
+		batch = make([]Entry, 0, p.config.BatchSize)
+	}
+
+	// A closed queue is set to nil so the selects stop receiving from it
+	urgent, queue := w.urgent, w.queue
+	takeUrgent := func(entry Entry, ok bool) {
+		if !ok {
+			urgent = nil
+			return
+		}
+		batch = append(batch, entry)
+		// Take the urgent entries already waiting, then flush right away
+		for drained := false; !drained && len(batch) < p.config.BatchSize; {
+			select {
+			case entry, ok := <-urgent:
+				if !ok {
+					urgent = nil
+					drained = true
+					break
+				}
+				batch = append(batch, entry)
+			default:
+				drained = true
+			}
+		}
+		flush()
+	}
+
+	for urgent != nil || queue != nil {
+		select {
+		case entry, ok := <-urgent:
+			takeUrgent(entry, ok)
+			continue
+		default:
+		}
+
+		select {
+		case entry, ok := <-urgent:
+			takeUrgent(entry, ok)
+		case entry, ok := <-queue:
+			if !ok {
+				queue = nil
+				continue
+			}
+			batch = append(batch, entry)
+			if len(batch) >= p.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+	flush()
+}
+
+// write hands a batch to the sink, containing its errors and panics
+func (p *Pipeline) write(w *worker, batch []Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.WriteTimeout)
+	defer cancel()
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("sink panicked: %v", r)
+			}
+		}()
+		return w.sink.Write(ctx, batch)
+	}()
+	if err != nil {
+		w.failed.Add(int64(len(batch)))
+		p.logger.Error("failed to write logs to sink",
+			"sink", w.sink.Name(),
+			"entries", len(batch),
+			"error", err)
+		return
+	}
+	w.written.Add(int64(len(batch)))
+}
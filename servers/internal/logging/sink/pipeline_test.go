@@ -0,0 +1,121 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+)
+
+type recordingSink struct {
+	name string
+	err  error
+	mu   sync.Mutex
+	logs []string
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Write(ctx context.Context, entries []Entry) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range entries {
+		s.logs = append(s.logs, e.LogID)
+	}
+	return nil
+}
+
+func (s *recordingSink) Close(ctx context.Context) error { return nil }
+
+func TestPipelineIsolatesFailingSink(t *testing.T) {
+	good := &recordingSink{name: "good"}
+	bad := &recordingSink{name: "bad", err: errors.New("unavailable")}
+	p := NewPipeline(slog.New(slog.DiscardHandler), Config{BatchSize: 2}, bad, good)
+
+	for _, id := range []string{"a", "b", "c"} {
+		p.Publish(Entry{LogMessage: model.LogMessage{LogID: id}, ReceivedTime: time.Now()})
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(good.logs) != 3 {
+		t.Fatalf("expected the healthy sink to receive 3 entries, got %v", good.logs)
+	}
+	stats := p.Stats()
+	if stats[0].Failed != 3 || stats[1].Written != 3 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestFileSinkRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSink(FileConfig{Dir: dir, MaxSize: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for range 5 {
+		entry := Entry{LogMessage: model.LogMessage{LogID: "id", EventType: "Classic_Play"}, ReceivedTime: time.Now()}
+		if err := s.Write(ctx, []Entry{entry}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, _ := filepath.Glob(filepath.Join(dir, "logs-*.jsonl"))
+	if len(rotated) == 0 {
+		t.Fatal("expected rotated files")
+	}
+	info, err := os.Stat(filepath.Join(dir, activeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 200 {
+		t.Fatalf("active file grew to %d bytes past the limit", info.Size())
+	}
+}
+
+// blockingSink holds its first Write until release is closed
+type blockingSink struct {
+	recordingSink
+	release chan struct{}
+	once    sync.Once
+}
+
+func (s *blockingSink) Write(ctx context.Context, entries []Entry) error {
+	s.once.Do(func() { <-s.release })
+	return s.recordingSink.Write(ctx, entries)
+}
+
+func TestPipelineServesUrgentEntriesFirst(t *testing.T) {
+	s := &blockingSink{recordingSink: recordingSink{name: "blocking"}, release: make(chan struct{})}
+	p := NewPipeline(slog.New(slog.DiscardHandler), Config{BatchSize: 100, FlushInterval: time.Hour}, s)
+
+	// The first urgent entry is flushed immediately and blocks the worker,
+	// so everything after it is queued while the sink is busy
+	p.Publish(Entry{LogMessage: model.LogMessage{LogID: "first", Severity: "ERROR"}})
+	time.Sleep(20 * time.Millisecond)
+	p.Publish(Entry{LogMessage: model.LogMessage{LogID: "info", Severity: "INFO"}})
+	p.Publish(Entry{LogMessage: model.LogMessage{LogID: "critical", Severity: "CRITICAL"}})
+	close(s.release)
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.logs) != 3 || s.logs[1] != "critical" {
+		t.Fatalf("expected the critical entry before the info entry, got %v", s.logs)
+	}
+}
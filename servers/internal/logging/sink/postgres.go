@@ -0,0 +1,81 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/sqlc/postgres"
+)
+
+// Compile-time check to ensure PostgresSink implements Sink
+var _ Sink = (*PostgresSink)(nil)
+
+// PostgresSink inserts each batch into log_entries in a single transaction
+type PostgresSink struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewPostgresSink(pool *pgxpool.Pool) *PostgresSink {
+	return &PostgresSink{pool: pool, queries: sqlc.New(pool)}
+}
+
+func (s *PostgresSink) Name() string {
+	return "postgres"
+}
+
+func (s *PostgresSink) Write(ctx context.Context, entries []Entry) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+	for _, entry := range entries {
+		params, err := insertParams(entry)
+		if err != nil {
+			return err
+		}
+		if err := q.InsertLogEntry(ctx, params); err != nil {
+			return fmt.Errorf("failed to insert log entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit log entries: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresSink) Close(ctx context.Context) error {
+	return nil
+}
+
+func insertParams(entry Entry) (sqlc.InsertLogEntryParams, error) {
+	record, err := json.Marshal(entry)
+	if err != nil {
+		return sqlc.InsertLogEntryParams{}, err
+	}
+
+	params := sqlc.InsertLogEntryParams{
+		LogID:       entry.LogID,
+		ReceivedAt:  pgtype.Timestamptz{Time: entry.ReceivedTime, Valid: true},
+		ServerTime:  pgtype.Timestamptz{Time: entry.ServerTime, Valid: !entry.ServerTime.IsZero()},
+		Severity:    entry.Severity,
+		ServiceType: entry.ServiceType,
+		ServiceName: entry.ServiceName,
+		Environment: entry.Environment,
+		EventType:   entry.EventType,
+		RequestID:   entry.RequestID,
+		SessionID:   entry.SessionID,
+		UserID:      entry.UserID,
+		PeerIp:      entry.PeerIP,
+		Data:        entry.Data,
+		Record:      record,
+	}
+	return params, nil
+}
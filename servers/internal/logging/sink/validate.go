@@ -0,0 +1,73 @@
+package sink
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	pb "github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/pb/logger"
+)
+
+// maxPayloadSize bounds the data and client_data JSON payloads
+const maxPayloadSize = 64 << 10
+
+// Validate rejects logs the sinks cannot store meaningfully. Enum names must be
+// upper case; NewEntry normalizes them first.
+func Validate(log model.LogMessage) error {
+	if log.EventType == "" {
+		return errors.New("event_type is required")
+	}
+	if _, ok := pb.LogSeverity_value[log.Severity]; !ok {
+		return fmt.Errorf("unknown severity %q", log.Severity)
+	}
+	if _, ok := pb.ServerType_value[log.ServiceType]; !ok {
+		return fmt.Errorf("unknown service_type %q", log.ServiceType)
+	}
+	if err := validatePayload("data", log.Data); err != nil {
+		return err
+	}
+	return validatePayload("client_data", log.ClientData)
+}
+
+func validatePayload(field, payload string) error {
+	if payload == "" {
+		return nil
+	}
+	if len(payload) > maxPayloadSize {
+		return fmt.Errorf("%s exceeds %d bytes", field, maxPayloadSize)
+	}
+	if !json.Valid([]byte(payload)) {
+		return fmt.Errorf("%s is not valid JSON", field)
+	}
+	return nil
+}
+
+// NewEntry validates a log and stamps it as received at receivedAt from peerIP.
+// Enum names are upper cased and default to their zero values; a missing log ID
+// and server time are filled so every stored entry can be identified and ordered.
+func NewEntry(log model.LogMessage, receivedAt time.Time, peerIP string) (Entry, error) {
+	log.Severity = enumName(log.Severity, pb.LogSeverity_DEBUG.String())
+	log.ServiceType = enumName(log.ServiceType, pb.ServerType_UNKNOWN.String())
+	if err := Validate(log); err != nil {
+		return Entry{}, err
+	}
+
+	if log.LogID == "" {
+		log.LogID = uuid.NewString()
+	}
+	if log.ServerTime.IsZero() {
+		log.ServerTime = receivedAt
+	}
+	return Entry{LogMessage: log, ReceivedTime: receivedAt, PeerIP: peerIP}, nil
+}
+
+func enumName(name, zero string) string {
+	if name == "" {
+		return zero
+	}
+	return strings.ToUpper(name)
+}
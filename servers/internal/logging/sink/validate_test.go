@@ -0,0 +1,46 @@
+package sink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+)
+
+func TestValidate(t *testing.T) {
+	valid := model.LogMessage{EventType: "Classic_Play", Severity: "INFO", ServiceType: "INGAME", Data: `{"score":3}`}
+	tests := []struct {
+		name    string
+		edit    func(*model.LogMessage)
+		wantErr bool
+	}{
+		{"valid", func(*model.LogMessage) {}, false},
+		{"missing event type", func(m *model.LogMessage) { m.EventType = "" }, true},
+		{"unknown severity", func(m *model.LogMessage) { m.Severity = "LOUD" }, true},
+		{"unknown service type", func(m *model.LogMessage) { m.ServiceType = "42" }, true},
+		{"invalid data", func(m *model.LogMessage) { m.Data = "{" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := valid
+			tt.edit(&log)
+			if err := Validate(log); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewEntryFillsIdentity(t *testing.T) {
+	now := time.Now()
+	entry, err := NewEntry(model.LogMessage{EventType: "Classic_Play", Severity: "error"}, now, "10.0.0.7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.LogID == "" || !entry.ServerTime.Equal(now) {
+		t.Fatalf("expected log ID and server time to be filled, got %+v", entry.LogMessage)
+	}
+	if entry.Severity != "ERROR" || entry.ServiceType != "UNKNOWN" || !entry.Urgent() {
+		t.Fatalf("expected normalized enums, got %q %q", entry.Severity, entry.ServiceType)
+	}
+}
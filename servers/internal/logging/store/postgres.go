@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/sqlc/postgres"
+)
+
+// Compile-time check to ensure PostgresStore implements Querier
+var _ Querier = (*PostgresStore)(nil)
+
+// PostgresStore searches log_entries
+type PostgresStore struct {
+	queries *sqlc.Queries
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{queries: sqlc.New(pool)}
+}
+
+func (s *PostgresStore) Search(ctx context.Context, q Query) (Page, error) {
+	limit := q.limit()
+	params := sqlc.SearchLogEntriesParams{
+		FromTime:    pgtype.Timestamptz{Time: q.From, Valid: true},
+		ToTime:      pgtype.Timestamptz{Time: q.To, Valid: true},
+		Severities:  q.Severities,
+		ServiceName: optionalText(q.ServiceName),
+		ServiceType: optionalText(q.ServiceType),
+		EventType:   optionalText(q.EventType),
+		UserID:      optionalText(q.UserID),
+		SessionID:   optionalText(q.SessionID),
+		RequestID:   optionalText(q.RequestID),
+		Search:      optionalText(q.Text),
+		// One extra row tells whether another page follows
+		RowLimit: int32(limit + 1),
+	}
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		params.CursorTime = pgtype.Timestamptz{Time: c.ReceivedAt, Valid: true}
+		params.CursorID = pgtype.Int8{Int64: c.ID, Valid: true}
+	}
+
+	rows, err := s.queries.SearchLogEntries(ctx, params)
+	if err != nil {
+		return Page{}, fmt.Errorf("failed to search log entries: %w", err)
+	}
+
+	page := Page{Entries: make([]json.RawMessage, 0, min(len(rows), limit))}
+	for i, row := range rows {
+		if i == limit {
+			last := rows[limit-1]
+			page.NextCursor = cursor{ReceivedAt: last.ReceivedAt.Time, ID: last.ID}.encode()
+			break
+		}
+		page.Entries = append(page.Entries, row.Record)
+	}
+	return page, nil
+}
+
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/sqlc/postgres"
+)
+
+// partitionsAhead is the number of days, today included, with a partition ready
+const partitionsAhead = 3
+
+// Retention creates upcoming log_entries partitions and drops expired ones
+type Retention struct {
+	logger  *slog.Logger
+	queries *sqlc.Queries
+	// days is the number of days kept, today included
+	days int
+}
+
+func NewRetention(logger *slog.Logger, pool *pgxpool.Pool, days int) *Retention {
+	return &Retention{logger: logger, queries: sqlc.New(pool), days: max(days, 1)}
+}
+
+// Apply creates the partitions of the next days and drops those older than the
+// retention period. It returns the number of partitions created and dropped.
+func (r *Retention) Apply(ctx context.Context, now time.Time) (int32, int32, error) {
+	from, to, before := partitionDays(now, r.days)
+
+	created, err := r.queries.CreateLogEntryPartitions(ctx, sqlc.CreateLogEntryPartitionsParams{
+		FromDay: pgtype.Date{Time: from, Valid: true},
+		ToDay:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create log entry partitions: %w", err)
+	}
+
+	dropped, err := r.queries.DropLogEntryPartitions(ctx, pgtype.Date{Time: before, Valid: true})
+	if err != nil {
+		return created, 0, fmt.Errorf("failed to drop log entry partitions: %w", err)
+	}
+	return created, dropped, nil
+}
+
+// Run applies the retention every interval until ctx is done
+func (r *Retention) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			created, dropped, err := r.Apply(ctx, now)
+			if err != nil {
+				r.logger.Error("failed to apply log retention", "error", err)
+				continue
+			}
+			if created > 0 || dropped > 0 {
+				r.logger.Info("applied log retention", "created", created, "dropped", dropped)
+			}
+		}
+	}
+}
+
+// partitionDays returns the UTC days [from, to) that need a partition and the
+// first day kept
+func partitionDays(now time.Time, days int) (time.Time, time.Time, time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today, today.AddDate(0, 0, partitionsAhead), today.AddDate(0, 0, 1-days)
+}
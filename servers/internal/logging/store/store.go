@@ -0,0 +1,97 @@
+// Package store reads the log entries written by the Postgres sink and keeps
+// their daily partitions: upcoming days are created ahead of ingestion and
+// days past the retention period are dropped whole.
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query selects log entries received in [From, To), newest first. Empty
+// filters match every entry; Text is a web-search style full-text query over data.
+type Query struct {
+	From        time.Time
+	To          time.Time
+	Severities  []string
+	ServiceName string
+	ServiceType string
+	EventType   string
+	UserID      string
+	SessionID   string
+	RequestID   string
+	Text        string
+	// Cursor continues after the last entry of a previous page
+	Cursor string
+	Limit  int
+}
+
+// DefaultLimit is the page size when Query.Limit is unset
+const DefaultLimit = 100
+
+// MaxLimit bounds the page size
+const MaxLimit = 1000
+
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultLimit
+	}
+	return min(q.Limit, MaxLimit)
+}
+
+// Page is a page of log entries. NextCursor is empty on the last page.
+type Page struct {
+	// Entries are the stored log records, including received_time and peer_ip
+	Entries    []json.RawMessage `json:"entries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// Querier searches stored log entries
+type Querier interface {
+	Search(ctx context.Context, q Query) (Page, error)
+}
+
+// cursor is the position of the last entry of a page in (received_at, id) order
+type cursor struct {
+	ReceivedAt time.Time
+	ID         int64
+}
+
+func (c cursor) encode() string {
+	raw := strconv.FormatInt(c.ReceivedAt.UnixNano(), 10) + ":" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+func decodeCursor(s string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, errInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return cursor{}, errInvalidCursor
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return cursor{}, errInvalidCursor
+	}
+	c := cursor{ReceivedAt: time.Unix(0, n).UTC()}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", errInvalidCursor, err)
+	}
+	return c, nil
+}
+
+// IsInvalidCursor reports whether err was caused by a malformed Query.Cursor
+func IsInvalidCursor(err error) bool {
+	return errors.Is(err, errInvalidCursor)
+}
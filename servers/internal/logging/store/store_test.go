@@ -0,0 +1,38 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := cursor{ReceivedAt: time.Date(2026, 10, 18, 12, 0, 0, 123456789, time.UTC), ID: 42}
+	got, err := decodeCursor(c.encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != c {
+		t.Fatalf("expected %+v, got %+v", c, got)
+	}
+
+	for _, bad := range []string{"???", "bm9jb2xvbg", "MTI6YQ"} {
+		if _, err := decodeCursor(bad); !IsInvalidCursor(err) {
+			t.Fatalf("expected an invalid cursor error for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestPartitionDays(t *testing.T) {
+	now := time.Date(2026, 10, 18, 23, 30, 0, 0, time.FixedZone("KST", 9*3600))
+	from, to, before := partitionDays(now, 30)
+
+	if want := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Fatalf("expected partitions from %v, got %v", want, from)
+	}
+	if want := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Fatalf("expected partitions to %v, got %v", want, to)
+	}
+	if want := time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC); !before.Equal(want) {
+		t.Fatalf("expected partitions before %v to be dropped, got %v", want, before)
+	}
+}
@@ -0,0 +1,137 @@
+// Package tail streams ingested log entries to live subscribers. The Hub is a
+// sink of the pipeline, so it gets its own queue; each subscriber has a bounded
+// buffer and entries it cannot keep up with are counted as dropped.
+package tail
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+// Filter selects the entries a subscriber receives. Empty fields match everything.
+type Filter struct {
+	Severities  []string
+	ServiceName string
+	ServiceType string
+	UserID      string
+}
+
+// Matches reports whether the entry passes the filter
+func (f Filter) Matches(e sink.Entry) bool {
+	if len(f.Severities) > 0 && !slices.Contains(f.Severities, e.Severity) {
+		return false
+	}
+	if f.ServiceName != "" && f.ServiceName != e.ServiceName {
+		return false
+	}
+	if f.ServiceType != "" && !strings.EqualFold(f.ServiceType, e.ServiceType) {
+		return false
+	}
+	return f.UserID == "" || f.UserID == e.UserID
+}
+
+// Subscription receives matching entries on C until it is closed
+type Subscription struct {
+	C       <-chan sink.Entry
+	ch      chan sink.Entry
+	filter  Filter
+	dropped atomic.Int64
+	hub     *Hub
+	once    sync.Once
+}
+
+// Dropped returns the number of entries skipped because the buffer was full
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close unsubscribes and closes C
+func (s *Subscription) Close() {
+	s.hub.remove(s)
+}
+
+// Compile-time check to ensure Hub implements sink.Sink
+var _ sink.Sink = (*Hub)(nil)
+
+// Hub fans entries out to subscribers without ever blocking on them
+type Hub struct {
+	mu     sync.RWMutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe registers a subscriber with room for buffer entries. The
+// subscription is closed immediately when the hub is closed.
+func (h *Hub) Subscribe(filter Filter, buffer int) *Subscription {
+	ch := make(chan sink.Entry, max(buffer, 1))
+	s := &Subscription{C: ch, ch: ch, filter: filter, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		s.once.Do(func() { close(ch) })
+		return s
+	}
+	h.subs[s] = struct{}{}
+	return s
+}
+
+// Subscribers returns the number of active subscriptions
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+func (h *Hub) remove(s *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs, s)
+	s.once.Do(func() { close(s.ch) })
+}
+
+func (h *Hub) Name() string {
+	return "tail"
+}
+
+// Write offers each entry to the matching subscribers
+func (h *Hub) Write(ctx context.Context, entries []sink.Entry) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for s := range h.subs {
+		for _, entry := range entries {
+			if !s.filter.Matches(entry) {
+				continue
+			}
+			select {
+			case s.ch <- entry:
+			default:
+				s.dropped.Add(1)
+			}
+		}
+	}
+	return nil
+}
+
+// Close ends every subscription
+func (h *Hub) Close(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for s := range h.subs {
+		delete(h.subs, s)
+		s.once.Do(func() { close(s.ch) })
+	}
+	return nil
+}
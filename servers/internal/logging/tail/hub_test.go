@@ -0,0 +1,49 @@
+package tail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/model"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/logging/sink"
+)
+
+func entry(id, severity, user string) sink.Entry {
+	return sink.Entry{LogMessage: model.LogMessage{LogID: id, Severity: severity, UserID: user}}
+}
+
+func TestHubFiltersAndDropsForSlowSubscribers(t *testing.T) {
+	hub := NewHub()
+	errors := hub.Subscribe(Filter{Severities: []string{"ERROR"}}, 1)
+	user := hub.Subscribe(Filter{UserID: "u1"}, 10)
+
+	ctx := context.Background()
+	if err := hub.Write(ctx, []sink.Entry{
+		entry("a", "ERROR", "u1"),
+		entry("b", "INFO", "u1"),
+		entry("c", "ERROR", "u2"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := (<-errors.C).LogID; got != "a" {
+		t.Fatalf("expected the first error, got %q", got)
+	}
+	if errors.Dropped() != 1 {
+		t.Fatalf("expected the second error to be dropped, got %d", errors.Dropped())
+	}
+	if len(user.C) != 2 || user.Dropped() != 0 {
+		t.Fatalf("expected both u1 entries, got %d (dropped %d)", len(user.C), user.Dropped())
+	}
+
+	errors.Close()
+	if hub.Subscribers() != 1 {
+		t.Fatalf("expected one subscriber left, got %d", hub.Subscribers())
+	}
+	if err := hub.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for range user.C {
+	}
+	user.Close()
+}
@@ -0,0 +1,111 @@
+package backends
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/memory"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/natsjs"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+)
+
+var natsURL = shared.EnvString("NATS_URL", "nats://127.0.0.1:4222")
+
+// ownedConsumer closes its backend once the consumer has shut down
+type ownedConsumer struct {
+	broker.StreamConsumer
+	backend broker.Backend
+}
+
+func (o *ownedConsumer) Shutdown(ctx context.Context, wg *sync.WaitGroup) error {
+	err := o.StreamConsumer.Shutdown(ctx, wg)
+	if closeErr := o.backend.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Option configures a stream consumer created by NewStreamConsumer
+type Option[T any] func(idempotentConsumer[T])
+
+// idempotentConsumer is implemented by both redisstream.Consumer and broker.Consumer
+type idempotentConsumer[T any] interface {
+	UseIdempotency(store idempotency.Store, idFunc idempotency.IDFunc[T])
+}
+
+// WithIdempotency skips redelivered messages whose ID was already handed off
+func WithIdempotency[T any](store idempotency.Store, idFunc idempotency.IDFunc[T]) Option[T] {
+	return func(c idempotentConsumer[T]) {
+		c.UseIdempotency(store, idFunc)
+	}
+}
+
+// Open returns a Backend for kind. The redis kind is not a Backend; Redis
+// streams are consumed through redisstream.Consumer directly.
+func Open(kind broker.Kind) (broker.Backend, error) {
+	switch kind {
+	case broker.KindMemory:
+		return memory.Default(), nil
+	case broker.KindNATS:
+		return natsjs.Connect(natsURL)
+	default:
+		return nil, fmt.Errorf("broker backend %q cannot be opened as a generic backend", kind)
+	}
+}
+
+// NewStreamConsumer creates a stream consumer on the selected backend. redisClient
+// is only used by the redis backend and may be nil otherwise.
+func NewStreamConsumer[T any](
+	kind broker.Kind,
+	logger *slog.Logger,
+	redisClient *redis.Client,
+	transferCh chan<- T,
+	parseFunc redisstream.ParseFunc[T],
+	config redisstream.Config,
+	opts ...Option[T],
+) (broker.StreamConsumer, error) {
+	logger.Info("creating stream consumer", "backend", kind, "stream_key", config.StreamKey)
+
+	if kind == broker.KindRedis {
+		if redisClient == nil {
+			return nil, fmt.Errorf("redis backend selected but no Redis client is available")
+		}
+		c := redisstream.NewConsumer(logger, redisClient, transferCh, parseFunc, config)
+		for _, opt := range opts {
+			opt(c)
+		}
+		return c, nil
+	}
+
+	backend, err := Open(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	c := broker.NewConsumer(logger, backend, transferCh, parseFunc, config)
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return &ownedConsumer{
+		StreamConsumer: c,
+		backend:        backend,
+	}, nil
+}
+
+// NewPublisher returns a publisher for kind. redisClient is only used by the redis backend.
+func NewPublisher(kind broker.Kind, redisClient *redis.Client) (broker.Publisher, error) {
+	if kind == broker.KindRedis {
+		if redisClient == nil {
+			return nil, fmt.Errorf("redis backend selected but no Redis client is available")
+		}
+		return redisstream.NewPublisher(redisClient), nil
+	}
+	return Open(kind)
+}
@@ -0,0 +1,119 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+)
+
+// Kind identifies a broker backend
+type Kind string
+
+const (
+	KindRedis  Kind = "redis"
+	KindMemory Kind = "memory"
+	KindNATS   Kind = "nats"
+)
+
+// ParseKind validates a backend name read from configuration
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(s); k {
+	case KindRedis, KindMemory, KindNATS:
+		return k, nil
+	default:
+		return "", fmt.Errorf("unknown broker backend %q (expected redis, memory or nats)", s)
+	}
+}
+
+// Delivery is a single message handed to a consumer by a Backend
+type Delivery struct {
+	ID     string
+	Values map[string]interface{}
+	// Attempts is the number of times the message was delivered, including this one
+	Attempts int64
+}
+
+// FetchRequest describes a read from a consumer group
+type FetchRequest struct {
+	Stream   string
+	Group    string
+	Consumer string
+	Count    int
+	Block    time.Duration
+	// AckWait is how long a delivered message may stay unacknowledged before it is redelivered
+	AckWait time.Duration
+}
+
+// Publisher appends messages to a stream
+type Publisher interface {
+	Publish(ctx context.Context, stream string, values map[string]interface{}) (string, error)
+}
+
+// BatchPublisher appends several messages to a stream in one round trip
+type BatchPublisher interface {
+	PublishBatch(ctx context.Context, stream string, batch []map[string]interface{}) error
+}
+
+// Backend is a message broker with consumer groups and explicit acknowledgements.
+// Implementations must redeliver unacknowledged messages after AckWait and count
+// deliveries, so that the shared Consumer can apply retry and dead-letter rules.
+type Backend interface {
+	Publisher
+
+	// EnsureGroup creates the stream and consumer group when they do not exist
+	EnsureGroup(ctx context.Context, stream, group string, ackWait time.Duration) error
+
+	// Fetch returns redeliveries first, then new messages. It blocks up to
+	// req.Block when nothing is available and returns an empty slice on timeout.
+	Fetch(ctx context.Context, req FetchRequest) ([]Delivery, error)
+
+	// Ack acknowledges processed messages
+	Ack(ctx context.Context, stream, group string, ids ...string) error
+
+	// Ping checks that the backend is reachable
+	Ping(ctx context.Context) error
+
+	Close() error
+}
+
+// Releaser is implemented by backends that hold state for every fetched message
+// until it is acknowledged. The shared Consumer calls Release for deliveries it
+// gives up on without acknowledging them; they are redelivered after AckWait
+// like any other unacknowledged message.
+type Releaser interface {
+	Release(ctx context.Context, stream, group string, ids ...string) error
+}
+
+// Compile-time checks to ensure the Redis publisher can be used wherever a Publisher is expected
+var (
+	_ Publisher      = (*redisstream.Publisher)(nil)
+	_ BatchPublisher = (*redisstream.Publisher)(nil)
+)
+
+// StreamConsumer is implemented by every stream consumer regardless of backend
+type StreamConsumer interface {
+	ConsumeLoop(ctx context.Context, wg *sync.WaitGroup) error
+	Shutdown(ctx context.Context, wg *sync.WaitGroup) error
+}
+
+// StringifyValues converts message values to strings, mirroring how Redis
+// stores stream fields, so parse funcs behave the same on every backend.
+func StringifyValues(values map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		switch tv := v.(type) {
+		case string:
+			out[k] = tv
+		case []byte:
+			out[k] = string(tv)
+		case time.Time:
+			out[k] = tv.Format(time.RFC3339Nano)
+		default:
+			out[k] = fmt.Sprint(tv)
+		}
+	}
+	return out
+}
@@ -0,0 +1,330 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/resilience"
+)
+
+// Compile-time checks to ensure both consumers are interchangeable
+var (
+	_ StreamConsumer = (*Consumer[any])(nil)
+	_ StreamConsumer = (*redisstream.Consumer[any])(nil)
+)
+
+// Consumer consumes a stream from any Backend with the same ack, retry and
+// dead-letter semantics as redisstream.Consumer:
+//   - messages are acknowledged once handed to the transfer channel
+//   - unacknowledged messages are redelivered after MinIdle
+//   - messages delivered more than MaxRetries times, or that fail to parse,
+//     are moved to the dead-letter stream
+//   - failed fetches back off exponentially and trip a circuit breaker that
+//     pings the backend before resuming
+type Consumer[T any] struct {
+	consumerID string
+	backend    Backend
+	logger     *slog.Logger
+	transferCh chan<- T
+	parseFunc  redisstream.ParseFunc[T]
+	exitCh     chan struct{}
+	config     redisstream.Config
+	retry      resilience.RetryPolicy
+	breaker    *resilience.CircuitBreaker
+
+	// optional deduplication of redelivered messages, see UseIdempotency
+	idempotency idempotency.Store
+	idFunc      idempotency.IDFunc[T]
+}
+
+// NewConsumer creates a new backend agnostic stream consumer
+func NewConsumer[T any](
+	logger *slog.Logger,
+	backend Backend,
+	transferCh chan<- T,
+	parseFunc redisstream.ParseFunc[T],
+	config redisstream.Config,
+) *Consumer[T] {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	if config.DeadLetterStreamKey == "" {
+		config.DeadLetterStreamKey = redisstream.DeadLetterKey(config.StreamKey)
+	}
+
+	return &Consumer[T]{
+		consumerID: fmt.Sprintf("%s-%s-%d", config.ConsumerIDPrefix, hostname, os.Getpid()),
+		backend:    backend,
+		logger:     logger,
+		transferCh: transferCh,
+		parseFunc:  parseFunc,
+		exitCh:     make(chan struct{}),
+		config:     config,
+		retry:      config.RetryPolicy(),
+		breaker:    resilience.NewCircuitBreaker(config.HealthComponent(), config.Breaker, config.Health),
+	}
+}
+
+// UseIdempotency skips messages whose ID was already handed off by this
+// consumer group. See redisstream.Consumer.UseIdempotency.
+func (c *Consumer[T]) UseIdempotency(store idempotency.Store, idFunc idempotency.IDFunc[T]) {
+	c.idempotency = store
+	c.idFunc = idFunc
+}
+
+// ConsumeLoop creates the consumer group and starts consuming in the background
+func (c *Consumer[T]) ConsumeLoop(ctx context.Context, wg *sync.WaitGroup) error {
+	if err := c.backend.EnsureGroup(ctx, c.config.StreamKey, c.config.ConsumerGroup, c.config.MinIdle); err != nil {
+		return fmt.Errorf("failed to create consumer group '%s': %w", c.config.ConsumerGroup, err)
+	}
+
+	c.logger.Info("consumer group ready; stream consumer initialized; start loop",
+		"consumer_id", c.consumerID,
+		"group", c.config.ConsumerGroup,
+		"stream_key", c.config.StreamKey)
+
+	wg.Go(func() {
+		c.consumeLoop(ctx)
+	})
+
+	return nil
+}
+
+func (c *Consumer[T]) consumeLoop(ctx context.Context) {
+	attempt := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("consume loop stopping due to context cancellation")
+			return
+		case <-c.exitCh:
+			c.logger.Info("consume loop stopping due to shutdown signal")
+			return
+		default:
+		}
+
+		if !c.breaker.Allow() {
+			if !resilience.Sleep(ctx, c.exitCh, c.breaker.RetryAfter()) {
+				return
+			}
+			continue
+		}
+
+		deliveries, err := c.fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			attempt++
+			c.breaker.Failure(err)
+			delay := c.retry.Backoff(attempt)
+			c.logger.Warn("fetch failed; waiting before retry",
+				"error", err,
+				"attempt", attempt,
+				"delay", delay,
+				"breaker", c.breaker.State())
+
+			if !resilience.Sleep(ctx, c.exitCh, delay) {
+				return
+			}
+			continue
+		}
+
+		if attempt > 0 {
+			c.logger.Info("stream consumer recovered", "failed_attempts", attempt)
+			attempt = 0
+		}
+		c.breaker.Success()
+
+		for _, d := range deliveries {
+			c.handle(ctx, d)
+		}
+	}
+}
+
+// fetch reads the next batch. A half open breaker first pings the backend and
+// recreates the group, which may have been lost while the backend was down.
+func (c *Consumer[T]) fetch(ctx context.Context) ([]Delivery, error) {
+	if c.breaker.State() == resilience.StateHalfOpen {
+		if err := c.backend.Ping(ctx); err != nil {
+			return nil, fmt.Errorf("backend probe failed: %w", err)
+		}
+		if err := c.backend.EnsureGroup(ctx, c.config.StreamKey, c.config.ConsumerGroup, c.config.MinIdle); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.backend.Fetch(ctx, FetchRequest{
+		Stream:   c.config.StreamKey,
+		Group:    c.config.ConsumerGroup,
+		Consumer: c.consumerID,
+		Count:    c.config.BatchSize,
+		Block:    c.config.BlockTime,
+		AckWait:  c.config.MinIdle,
+	})
+}
+
+// handle processes a single delivery
+func (c *Consumer[T]) handle(ctx context.Context, d Delivery) {
+	if d.Attempts > int64(c.config.MaxRetries) {
+		c.deadLetter(ctx, d, fmt.Sprintf("exceeded max retries (%d)", c.config.MaxRetries))
+		return
+	}
+
+	parsed, err := c.parseFunc(redis.XMessage{ID: d.ID, Values: d.Values})
+	if err != nil {
+		c.logger.Error("failed to parse message",
+			"message_id", d.ID,
+			"error", err)
+		c.deadLetter(ctx, d, err.Error())
+		return
+	}
+
+	if c.isDuplicate(ctx, parsed) {
+		c.logger.Info("skipping duplicate message", "message_id", d.ID)
+		c.ack(ctx, d.ID)
+		return
+	}
+
+	// A delivery that cannot be handed off before shutdown stays pending and is redelivered
+	select {
+	case c.transferCh <- parsed:
+	case <-c.exitCh:
+		c.release(ctx, d.ID)
+		return
+	case <-ctx.Done():
+		c.release(ctx, d.ID)
+		return
+	}
+
+	c.claim(ctx, parsed)
+	c.ack(ctx, d.ID)
+}
+
+// release hands a delivery that was not acknowledged back to the backend
+func (c *Consumer[T]) release(ctx context.Context, id string) {
+	releaser, ok := c.backend.(Releaser)
+	if !ok {
+		return
+	}
+	if err := releaser.Release(context.WithoutCancel(ctx), c.config.StreamKey, c.config.ConsumerGroup, id); err != nil {
+		c.logger.Warn("failed to release message",
+			"message_id", id,
+			"error", err)
+	}
+}
+
+func (c *Consumer[T]) ack(ctx context.Context, id string) {
+	if err := c.backend.Ack(ctx, c.config.StreamKey, c.config.ConsumerGroup, id); err != nil {
+		c.logger.Error("failed to acknowledge message",
+			"message_id", id,
+			"error", err)
+	}
+}
+
+// isDuplicate reports whether the message ID was already handed off.
+// Store errors are logged and the message is processed, preferring a duplicate over a loss.
+func (c *Consumer[T]) isDuplicate(ctx context.Context, msg T) bool {
+	if c.idempotency == nil || c.idFunc == nil {
+		return false
+	}
+
+	id := c.idFunc(msg)
+	if id == "" {
+		return false
+	}
+
+	seen, err := c.idempotency.Seen(ctx, idempotency.Scope(c.config.StreamKey, c.config.ConsumerGroup), id)
+	if err != nil {
+		c.logger.Warn("idempotency check failed; processing message anyway",
+			"id", id,
+			"error", err)
+		return false
+	}
+	return seen
+}
+
+// claim records the message ID once the message was handed off
+func (c *Consumer[T]) claim(ctx context.Context, msg T) {
+	if c.idempotency == nil || c.idFunc == nil {
+		return
+	}
+
+	id := c.idFunc(msg)
+	if id == "" {
+		return
+	}
+
+	if _, err := c.idempotency.Claim(ctx, idempotency.Scope(c.config.StreamKey, c.config.ConsumerGroup), id); err != nil {
+		c.logger.Warn("failed to claim handed off message; a redelivery will be handed off again",
+			"id", id,
+			"error", err)
+	}
+}
+
+// deadLetter copies a delivery to the dead-letter stream and acknowledges the original
+func (c *Consumer[T]) deadLetter(ctx context.Context, d Delivery, reason string) {
+	values := make(map[string]interface{}, len(d.Values)+5)
+	for k, v := range d.Values {
+		values[k] = v
+	}
+	values[redisstream.DeadLetterFieldSourceStream] = c.config.StreamKey
+	values[redisstream.DeadLetterFieldSourceID] = d.ID
+	values[redisstream.DeadLetterFieldGroup] = c.config.ConsumerGroup
+	values[redisstream.DeadLetterFieldReason] = reason
+	values[redisstream.DeadLetterFieldFailedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	if _, err := c.backend.Publish(ctx, c.config.DeadLetterStreamKey, values); err != nil {
+		c.logger.Error("failed to move message to dead-letter stream",
+			"message_id", d.ID,
+			"dead_letter_stream", c.config.DeadLetterStreamKey,
+			"error", err)
+		c.release(ctx, d.ID)
+		return
+	}
+
+	if err := c.backend.Ack(ctx, c.config.StreamKey, c.config.ConsumerGroup, d.ID); err != nil {
+		c.logger.Error("failed to acknowledge dead-lettered message",
+			"message_id", d.ID,
+			"error", err)
+		return
+	}
+
+	c.logger.Warn("message moved to dead-letter stream",
+		"message_id", d.ID,
+		"dead_letter_stream", c.config.DeadLetterStreamKey,
+		"reason", reason)
+}
+
+// Shutdown gracefully stops the consumer
+func (c *Consumer[T]) Shutdown(ctx context.Context, wg *sync.WaitGroup) error {
+	c.logger.Info("initiating consumer shutdown")
+	close(c.exitCh)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		c.logger.Info("consumer stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		c.logger.Warn("consumer shutdown timed out")
+		return errors.New("shutdown timeout exceeded")
+	}
+}
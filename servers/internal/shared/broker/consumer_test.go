@@ -0,0 +1,229 @@
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/memory"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+)
+
+func parseValue(msg redis.XMessage) (string, error) {
+	v, _ := msg.Values["value"].(string)
+	if v == "" {
+		return "", errors.New("missing value")
+	}
+	return v, nil
+}
+
+func TestMemoryBrokerRedeliversUnackedMessages(t *testing.T) {
+	ctx := context.Background()
+	b := memory.New(100)
+
+	if err := b.EnsureGroup(ctx, "s", "g", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Publish(ctx, "s", map[string]interface{}{"value": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := broker.FetchRequest{Stream: "s", Group: "g", Consumer: "c", Count: 10, AckWait: 0}
+
+	first, err := b.Fetch(ctx, req)
+	if err != nil || len(first) != 1 || first[0].Attempts != 1 {
+		t.Fatalf("unexpected first fetch: %+v, %v", first, err)
+	}
+	if first[0].Values["value"] != "1" {
+		t.Errorf("expected values to be stringified, got %#v", first[0].Values["value"])
+	}
+
+	second, err := b.Fetch(ctx, req)
+	if err != nil || len(second) != 1 || second[0].Attempts != 2 {
+		t.Fatalf("expected redelivery with 2 attempts, got %+v, %v", second, err)
+	}
+
+	if err := b.Ack(ctx, "s", "g", second[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	third, err := b.Fetch(ctx, req)
+	if err != nil || len(third) != 0 {
+		t.Fatalf("expected nothing after ack, got %+v, %v", third, err)
+	}
+}
+
+func TestConsumerDeadLettersUnparseableMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := memory.New(100)
+	transferCh := make(chan string, 10)
+	config := redisstream.Config{
+		StreamKey:     "events",
+		ConsumerGroup: "group",
+		BatchSize:     10,
+		BlockTime:     50 * time.Millisecond,
+		MaxRetries:    3,
+		RetryDelay:    10 * time.Millisecond,
+		MinIdle:       time.Minute,
+	}
+
+	c := broker.NewConsumer(slog.New(slog.DiscardHandler), b, transferCh, parseValue, config)
+	wg := new(sync.WaitGroup)
+	if err := c.ConsumeLoop(ctx, wg); err != nil {
+		t.Fatal(err)
+	}
+
+	b.Publish(ctx, "events", map[string]interface{}{"value": "ok"})
+	b.Publish(ctx, "events", map[string]interface{}{"other": "field"})
+
+	select {
+	case got := <-transferCh:
+		if got != "ok" {
+			t.Errorf("expected ok, got %s", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+
+	if err := b.EnsureGroup(ctx, redisstream.DeadLetterKey("events"), "inspect", 0); err != nil {
+		t.Fatal(err)
+	}
+	dead, err := b.Fetch(ctx, broker.FetchRequest{
+		Stream:   redisstream.DeadLetterKey("events"),
+		Group:    "inspect",
+		Consumer: "test",
+		Count:    10,
+		Block:    time.Second,
+	})
+	if err != nil || len(dead) != 1 {
+		t.Fatalf("expected one dead-lettered message, got %+v, %v", dead, err)
+	}
+	if dead[0].Values[redisstream.DeadLetterFieldSourceStream] != "events" {
+		t.Errorf("dead letter is missing source metadata: %#v", dead[0].Values)
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
+	defer shutdownCancel()
+	if err := c.Shutdown(shutdownCtx, wg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConsumerSkipsDuplicateIDs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := memory.New(100)
+	transferCh := make(chan string, 10)
+	config := redisstream.Config{
+		StreamKey:     "events",
+		ConsumerGroup: "group",
+		BatchSize:     10,
+		BlockTime:     50 * time.Millisecond,
+		MaxRetries:    3,
+		RetryDelay:    10 * time.Millisecond,
+		MinIdle:       time.Minute,
+	}
+
+	c := broker.NewConsumer(slog.New(slog.DiscardHandler), b, transferCh, parseValue, config)
+	c.UseIdempotency(idempotency.NewMemoryStore(time.Minute), func(v string) string { return v })
+
+	wg := new(sync.WaitGroup)
+	if err := c.ConsumeLoop(ctx, wg); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{"a", "a", "b"} {
+		b.Publish(ctx, "events", map[string]interface{}{"value": v})
+	}
+
+	var got []string
+	timeout := time.After(2 * time.Second)
+	for len(got) < 2 {
+		select {
+		case v := <-transferCh:
+			got = append(got, v)
+		case <-timeout:
+			t.Fatalf("timed out, got %v", got)
+		}
+	}
+
+	select {
+	case v := <-transferCh:
+		t.Fatalf("unexpected duplicate delivery %q", v)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if got[0] != "a" || got[1] != "b" {
+		t.Errorf("expected [a b], got %v", got)
+	}
+
+	cancel()
+	wg.Wait()
+}
+
+// releasingBroker records the deliveries the consumer releases
+type releasingBroker struct {
+	*memory.Broker
+	released chan string
+}
+
+func (b *releasingBroker) Release(ctx context.Context, stream, group string, ids ...string) error {
+	for _, id := range ids {
+		b.released <- id
+	}
+	return nil
+}
+
+func TestConsumerReleasesDeliveriesNotHandedOff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := &releasingBroker{Broker: memory.New(100), released: make(chan string, 10)}
+	// Nobody reads the transfer channel, so the delivery is still waiting at shutdown
+	transferCh := make(chan string)
+	config := redisstream.Config{
+		StreamKey:     "events",
+		ConsumerGroup: "group",
+		BatchSize:     10,
+		BlockTime:     50 * time.Millisecond,
+		MaxRetries:    3,
+		RetryDelay:    10 * time.Millisecond,
+		MinIdle:       time.Minute,
+	}
+
+	c := broker.NewConsumer(slog.New(slog.DiscardHandler), b, transferCh, parseValue, config)
+	wg := new(sync.WaitGroup)
+	if err := c.ConsumeLoop(ctx, wg); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := b.Publish(ctx, "events", map[string]interface{}{"value": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Give the consumer time to fetch the message and block on the hand-off
+	time.Sleep(100 * time.Millisecond)
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
+	defer shutdownCancel()
+	if err := c.Shutdown(shutdownCtx, wg); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case released := <-b.released:
+		if released != id {
+			t.Errorf("expected %s to be released, got %s", id, released)
+		}
+	default:
+		t.Fatal("expected the delivery to be released")
+	}
+}
@@ -0,0 +1,295 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+)
+
+// Compile-time check to ensure Broker implements broker.Backend
+var _ broker.Backend = (*Broker)(nil)
+
+const defaultMaxLen = 10000
+
+var (
+	defaultOnce   sync.Once
+	defaultBroker *Broker
+)
+
+// Default returns a process wide broker so that producers and consumers
+// running in the same binary (dev mode) share streams.
+func Default() *Broker {
+	defaultOnce.Do(func() {
+		defaultBroker = New(defaultMaxLen)
+	})
+	return defaultBroker
+}
+
+type entry struct {
+	id     string
+	values map[string]interface{}
+}
+
+type pendingEntry struct {
+	entry       entry
+	consumer    string
+	deliveredAt time.Time
+	attempts    int64
+}
+
+type group struct {
+	// next is the index of the first entry not yet delivered to the group
+	next    int
+	pending map[string]*pendingEntry
+	// order keeps pending IDs in delivery order for deterministic redelivery
+	order []string
+}
+
+type stream struct {
+	entries []entry
+	groups  map[string]*group
+}
+
+// Broker is an in-memory Backend intended for unit tests and single binary dev mode.
+// Streams are capped at maxLen entries; the oldest entries are dropped first.
+type Broker struct {
+	mu      sync.Mutex
+	streams map[string]*stream
+	notify  chan struct{}
+	lastMs  int64
+	seq     uint64
+	maxLen  int
+	closed  bool
+}
+
+// New creates an empty in-memory broker
+func New(maxLen int) *Broker {
+	if maxLen <= 0 {
+		maxLen = defaultMaxLen
+	}
+
+	return &Broker{
+		streams: make(map[string]*stream),
+		notify:  make(chan struct{}),
+		maxLen:  maxLen,
+	}
+}
+
+func (b *Broker) getStream(key string) *stream {
+	s, ok := b.streams[key]
+	if !ok {
+		s = &stream{groups: make(map[string]*group)}
+		b.streams[key] = s
+	}
+	return s
+}
+
+// nextID generates Redis style "<ms>-<seq>" IDs
+func (b *Broker) nextID() string {
+	ms := time.Now().UnixMilli()
+	if ms <= b.lastMs {
+		ms = b.lastMs
+		b.seq++
+	} else {
+		b.lastMs = ms
+		b.seq = 0
+	}
+	return fmt.Sprintf("%d-%d", ms, b.seq)
+}
+
+// Publish appends a message to a stream
+func (b *Broker) Publish(ctx context.Context, streamKey string, values map[string]interface{}) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return "", fmt.Errorf("memory broker is closed")
+	}
+
+	s := b.getStream(streamKey)
+	id := b.nextID()
+	s.entries = append(s.entries, entry{id: id, values: broker.StringifyValues(values)})
+
+	if overflow := len(s.entries) - b.maxLen; overflow > 0 {
+		s.entries = s.entries[overflow:]
+		for _, g := range s.groups {
+			g.next = max(g.next-overflow, 0)
+		}
+	}
+
+	// wake up blocked fetchers
+	close(b.notify)
+	b.notify = make(chan struct{})
+
+	return id, nil
+}
+
+// EnsureGroup creates the consumer group starting at the beginning of the stream
+func (b *Broker) EnsureGroup(ctx context.Context, streamKey, groupName string, ackWait time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := b.getStream(streamKey)
+	if _, ok := s.groups[groupName]; !ok {
+		s.groups[groupName] = &group{pending: make(map[string]*pendingEntry)}
+	}
+	return nil
+}
+
+// Fetch returns expired pending entries first, then new entries
+func (b *Broker) Fetch(ctx context.Context, req broker.FetchRequest) ([]broker.Delivery, error) {
+	deadline := time.Now().Add(req.Block)
+
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return nil, fmt.Errorf("memory broker is closed")
+		}
+
+		s, ok := b.streams[req.Stream]
+		if !ok {
+			b.mu.Unlock()
+			return nil, fmt.Errorf("stream %s does not exist", req.Stream)
+		}
+		g, ok := s.groups[req.Group]
+		if !ok {
+			b.mu.Unlock()
+			return nil, fmt.Errorf("consumer group %s does not exist on %s", req.Group, req.Stream)
+		}
+
+		deliveries := b.collect(s, g, req)
+		notify := b.notify
+		b.mu.Unlock()
+
+		if len(deliveries) > 0 {
+			return deliveries, nil
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-notify:
+			timer.Stop()
+		case <-timer.C:
+			return nil, nil
+		}
+	}
+}
+
+// collect must be called with b.mu held
+func (b *Broker) collect(s *stream, g *group, req broker.FetchRequest) []broker.Delivery {
+	count := req.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	now := time.Now()
+	var deliveries []broker.Delivery
+
+	for _, id := range g.order {
+		if len(deliveries) >= count {
+			return deliveries
+		}
+
+		p := g.pending[id]
+		if now.Sub(p.deliveredAt) < req.AckWait {
+			continue
+		}
+
+		p.consumer = req.Consumer
+		p.deliveredAt = now
+		p.attempts++
+		deliveries = append(deliveries, broker.Delivery{
+			ID:       p.entry.id,
+			Values:   p.entry.values,
+			Attempts: p.attempts,
+		})
+	}
+
+	for g.next < len(s.entries) && len(deliveries) < count {
+		e := s.entries[g.next]
+		g.next++
+
+		g.pending[e.id] = &pendingEntry{
+			entry:       e,
+			consumer:    req.Consumer,
+			deliveredAt: now,
+			attempts:    1,
+		}
+		g.order = append(g.order, e.id)
+		deliveries = append(deliveries, broker.Delivery{
+			ID:       e.id,
+			Values:   e.values,
+			Attempts: 1,
+		})
+	}
+
+	return deliveries
+}
+
+// Ack removes messages from the pending list of a group
+func (b *Broker) Ack(ctx context.Context, streamKey, groupName string, ids ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.streams[streamKey]
+	if !ok {
+		return fmt.Errorf("stream %s does not exist", streamKey)
+	}
+	g, ok := s.groups[groupName]
+	if !ok {
+		return fmt.Errorf("consumer group %s does not exist on %s", groupName, streamKey)
+	}
+
+	for _, id := range ids {
+		delete(g.pending, id)
+	}
+
+	order := g.order[:0]
+	for _, id := range g.order {
+		if _, ok := g.pending[id]; ok {
+			order = append(order, id)
+		}
+	}
+	g.order = order
+
+	return nil
+}
+
+// Ping always succeeds while the broker is open
+func (b *Broker) Ping(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("memory broker is closed")
+	}
+	return nil
+}
+
+// Close releases blocked fetchers. The default broker is never closed by consumers.
+func (b *Broker) Close() error {
+	if b == defaultBroker {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		close(b.notify)
+	}
+	return nil
+}
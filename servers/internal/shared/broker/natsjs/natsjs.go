@@ -0,0 +1,264 @@
+package natsjs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+)
+
+// Compile-time checks to ensure Broker implements broker.Backend and broker.Releaser
+var (
+	_ broker.Backend  = (*Broker)(nil)
+	_ broker.Releaser = (*Broker)(nil)
+)
+
+// Broker is a broker.Backend on top of NATS JetStream.
+//
+// Each stream key maps to a JetStream stream (":" replaced by "_") bound to a
+// subject (":" replaced by "."), and each consumer group to a durable pull
+// consumer with explicit acks. Redelivery counting is left to JetStream
+// (MaxDeliver is unlimited) so the shared Consumer decides when to dead-letter.
+type Broker struct {
+	nc *nats.Conn
+	js jetstream.JetStream
+
+	mu        sync.Mutex
+	consumers map[string]jetstream.Consumer
+	// ackWaits is the AckWait of each consumer, used as the redelivery delay of released messages
+	ackWaits map[string]time.Duration
+	// inflight holds fetched messages until they are acked, released or terminated
+	inflight map[string]jetstream.Msg
+	streams  map[string]struct{}
+}
+
+// Connect connects to a NATS server with JetStream enabled
+func Connect(url string) (*Broker, error) {
+	nc, err := nats.Connect(url, nats.Name("go-monorepo-broker"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", url, err)
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	return newBroker(nc, js), nil
+}
+
+func newBroker(nc *nats.Conn, js jetstream.JetStream) *Broker {
+	return &Broker{
+		nc:        nc,
+		js:        js,
+		consumers: make(map[string]jetstream.Consumer),
+		ackWaits:  make(map[string]time.Duration),
+		inflight:  make(map[string]jetstream.Msg),
+		streams:   make(map[string]struct{}),
+	}
+}
+
+func streamName(key string) string {
+	return strings.ReplaceAll(key, ":", "_")
+}
+
+func subject(key string) string {
+	return strings.ReplaceAll(key, ":", ".")
+}
+
+func inflightKey(stream, group, id string) string {
+	return stream + "/" + group + "/" + id
+}
+
+func (b *Broker) ensureStream(ctx context.Context, key string) error {
+	b.mu.Lock()
+	_, ok := b.streams[key]
+	b.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	if _, err := b.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     streamName(key),
+		Subjects: []string{subject(key)},
+	}); err != nil {
+		return fmt.Errorf("failed to create JetStream stream %s: %w", streamName(key), err)
+	}
+
+	b.mu.Lock()
+	b.streams[key] = struct{}{}
+	b.mu.Unlock()
+	return nil
+}
+
+// Publish appends a message to the stream. Values are stored as a JSON object of strings.
+func (b *Broker) Publish(ctx context.Context, stream string, values map[string]interface{}) (string, error) {
+	if err := b.ensureStream(ctx, stream); err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(broker.StringifyValues(values))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	ack, err := b.js.Publish(ctx, subject(stream), payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to publish to %s: %w", subject(stream), err)
+	}
+	return strconv.FormatUint(ack.Sequence, 10), nil
+}
+
+// EnsureGroup creates the stream and a durable pull consumer for the group
+func (b *Broker) EnsureGroup(ctx context.Context, stream, group string, ackWait time.Duration) error {
+	if err := b.ensureStream(ctx, stream); err != nil {
+		return err
+	}
+
+	cfg := jetstream.ConsumerConfig{
+		Durable:       group,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+		MaxDeliver:    -1,
+	}
+	if ackWait > 0 {
+		cfg.AckWait = ackWait
+	}
+
+	cons, err := b.js.CreateOrUpdateConsumer(ctx, streamName(stream), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create JetStream consumer %s: %w", group, err)
+	}
+
+	b.mu.Lock()
+	b.consumers[stream+"/"+group] = cons
+	b.ackWaits[stream+"/"+group] = ackWait
+	b.mu.Unlock()
+	return nil
+}
+
+// Fetch pulls up to req.Count messages, waiting at most req.Block
+func (b *Broker) Fetch(ctx context.Context, req broker.FetchRequest) ([]broker.Delivery, error) {
+	b.mu.Lock()
+	cons, ok := b.consumers[req.Stream+"/"+req.Group]
+	b.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("consumer group %s does not exist on %s", req.Group, req.Stream)
+	}
+
+	count := max(req.Count, 1)
+	block := req.Block
+	if block <= 0 {
+		block = time.Second
+	}
+
+	batch, err := cons.Fetch(count, jetstream.FetchMaxWait(block))
+	if err != nil {
+		return nil, fmt.Errorf("JetStream fetch failed: %w", err)
+	}
+
+	var deliveries []broker.Delivery
+	for msg := range batch.Messages() {
+		meta, err := msg.Metadata()
+		if err != nil {
+			msg.Term()
+			continue
+		}
+
+		values := make(map[string]interface{})
+		var decoded map[string]string
+		if err := json.Unmarshal(msg.Data(), &decoded); err == nil {
+			for k, v := range decoded {
+				values[k] = v
+			}
+		}
+
+		id := strconv.FormatUint(meta.Sequence.Stream, 10)
+		b.mu.Lock()
+		b.inflight[inflightKey(req.Stream, req.Group, id)] = msg
+		b.mu.Unlock()
+
+		deliveries = append(deliveries, broker.Delivery{
+			ID:       id,
+			Values:   values,
+			Attempts: int64(meta.NumDelivered),
+		})
+	}
+
+	if err := batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+		return deliveries, fmt.Errorf("JetStream fetch failed: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Ack acknowledges messages previously returned by Fetch
+func (b *Broker) Ack(ctx context.Context, stream, group string, ids ...string) error {
+	var errs []error
+	for _, id := range ids {
+		msg, ok := b.take(stream, group, id)
+		if !ok {
+			errs = append(errs, fmt.Errorf("message %s is not in flight", id))
+			continue
+		}
+		if err := msg.Ack(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to ack message %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Release naks messages previously returned by Fetch so JetStream redelivers
+// them after the consumer's AckWait, and forgets them
+func (b *Broker) Release(ctx context.Context, stream, group string, ids ...string) error {
+	b.mu.Lock()
+	delay := b.ackWaits[stream+"/"+group]
+	b.mu.Unlock()
+
+	var errs []error
+	for _, id := range ids {
+		msg, ok := b.take(stream, group, id)
+		if !ok {
+			continue
+		}
+		if err := msg.NakWithDelay(delay); err != nil {
+			errs = append(errs, fmt.Errorf("failed to nak message %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// take removes a message from the in-flight set
+func (b *Broker) take(stream, group, id string) (jetstream.Msg, bool) {
+	key := inflightKey(stream, group, id)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	msg, ok := b.inflight[key]
+	delete(b.inflight, key)
+	return msg, ok
+}
+
+// Ping checks the NATS connection and JetStream availability
+func (b *Broker) Ping(ctx context.Context) error {
+	if !b.nc.IsConnected() {
+		return fmt.Errorf("NATS connection is %s", b.nc.Status())
+	}
+	if _, err := b.js.AccountInfo(ctx); err != nil {
+		return fmt.Errorf("JetStream unavailable: %w", err)
+	}
+	return nil
+}
+
+// Close drains the NATS connection
+func (b *Broker) Close() error {
+	return b.nc.Drain()
+}
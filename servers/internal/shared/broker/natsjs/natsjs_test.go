@@ -0,0 +1,100 @@
+package natsjs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+)
+
+// fakeMsg records how a fetched message was settled
+type fakeMsg struct {
+	jetstream.Msg
+	seq      uint64
+	acked    bool
+	nakDelay time.Duration
+	naked    bool
+}
+
+func (m *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{Sequence: jetstream.SequencePair{Stream: m.seq}, NumDelivered: 1}, nil
+}
+
+func (m *fakeMsg) Data() []byte {
+	data, _ := json.Marshal(map[string]string{"value": "x"})
+	return data
+}
+
+func (m *fakeMsg) Ack() error {
+	m.acked = true
+	return nil
+}
+
+func (m *fakeMsg) NakWithDelay(delay time.Duration) error {
+	m.naked = true
+	m.nakDelay = delay
+	return nil
+}
+
+type fakeBatch struct {
+	msgs chan jetstream.Msg
+}
+
+func (b *fakeBatch) Messages() <-chan jetstream.Msg { return b.msgs }
+func (b *fakeBatch) Error() error                   { return nil }
+
+// fakeConsumer hands out a fixed set of messages on the first fetch
+type fakeConsumer struct {
+	jetstream.Consumer
+	msgs []*fakeMsg
+}
+
+func (c *fakeConsumer) Fetch(batch int, opts ...jetstream.FetchOpt) (jetstream.MessageBatch, error) {
+	ch := make(chan jetstream.Msg, len(c.msgs))
+	for _, msg := range c.msgs {
+		ch <- msg
+	}
+	close(ch)
+	c.msgs = nil
+	return &fakeBatch{msgs: ch}, nil
+}
+
+func TestInflightMessagesAreForgottenOnceSettled(t *testing.T) {
+	ctx := context.Background()
+	acked, released := &fakeMsg{seq: 1}, &fakeMsg{seq: 2}
+
+	b := newBroker(nil, nil)
+	b.consumers["s/g"] = &fakeConsumer{msgs: []*fakeMsg{acked, released}}
+	b.ackWaits["s/g"] = 30 * time.Second
+
+	deliveries, err := b.Fetch(ctx, broker.FetchRequest{Stream: "s", Group: "g", Count: 10})
+	if err != nil || len(deliveries) != 2 {
+		t.Fatalf("unexpected fetch: %+v, %v", deliveries, err)
+	}
+	if len(b.inflight) != 2 {
+		t.Fatalf("expected 2 messages in flight, got %d", len(b.inflight))
+	}
+
+	if err := b.Ack(ctx, "s", "g", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Release(ctx, "s", "g", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(b.inflight) != 0 {
+		t.Errorf("expected no message in flight, got %d", len(b.inflight))
+	}
+	if !acked.acked || acked.naked {
+		t.Error("expected the first message to be acked")
+	}
+	if !released.naked || released.acked || released.nakDelay != 30*time.Second {
+		t.Errorf("expected the second message to be naked with the ack wait, got %+v", released)
+	}
+	if err := b.Ack(ctx, "s", "g", "2"); err == nil {
+		t.Error("expected acking a released message to fail")
+	}
+}
@@ -19,6 +19,85 @@ type Item struct {
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+// Log entries ingested by the logging server, partitioned by received day
+type LogEntry struct {
+	ID          int64              `db:"id" json:"id"`
+	LogID       string             `db:"log_id" json:"log_id"`
+	ReceivedAt  pgtype.Timestamptz `db:"received_at" json:"received_at"`
+	ServerTime  pgtype.Timestamptz `db:"server_time" json:"server_time"`
+	Severity    string             `db:"severity" json:"severity"`
+	ServiceType string             `db:"service_type" json:"service_type"`
+	ServiceName string             `db:"service_name" json:"service_name"`
+	Environment string             `db:"environment" json:"environment"`
+	EventType   string             `db:"event_type" json:"event_type"`
+	RequestID   string             `db:"request_id" json:"request_id"`
+	SessionID   string             `db:"session_id" json:"session_id"`
+	UserID      string             `db:"user_id" json:"user_id"`
+	// Address of the gRPC peer that sent the log
+	PeerIp string `db:"peer_ip" json:"peer_ip"`
+	Data   string `db:"data" json:"data"`
+	// Full log request, including fields without a column
+	Record []byte `db:"record" json:"record"`
+}
+
+// Idempotency records for stream events, expired rows are purged periodically
+type ProcessedEvent struct {
+	Scope       string             `db:"scope" json:"scope"`
+	EventID     string             `db:"event_id" json:"event_id"`
+	ProcessedAt pgtype.Timestamptz `db:"processed_at" json:"processed_at"`
+	ExpiresAt   pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+// Funnel step conversions per entry day
+type StatsFunnelStep struct {
+	Funnel     string      `db:"funnel" json:"funnel"`
+	CohortDate pgtype.Date `db:"cohort_date" json:"cohort_date"`
+	// Zero-based index of the step in the funnel definition
+	Step      int32              `db:"step" json:"step"`
+	Users     int64              `db:"users" json:"users"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+// Latency histogram counts per minute, hour and day bucket
+type StatsLatencyBucket struct {
+	Granularity    string             `db:"granularity" json:"granularity"`
+	BucketStart    pgtype.Timestamptz `db:"bucket_start" json:"bucket_start"`
+	Dimension      string             `db:"dimension" json:"dimension"`
+	DimensionValue string             `db:"dimension_value" json:"dimension_value"`
+	// Log-linear histogram bucket index of the duration in microseconds
+	HistogramBucket int32              `db:"histogram_bucket" json:"histogram_bucket"`
+	Count           int64              `db:"count" json:"count"`
+	Errors          int64              `db:"errors" json:"errors"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+// Daily retention cohorts keyed by first-seen date
+type StatsRetention struct {
+	CohortDate pgtype.Date        `db:"cohort_date" json:"cohort_date"`
+	DayOffset  int32              `db:"day_offset" json:"day_offset"`
+	Users      int64              `db:"users" json:"users"`
+	UpdatedAt  pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+// Event counts per minute, hour and day bucket
+type StatsRollup struct {
+	Granularity string             `db:"granularity" json:"granularity"`
+	BucketStart pgtype.Timestamptz `db:"bucket_start" json:"bucket_start"`
+	EventType   string             `db:"event_type" json:"event_type"`
+	// Empty for per event type totals
+	Dimension      string             `db:"dimension" json:"dimension"`
+	DimensionValue string             `db:"dimension_value" json:"dimension_value"`
+	Count          int64              `db:"count" json:"count"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+// First-seen and last active day per user
+type StatsUserActivity struct {
+	UserID     string      `db:"user_id" json:"user_id"`
+	FirstSeen  pgtype.Date `db:"first_seen" json:"first_seen"`
+	LastActive pgtype.Date `db:"last_active" json:"last_active"`
+}
+
 // Transaction history for items and users
 type Transaction struct {
 	ID              int64              `db:"id" json:"id"`
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Status represents the health of a single component
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Report is the latest health information published by a component
+type Report struct {
+	Status    Status    `json:"status"`
+	Detail    any       `json:"detail,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Registry collects health reports pushed by background components
+// (stream inspectors, consumers, ...) so that a single endpoint can expose them.
+type Registry struct {
+	mu      sync.RWMutex
+	reports map[string]Report
+}
+
+// NewRegistry creates an empty health registry
+func NewRegistry() *Registry {
+	return &Registry{
+		reports: make(map[string]Report),
+	}
+}
+
+// Set records the current status of a component
+func (r *Registry) Set(component string, status Status, detail any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reports[component] = Report{
+		Status:    status,
+		Detail:    detail,
+		CheckedAt: time.Now(),
+	}
+}
+
+// Remove drops a component from the registry
+func (r *Registry) Remove(component string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.reports, component)
+}
+
+// Snapshot returns a copy of all reports
+func (r *Registry) Snapshot() map[string]Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reports := make(map[string]Report, len(r.reports))
+	for k, v := range r.reports {
+		reports[k] = v
+	}
+	return reports
+}
+
+// Overall returns the worst status across all components
+func (r *Registry) Overall() Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	overall := StatusUp
+	for _, report := range r.reports {
+		switch report.Status {
+		case StatusDown:
+			return StatusDown
+		case StatusDegraded:
+			overall = StatusDegraded
+		}
+	}
+	return overall
+}
+
+// Handler serves the registry as JSON. It responds with 503 when any component is down.
+func (r *Registry) Handler(w http.ResponseWriter, req *http.Request) {
+	overall := r.Overall()
+
+	w.Header().Set("Content-Type", "application/json")
+	if overall == StatusDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	json.NewEncoder(w).Encode(struct {
+		Status     Status            `json:"status"`
+		Components map[string]Report `json:"components"`
+	}{
+		Status:     overall,
+		Components: r.Snapshot(),
+	})
+}
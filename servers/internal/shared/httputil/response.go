@@ -86,6 +86,28 @@ func ErrRaw(w http.ResponseWriter, r *http.Request, err error) {
 	})
 }
 
+func BadRequestRaw(w http.ResponseWriter, r *http.Request, msg string) {
+	if os.Getenv("RUN_INTEGRATION_TESTS") == "false" {
+		logger.Warn("Bad Request", "message", msg)
+	}
+	render.Status(r, http.StatusBadRequest)
+	render.JSON(w, r, render.M{
+		"status":  "fail",
+		"message": msg,
+	})
+}
+
+func NotFoundRaw(w http.ResponseWriter, r *http.Request, msg string) {
+	if os.Getenv("RUN_INTEGRATION_TESTS") == "false" {
+		logger.Warn("Not Found", "message", msg)
+	}
+	render.Status(r, http.StatusNotFound)
+	render.JSON(w, r, render.M{
+		"status":  "fail",
+		"message": msg,
+	})
+}
+
 func OkNoData(c *HttpRequestContext) {
 	OkNoDataRaw(c.Writer, c.Request)
 }
@@ -113,3 +135,11 @@ func ErrWithMsg(c *HttpRequestContext, err error, msg string) {
 func Err(c *HttpRequestContext, err error) {
 	ErrRaw(c.Writer, c.Request, err)
 }
+
+func BadRequest(c *HttpRequestContext, msg string) {
+	BadRequestRaw(c.Writer, c.Request, msg)
+}
+
+func NotFound(c *HttpRequestContext, msg string) {
+	NotFoundRaw(c.Writer, c.Request, msg)
+}
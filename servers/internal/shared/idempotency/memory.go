@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Compile-time check to ensure MemoryStore implements Store
+var _ Store = (*MemoryStore)(nil)
+
+// purgeEvery is the number of claims between sweeps of expired entries
+const purgeEvery = 1024
+
+// MemoryStore is a process local Store for tests and single instance deployments
+type MemoryStore struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	claims map[string]time.Time
+	count  int
+	now    func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store that forgets IDs after ttl
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &MemoryStore{
+		ttl:    ttl,
+		claims: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Seen reports whether id has an unexpired claim
+func (s *MemoryStore) Seen(ctx context.Context, scope, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.claims[scope+":"+id]
+	return ok && !s.now().After(expiresAt), nil
+}
+
+// Claim records id unless an unexpired claim exists
+func (s *MemoryStore) Claim(ctx context.Context, scope, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.count++
+	if s.count%purgeEvery == 0 {
+		for key, expiresAt := range s.claims {
+			if now.After(expiresAt) {
+				delete(s.claims, key)
+			}
+		}
+	}
+
+	key := scope + ":" + id
+	if expiresAt, ok := s.claims[key]; ok && !now.After(expiresAt) {
+		return false, nil
+	}
+
+	s.claims[key] = now.Add(s.ttl)
+	return true, nil
+}
+
+// Release removes a claim
+func (s *MemoryStore) Release(ctx context.Context, scope, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, scope+":"+id)
+	return nil
+}
@@ -0,0 +1,46 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreClaim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+
+	s := NewMemoryStore(time.Minute)
+	s.now = func() time.Time { return now }
+
+	if seen, _ := s.Seen(ctx, "stream/group", "1"); seen {
+		t.Fatal("expected an unclaimed ID not to be seen")
+	}
+	if ok, _ := s.Claim(ctx, "stream/group", "1"); !ok {
+		t.Fatal("expected first claim to succeed")
+	}
+	if seen, _ := s.Seen(ctx, "stream/group", "1"); !seen {
+		t.Fatal("expected a claimed ID to be seen")
+	}
+	if ok, _ := s.Claim(ctx, "stream/group", "1"); ok {
+		t.Fatal("expected duplicate claim to be rejected")
+	}
+	if ok, _ := s.Claim(ctx, "other/group", "1"); !ok {
+		t.Fatal("expected claims to be scoped")
+	}
+
+	if err := s.Release(ctx, "stream/group", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Claim(ctx, "stream/group", "1"); !ok {
+		t.Fatal("expected claim after release to succeed")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if seen, _ := s.Seen(ctx, "stream/group", "1"); seen {
+		t.Fatal("expected an expired claim not to be seen")
+	}
+	if ok, _ := s.Claim(ctx, "stream/group", "1"); !ok {
+		t.Fatal("expected claim after expiry to succeed")
+	}
+}
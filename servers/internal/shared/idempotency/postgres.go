@@ -0,0 +1,122 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/sqlc/postgres"
+)
+
+// Compile-time check to ensure PostgresStore implements Store
+var _ Store = (*PostgresStore)(nil)
+
+// PostgresStore keeps claims in the processed_events table.
+//
+// Use ClaimTx to write the claim in the same transaction as the side effect,
+// so that either both are committed or neither is.
+type PostgresStore struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+	ttl     time.Duration
+}
+
+// NewPostgresStore creates a Postgres backed store that forgets IDs after ttl
+func NewPostgresStore(pool *pgxpool.Pool, ttl time.Duration) *PostgresStore {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &PostgresStore{
+		pool:    pool,
+		queries: sqlc.New(pool),
+		ttl:     ttl,
+	}
+}
+
+// Seen reports whether an unexpired claim exists
+func (s *PostgresStore) Seen(ctx context.Context, scope, id string) (bool, error) {
+	seen, err := s.queries.ProcessedEventExists(ctx, sqlc.ProcessedEventExistsParams{
+		Scope:   scope,
+		EventID: id,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s in %s: %w", id, scope, err)
+	}
+	return seen, nil
+}
+
+// Claim inserts the claim in its own statement
+func (s *PostgresStore) Claim(ctx context.Context, scope, id string) (bool, error) {
+	return s.claim(ctx, s.queries, scope, id)
+}
+
+// ClaimTx inserts the claim inside tx. A duplicate leaves tx usable, so the
+// caller can simply skip the side effect and roll back.
+func (s *PostgresStore) ClaimTx(ctx context.Context, tx pgx.Tx, scope, id string) (bool, error) {
+	return s.claim(ctx, s.queries.WithTx(tx), scope, id)
+}
+
+func (s *PostgresStore) claim(ctx context.Context, q *sqlc.Queries, scope, id string) (bool, error) {
+	_, err := q.ClaimProcessedEvent(ctx, sqlc.ClaimProcessedEventParams{
+		Scope:     scope,
+		EventID:   id,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.ttl), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// conflict with an unexpired claim
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim %s in %s: %w", id, scope, err)
+	}
+	return true, nil
+}
+
+// Release deletes the claim
+func (s *PostgresStore) Release(ctx context.Context, scope, id string) error {
+	_, err := s.queries.ReleaseProcessedEvent(ctx, sqlc.ReleaseProcessedEventParams{
+		Scope:   scope,
+		EventID: id,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to release %s in %s: %w", id, scope, err)
+	}
+	return nil
+}
+
+// Purge deletes expired claims and returns how many were removed
+func (s *PostgresStore) Purge(ctx context.Context) (int64, error) {
+	count, err := s.queries.DeleteExpiredProcessedEvents(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired processed events: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeLoop purges expired claims every interval until ctx is done
+func (s *PostgresStore) PurgeLoop(ctx context.Context, logger *slog.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.Purge(ctx)
+			if err != nil {
+				logger.Warn("failed to purge processed events", "error", err)
+				continue
+			}
+			if count > 0 {
+				logger.Debug("purged expired processed events", "count", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Compile-time check to ensure RedisStore implements Store
+var _ Store = (*RedisStore)(nil)
+
+const redisKeyPrefix = "idempotency:"
+
+// RedisStore keeps claims as Redis keys that expire after the TTL
+type RedisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisStore creates a Redis backed store that forgets IDs after ttl
+func NewRedisStore(client *redis.Client, ttl time.Duration) *RedisStore {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &RedisStore{client: client, ttl: ttl}
+}
+
+func redisKey(scope, id string) string {
+	return redisKeyPrefix + scope + ":" + id
+}
+
+// Seen reports whether the claim key exists
+func (s *RedisStore) Seen(ctx context.Context, scope, id string) (bool, error) {
+	n, err := s.client.Exists(ctx, redisKey(scope, id)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s in %s: %w", id, scope, err)
+	}
+	return n > 0, nil
+}
+
+// Claim sets the claim key with SET NX, so concurrent consumers race safely
+func (s *RedisStore) Claim(ctx context.Context, scope, id string) (bool, error) {
+	ok, err := s.client.SetNX(ctx, redisKey(scope, id), time.Now().Unix(), s.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim %s in %s: %w", id, scope, err)
+	}
+	return ok, nil
+}
+
+// Release deletes the claim key
+func (s *RedisStore) Release(ctx context.Context, scope, id string) error {
+	if err := s.client.Del(ctx, redisKey(scope, id)).Err(); err != nil {
+		return fmt.Errorf("failed to release %s in %s: %w", id, scope, err)
+	}
+	return nil
+}
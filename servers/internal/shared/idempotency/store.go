@@ -0,0 +1,36 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTTL is how long processed IDs are remembered when no TTL is configured
+const DefaultTTL = 24 * time.Hour
+
+// Store records processed message IDs so that redelivered messages are applied only once.
+//
+// Claim after the side effect succeeded, checking Seen before applying it,
+// or claim in the same transaction as the side effect. Claiming first and
+// applying afterwards loses the message when the process fails in between.
+type Store interface {
+	// Seen reports whether id has an unexpired claim within scope
+	Seen(ctx context.Context, scope, id string) (bool, error)
+
+	// Claim records id as processed within scope. It returns false when id
+	// was already claimed and the claim has not expired yet.
+	Claim(ctx context.Context, scope, id string) (bool, error)
+
+	// Release removes a claim so the message can be processed again,
+	// e.g. after its side effect failed.
+	Release(ctx context.Context, scope, id string) error
+}
+
+// IDFunc extracts the idempotency key from a parsed message.
+// An empty key disables deduplication for that message.
+type IDFunc[T any] func(T) string
+
+// Scope builds the claim scope of a consumer group on a stream
+func Scope(streamKey, consumerGroup string) string {
+	return streamKey + "/" + consumerGroup
+}
@@ -554,6 +554,275 @@ func (x *LogResponse) GetMessage() string {
 	return ""
 }
 
+type LogBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*LogRequest          `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogBatchRequest) Reset() {
+	*x = LogBatchRequest{}
+	mi := &file_logger_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogBatchRequest) ProtoMessage() {}
+
+func (x *LogBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogBatchRequest.ProtoReflect.Descriptor instead.
+func (*LogBatchRequest) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LogBatchRequest) GetLogs() []*LogRequest {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+// 배치 중 거부된 로그
+type LogRejection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // LogBatchRequest.logs 내 위치
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogRejection) Reset() {
+	*x = LogRejection{}
+	mi := &file_logger_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogRejection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRejection) ProtoMessage() {}
+
+func (x *LogRejection) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRejection.ProtoReflect.Descriptor instead.
+func (*LogRejection) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LogRejection) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LogRejection) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LogBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      uint32                 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      []*LogRejection        `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogBatchResponse) Reset() {
+	*x = LogBatchResponse{}
+	mi := &file_logger_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogBatchResponse) ProtoMessage() {}
+
+func (x *LogBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogBatchResponse.ProtoReflect.Descriptor instead.
+func (*LogBatchResponse) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LogBatchResponse) GetAccepted() uint32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *LogBatchResponse) GetRejected() []*LogRejection {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+type StreamLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // 클라이언트가 부여하는 증가 번호 (1부터)
+	Log           *LogRequest            `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_logger_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamLogsRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StreamLogsRequest) GetLog() *LogRequest {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+type LogAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // 0: 스트림 시작 시 window 안내
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Window        uint32                 `protobuf:"varint,4,opt,name=window,proto3" json:"window,omitempty"` // ack되지 않은 메시지 최대 수
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogAck) Reset() {
+	*x = LogAck{}
+	mi := &file_logger_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogAck) ProtoMessage() {}
+
+func (x *LogAck) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogAck.ProtoReflect.Descriptor instead.
+func (*LogAck) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LogAck) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *LogAck) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LogAck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LogAck) GetWindow() uint32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
 var File_logger_proto protoreflect.FileDescriptor
 
 const file_logger_proto_rawDesc = "" +
@@ -613,7 +882,23 @@ const file_logger_proto_rawDesc = "" +
 	"clientData\"A\n" +
 	"\vLogResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage*H\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\">\n" +
+	"\x0fLogBatchRequest\x12+\n" +
+	"\x04logs\x18\x01 \x03(\v2\x17.log_message.LogRequestR\x04logs\">\n" +
+	"\fLogRejection\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"e\n" +
+	"\x10LogBatchResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\rR\baccepted\x125\n" +
+	"\brejected\x18\x02 \x03(\v2\x19.log_message.LogRejectionR\brejected\"Z\n" +
+	"\x11StreamLogsRequest\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12)\n" +
+	"\x03log\x18\x02 \x01(\v2\x17.log_message.LogRequestR\x03log\"p\n" +
+	"\x06LogAck\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06window\x18\x04 \x01(\rR\x06window*H\n" +
 	"\vLogSeverity\x12\t\n" +
 	"\x05DEBUG\x10\x00\x12\b\n" +
 	"\x04INFO\x10\x01\x12\v\n" +
@@ -629,9 +914,12 @@ const file_logger_proto_rawDesc = "" +
 	"\aRANKING\x10\x03\x12\v\n" +
 	"\aOUTGAME\x10\x04\x12\t\n" +
 	"\x05STATS\x10\x05\x12\v\n" +
-	"\aLOGGING\x10\x062F\n" +
+	"\aLOGGING\x10\x062\xda\x01\n" +
 	"\x06Logger\x12<\n" +
-	"\aSendLog\x12\x17.log_message.LogRequest\x1a\x18.log_message.LogResponseB\x13Z\x11logging/pb/clientb\x06proto3"
+	"\aSendLog\x12\x17.log_message.LogRequest\x1a\x18.log_message.LogResponse\x12K\n" +
+	"\fSendLogBatch\x12\x1c.log_message.LogBatchRequest\x1a\x1d.log_message.LogBatchResponse\x12E\n" +
+	"\n" +
+	"StreamLogs\x12\x1e.log_message.StreamLogsRequest\x1a\x13.log_message.LogAck(\x010\x01B\x13Z\x11logging/pb/clientb\x06proto3"
 
 var (
 	file_logger_proto_rawDescOnce sync.Once
@@ -646,25 +934,37 @@ func file_logger_proto_rawDescGZIP() []byte {
 }
 
 var file_logger_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_logger_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_logger_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_logger_proto_goTypes = []any{
 	(LogSeverity)(0),              // 0: log_message.LogSeverity
 	(ServerType)(0),               // 1: log_message.ServerType
 	(*LogRequest)(nil),            // 2: log_message.LogRequest
 	(*LogResponse)(nil),           // 3: log_message.LogResponse
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*LogBatchRequest)(nil),       // 4: log_message.LogBatchRequest
+	(*LogRejection)(nil),          // 5: log_message.LogRejection
+	(*LogBatchResponse)(nil),      // 6: log_message.LogBatchResponse
+	(*StreamLogsRequest)(nil),     // 7: log_message.StreamLogsRequest
+	(*LogAck)(nil),                // 8: log_message.LogAck
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
 }
 var file_logger_proto_depIdxs = []int32{
 	0, // 0: log_message.LogRequest.severity:type_name -> log_message.LogSeverity
-	4, // 1: log_message.LogRequest.server_time:type_name -> google.protobuf.Timestamp
+	9, // 1: log_message.LogRequest.server_time:type_name -> google.protobuf.Timestamp
 	1, // 2: log_message.LogRequest.service_type:type_name -> log_message.ServerType
-	2, // 3: log_message.Logger.SendLog:input_type -> log_message.LogRequest
-	3, // 4: log_message.Logger.SendLog:output_type -> log_message.LogResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2, // 3: log_message.LogBatchRequest.logs:type_name -> log_message.LogRequest
+	5, // 4: log_message.LogBatchResponse.rejected:type_name -> log_message.LogRejection
+	2, // 5: log_message.StreamLogsRequest.log:type_name -> log_message.LogRequest
+	2, // 6: log_message.Logger.SendLog:input_type -> log_message.LogRequest
+	4, // 7: log_message.Logger.SendLogBatch:input_type -> log_message.LogBatchRequest
+	7, // 8: log_message.Logger.StreamLogs:input_type -> log_message.StreamLogsRequest
+	3, // 9: log_message.Logger.SendLog:output_type -> log_message.LogResponse
+	6, // 10: log_message.Logger.SendLogBatch:output_type -> log_message.LogBatchResponse
+	8, // 11: log_message.Logger.StreamLogs:output_type -> log_message.LogAck
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_logger_proto_init() }
@@ -678,7 +978,7 @@ func file_logger_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_logger_proto_rawDesc), len(file_logger_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Logger_SendLog_FullMethodName = "/log_message.Logger/SendLog"
+	Logger_SendLog_FullMethodName      = "/log_message.Logger/SendLog"
+	Logger_SendLogBatch_FullMethodName = "/log_message.Logger/SendLogBatch"
+	Logger_StreamLogs_FullMethodName   = "/log_message.Logger/StreamLogs"
 )
 
 // LoggerClient is the client API for Logger service.
@@ -27,6 +29,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LoggerClient interface {
 	SendLog(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
+	// 여러 로그를 한 번의 RPC로 전송
+	SendLogBatch(ctx context.Context, in *LogBatchRequest, opts ...grpc.CallOption) (*LogBatchResponse, error)
+	// 로그 스트림 전송. 서버는 메시지마다 ack를 보내고,
+	// 클라이언트는 ack되지 않은 메시지를 window 개 이하로 유지해야 함
+	StreamLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamLogsRequest, LogAck], error)
 }
 
 type loggerClient struct {
@@ -47,11 +54,39 @@ func (c *loggerClient) SendLog(ctx context.Context, in *LogRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *loggerClient) SendLogBatch(ctx context.Context, in *LogBatchRequest, opts ...grpc.CallOption) (*LogBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogBatchResponse)
+	err := c.cc.Invoke(ctx, Logger_SendLogBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loggerClient) StreamLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamLogsRequest, LogAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Logger_ServiceDesc.Streams[0], Logger_StreamLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamLogsRequest, LogAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Logger_StreamLogsClient = grpc.BidiStreamingClient[StreamLogsRequest, LogAck]
+
 // LoggerServer is the server API for Logger service.
 // All implementations must embed UnimplementedLoggerServer
 // for forward compatibility.
 type LoggerServer interface {
 	SendLog(context.Context, *LogRequest) (*LogResponse, error)
+	// 여러 로그를 한 번의 RPC로 전송
+	SendLogBatch(context.Context, *LogBatchRequest) (*LogBatchResponse, error)
+	// 로그 스트림 전송. 서버는 메시지마다 ack를 보내고,
+	// 클라이언트는 ack되지 않은 메시지를 window 개 이하로 유지해야 함
+	StreamLogs(grpc.BidiStreamingServer[StreamLogsRequest, LogAck]) error
 	mustEmbedUnimplementedLoggerServer()
 }
 
@@ -65,6 +100,12 @@ type UnimplementedLoggerServer struct{}
 func (UnimplementedLoggerServer) SendLog(context.Context, *LogRequest) (*LogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendLog not implemented")
 }
+func (UnimplementedLoggerServer) SendLogBatch(context.Context, *LogBatchRequest) (*LogBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendLogBatch not implemented")
+}
+func (UnimplementedLoggerServer) StreamLogs(grpc.BidiStreamingServer[StreamLogsRequest, LogAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
 func (UnimplementedLoggerServer) mustEmbedUnimplementedLoggerServer() {}
 func (UnimplementedLoggerServer) testEmbeddedByValue()                {}
 
@@ -104,6 +145,31 @@ func _Logger_SendLog_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Logger_SendLogBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoggerServer).SendLogBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logger_SendLogBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoggerServer).SendLogBatch(ctx, req.(*LogBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logger_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LoggerServer).StreamLogs(&grpc.GenericServerStream[StreamLogsRequest, LogAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Logger_StreamLogsServer = grpc.BidiStreamingServer[StreamLogsRequest, LogAck]
+
 // Logger_ServiceDesc is the grpc.ServiceDesc for Logger service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +181,18 @@ var Logger_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendLog",
 			Handler:    _Logger_SendLog_Handler,
 		},
+		{
+			MethodName: "SendLogBatch",
+			Handler:    _Logger_SendLogBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _Logger_StreamLogs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "logger.proto",
 }
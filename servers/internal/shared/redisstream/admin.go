@@ -0,0 +1,263 @@
+package redisstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Admin provides administrative operations on Redis streams
+type Admin struct {
+	client *redis.Client
+}
+
+// NewAdmin creates a new stream admin
+func NewAdmin(redisClient *redis.Client) *Admin {
+	return &Admin{client: redisClient}
+}
+
+// ListStreams returns all stream keys matching pattern
+func (a *Admin) ListStreams(ctx context.Context, pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var (
+		cursor uint64
+		keys   []string
+	)
+	for {
+		batch, next, err := a.client.ScanType(ctx, cursor, pattern, 100, "stream").Result()
+		if err != nil {
+			return nil, fmt.Errorf("SCAN failed: %w", err)
+		}
+		keys = append(keys, batch...)
+
+		cursor = next
+		if cursor == 0 {
+			return keys, nil
+		}
+	}
+}
+
+// Groups returns the consumer groups of a stream along with their consumers
+func (a *Admin) Groups(ctx context.Context, streamKey string) (map[string][]redis.XInfoConsumer, []redis.XInfoGroup, error) {
+	groups, err := a.client.XInfoGroups(ctx, streamKey).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("XINFO GROUPS failed: %w", err)
+	}
+
+	consumers := make(map[string][]redis.XInfoConsumer, len(groups))
+	for _, g := range groups {
+		c, err := a.client.XInfoConsumers(ctx, streamKey, g.Name).Result()
+		if err != nil {
+			return nil, nil, fmt.Errorf("XINFO CONSUMERS failed for group %s: %w", g.Name, err)
+		}
+		consumers[g.Name] = c
+	}
+
+	return consumers, groups, nil
+}
+
+// Tail follows a stream starting after fromID ("$" for new entries only) and
+// invokes fn for each message until ctx is cancelled, fn fails or limit
+// messages were seen (0 means unlimited).
+func (a *Admin) Tail(ctx context.Context, streamKey, fromID string, limit int, fn func(redis.XMessage) error) error {
+	lastID := fromID
+	seen := 0
+
+	for {
+		streams, err := a.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{streamKey, lastID},
+			Count:   100,
+			Block:   5 * time.Second,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("XREAD failed: %w", err)
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				if err := fn(msg); err != nil {
+					return err
+				}
+				lastID = msg.ID
+
+				seen++
+				if limit > 0 && seen >= limit {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// Range returns up to count messages between start and end (inclusive). A count of 0 returns all of them.
+func (a *Admin) Range(ctx context.Context, streamKey, start, end string, count int64) ([]redis.XMessage, error) {
+	var (
+		msgs []redis.XMessage
+		err  error
+	)
+	if count > 0 {
+		msgs, err = a.client.XRangeN(ctx, streamKey, start, end, count).Result()
+	} else {
+		msgs, err = a.client.XRange(ctx, streamKey, start, end).Result()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("XRANGE failed: %w", err)
+	}
+	return msgs, nil
+}
+
+// Replay copies messages between start and end (inclusive) from srcKey into dstKey.
+// New IDs are generated on the destination stream. An end of "+" stops at the
+// last entry when the replay starts, so entries added meanwhile are not copied.
+func (a *Admin) Replay(ctx context.Context, srcKey, dstKey, start, end string) (int, error) {
+	const batchSize = 500
+
+	if srcKey == dstKey {
+		return 0, fmt.Errorf("cannot replay %s into itself", srcKey)
+	}
+	if end == "+" {
+		last, err := a.client.XRevRangeN(ctx, srcKey, "+", "-", 1).Result()
+		if err != nil {
+			return 0, fmt.Errorf("XREVRANGE failed: %w", err)
+		}
+		if len(last) == 0 {
+			return 0, nil
+		}
+		end = last[0].ID
+	}
+
+	copied := 0
+	for {
+		msgs, err := a.Range(ctx, srcKey, start, end, batchSize)
+		if err != nil {
+			return copied, err
+		}
+		if len(msgs) == 0 {
+			return copied, nil
+		}
+
+		pipe := a.client.Pipeline()
+		for _, msg := range msgs {
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: dstKey,
+				ID:     "*",
+				Values: msg.Values,
+			})
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return copied, fmt.Errorf("XADD to %s failed: %w", dstKey, err)
+		}
+		copied += len(msgs)
+
+		if len(msgs) < batchSize {
+			return copied, nil
+		}
+		// continue after the last copied entry
+		start = "(" + msgs[len(msgs)-1].ID
+	}
+}
+
+// ResetGroup moves the last-delivered ID of a group so that entries added at
+// or after t are delivered again. It returns the ID the group was set to.
+func (a *Admin) ResetGroup(ctx context.Context, streamKey, group string, t time.Time) (string, error) {
+	id := "0-0"
+	if ms := t.UnixMilli(); ms > 0 {
+		id = fmt.Sprintf("%d-%d", ms-1, uint64(1<<64-1))
+	}
+
+	if err := a.client.XGroupSetID(ctx, streamKey, group, id).Err(); err != nil {
+		return "", fmt.Errorf("XGROUP SETID failed: %w", err)
+	}
+	return id, nil
+}
+
+// SkipGroup moves the last-delivered ID of a group to the end of the stream so
+// that only entries added afterwards are delivered
+func (a *Admin) SkipGroup(ctx context.Context, streamKey, group string) error {
+	if err := a.client.XGroupSetID(ctx, streamKey, group, "$").Err(); err != nil {
+		return fmt.Errorf("XGROUP SETID failed: %w", err)
+	}
+	return nil
+}
+
+// DeadLetters returns up to count entries of the dead-letter stream of streamKey
+func (a *Admin) DeadLetters(ctx context.Context, streamKey string, count int64) ([]redis.XMessage, error) {
+	return a.Range(ctx, DeadLetterKey(streamKey), "-", "+", count)
+}
+
+// RequeueDeadLetters re-adds dead-lettered entries to their source stream and
+// removes them from the dead-letter stream. When ids is empty every entry is requeued.
+func (a *Admin) RequeueDeadLetters(ctx context.Context, streamKey string, ids []string) (int, error) {
+	dlqKey := DeadLetterKey(streamKey)
+
+	var msgs []redis.XMessage
+	if len(ids) == 0 {
+		all, err := a.Range(ctx, dlqKey, "-", "+", 0)
+		if err != nil {
+			return 0, err
+		}
+		msgs = all
+	} else {
+		for _, id := range ids {
+			found, err := a.Range(ctx, dlqKey, id, id, 1)
+			if err != nil {
+				return 0, err
+			}
+			msgs = append(msgs, found...)
+		}
+	}
+
+	requeued := 0
+	for _, msg := range msgs {
+		values := make(map[string]interface{}, len(msg.Values))
+		for k, v := range msg.Values {
+			if strings.HasPrefix(k, deadLetterFieldPrefix) {
+				continue
+			}
+			values[k] = v
+		}
+
+		if err := a.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: streamKey,
+			ID:     "*",
+			Values: values,
+		}).Err(); err != nil {
+			return requeued, fmt.Errorf("XADD to %s failed: %w", streamKey, err)
+		}
+
+		if err := a.client.XDel(ctx, dlqKey, msg.ID).Err(); err != nil {
+			return requeued, fmt.Errorf("XDEL from %s failed: %w", dlqKey, err)
+		}
+		requeued++
+	}
+
+	return requeued, nil
+}
+
+// PurgeDeadLetters deletes the dead-letter stream of streamKey and returns how many entries it held
+func (a *Admin) PurgeDeadLetters(ctx context.Context, streamKey string) (int64, error) {
+	dlqKey := DeadLetterKey(streamKey)
+
+	length, err := a.client.XLen(ctx, dlqKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("XLEN failed: %w", err)
+	}
+
+	if err := a.client.Del(ctx, dlqKey).Err(); err != nil {
+		return 0, fmt.Errorf("DEL failed: %w", err)
+	}
+	return length, nil
+}
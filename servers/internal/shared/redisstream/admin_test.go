@@ -0,0 +1,134 @@
+package redisstream
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestReplayCopiesRange(t *testing.T) {
+	ctx := context.Background()
+	f, client := newFakeRedis(t)
+	f.mu.Lock()
+	first := f.add("src", "n", "1")
+	f.add("src", "n", "2")
+	third := f.add("src", "n", "3")
+	f.add("src", "n", "4")
+	f.mu.Unlock()
+
+	copied, err := NewAdmin(client).Replay(ctx, "src", "dst", first, third)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied != 3 {
+		t.Fatalf("expected 3 copied entries, got %d", copied)
+	}
+
+	var values []string
+	for _, e := range f.entries("dst") {
+		if e.id == first || e.id == third {
+			t.Errorf("expected new IDs on the destination, got %s", e.id)
+		}
+		values = append(values, e.fields[1])
+	}
+	if want := []string{"1", "2", "3"}; !slices.Equal(values, want) {
+		t.Errorf("expected %v on the destination, got %v", want, values)
+	}
+}
+
+func TestReplayStopsAtTheLastEntryWhenStarted(t *testing.T) {
+	ctx := context.Background()
+	f, client := newFakeRedis(t)
+	// A full batch, so the replay reads the source again
+	const entries = 500
+	f.mu.Lock()
+	for range entries {
+		f.add("src", "n", "old")
+	}
+	// A producer keeps appending to the source while it is replayed
+	f.afterCommand = func(f *fakeRedis, args []string) {
+		if strings.EqualFold(args[0], "XADD") && args[1] == "dst" {
+			f.add("src", "n", "new")
+		}
+	}
+	f.mu.Unlock()
+
+	copied, err := NewAdmin(client).Replay(ctx, "src", "dst", "-", "+")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied != entries || len(f.entries("dst")) != entries {
+		t.Errorf("expected the %d entries present at the start to be copied, got %d", entries, copied)
+	}
+}
+
+func TestReplayRejectsTheSameStream(t *testing.T) {
+	f, client := newFakeRedis(t)
+	f.mu.Lock()
+	f.add("src", "n", "1")
+	f.mu.Unlock()
+
+	if _, err := NewAdmin(client).Replay(context.Background(), "src", "src", "-", "+"); err == nil {
+		t.Fatal("expected replaying a stream into itself to fail")
+	}
+	if n := len(f.entries("src")); n != 1 {
+		t.Errorf("expected the stream to be left alone, got %d entries", n)
+	}
+}
+
+func TestDeadLettersAreMovedAndRequeued(t *testing.T) {
+	ctx := context.Background()
+	f, client := newFakeRedis(t)
+	c := &Consumer[string]{
+		logger: slog.New(slog.DiscardHandler),
+		client: client,
+		config: Config{StreamKey: "events", ConsumerGroup: "group", DeadLetterStreamKey: DeadLetterKey("events")},
+	}
+
+	for _, id := range []string{"1-1", "1-2"} {
+		c.deadLetter(ctx, redis.XMessage{ID: id, Values: map[string]any{"payload": id}}, "invalid payload")
+	}
+	f.mu.Lock()
+	acked := f.acked
+	f.mu.Unlock()
+	if !slices.Equal(acked, []string{"1-1", "1-2"}) {
+		t.Errorf("expected the dead-lettered messages to be acknowledged, got %v", acked)
+	}
+
+	admin := NewAdmin(client)
+	letters, err := admin.DeadLetters(ctx, "events", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("expected 2 dead letters, got %d", len(letters))
+	}
+	if got := letters[0].Values; got["payload"] != "1-1" || got[DeadLetterFieldSourceID] != "1-1" ||
+		got[DeadLetterFieldGroup] != "group" || got[DeadLetterFieldReason] != "invalid payload" {
+		t.Errorf("unexpected dead letter %v", got)
+	}
+
+	requeued, err := admin.RequeueDeadLetters(ctx, "events", []string{letters[1].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeued != 1 {
+		t.Fatalf("expected 1 requeued entry, got %d", requeued)
+	}
+	source := f.entries("events")
+	if len(source) != 1 || !slices.Equal(source[0].fields, []string{"payload", "1-2"}) {
+		t.Errorf("expected the payload back without dead-letter fields, got %v", source)
+	}
+
+	purged, err := admin.PurgeDeadLetters(ctx, "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 || len(f.entries(DeadLetterKey("events"))) != 0 {
+		t.Errorf("expected the remaining dead letter to be purged, got %d", purged)
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/resilience"
 )
 
 // Config holds configuration for Redis stream consumer
@@ -20,11 +23,54 @@ type Config struct {
 	ConsumerIDPrefix string
 	BatchSize        int
 	BlockTime        time.Duration
-	MaxRetries       int
-	RetryDelay       time.Duration
-	MinIdle          time.Duration
+	// MaxRetries is the number of deliveries of a single message before it is dead-lettered
+	MaxRetries int
+	// RetryDelay is the initial backoff after a failed poll when Retry is not set
+	RetryDelay time.Duration
+	MinIdle    time.Duration
+	// DeadLetterStreamKey receives messages that failed to parse or exceeded
+	// MaxRetries deliveries. Defaults to DeadLetterKey(StreamKey).
+	DeadLetterStreamKey string
+	// Retry is the backoff between failed polls. Defaults to
+	// resilience.DefaultRetryPolicy(RetryDelay).
+	Retry resilience.RetryPolicy
+	// Breaker stops polling after consecutive failures and probes the backend after its cooldown
+	Breaker resilience.BreakerConfig
+	// Health receives the circuit breaker state under HealthComponent(); optional
+	Health *health.Registry
 }
 
+// RetryPolicy returns the configured retry policy or the default one
+func (c Config) RetryPolicy() resilience.RetryPolicy {
+	if c.Retry.InitialDelay > 0 {
+		return c.Retry
+	}
+	return resilience.DefaultRetryPolicy(c.RetryDelay)
+}
+
+// HealthComponent is the health registry component of the consumer group
+func (c Config) HealthComponent() string {
+	return "consumer:" + c.StreamKey + "/" + c.ConsumerGroup
+}
+
+// errParse marks messages that can never be processed and go straight to the dead-letter stream
+var errParse = errors.New("failed to parse message")
+
+// DeadLetterKey returns the default dead-letter stream key for a stream
+func DeadLetterKey(streamKey string) string {
+	return streamKey + ":dlq"
+}
+
+// Dead-letter metadata fields added to the original message values
+const (
+	deadLetterFieldPrefix       = "_dlq_"
+	DeadLetterFieldSourceStream = deadLetterFieldPrefix + "source_stream"
+	DeadLetterFieldSourceID     = deadLetterFieldPrefix + "source_id"
+	DeadLetterFieldGroup        = deadLetterFieldPrefix + "group"
+	DeadLetterFieldReason       = deadLetterFieldPrefix + "reason"
+	DeadLetterFieldFailedAt     = deadLetterFieldPrefix + "failed_at"
+)
+
 // ParseFunc is a function that parses a Redis message into type T
 type ParseFunc[T any] func(redis.XMessage) (T, error)
 
@@ -36,8 +82,13 @@ type Consumer[T any] struct {
 	transferCh chan<- T
 	parseFunc  ParseFunc[T]
 	exitCh     chan struct{}
-	ErrCh      chan error
 	config     Config
+	retry      resilience.RetryPolicy
+	breaker    *resilience.CircuitBreaker
+
+	// optional deduplication of redelivered messages, see UseIdempotency
+	idempotency idempotency.Store
+	idFunc      idempotency.IDFunc[T]
 }
 
 // NewConsumer creates a new generic Redis stream consumer
@@ -61,6 +112,10 @@ func NewConsumer[T any](
 	}
 	consumerID := fmt.Sprintf("%s-%s-%d", config.ConsumerIDPrefix, hostname, os.Getpid())
 
+	if config.DeadLetterStreamKey == "" {
+		config.DeadLetterStreamKey = DeadLetterKey(config.StreamKey)
+	}
+
 	consumer := &Consumer[T]{
 		logger:     logger,
 		client:     redisClient,
@@ -68,29 +123,32 @@ func NewConsumer[T any](
 		transferCh: transferCh,
 		parseFunc:  parseFunc,
 		exitCh:     make(chan struct{}, 1),
-		ErrCh:      make(chan error, 1),
 		config:     config,
+		retry:      config.RetryPolicy(),
+		breaker:    resilience.NewCircuitBreaker(config.HealthComponent(), config.Breaker, config.Health),
 	}
 
 	return consumer
 }
 
+// UseIdempotency skips messages whose ID, as returned by idFunc, was already
+// handed off by this consumer group. It must be called before ConsumeLoop.
+//
+// The ID is claimed only after the hand-off, so a crash in between hands the
+// redelivered message off again instead of dropping it. This skips
+// redeliveries of messages whose acknowledgement failed; it does not make the
+// processor's side effects exactly-once. Processors that need that should
+// claim the ID themselves, after applying the message or in the same transaction.
+func (c *Consumer[T]) UseIdempotency(store idempotency.Store, idFunc idempotency.IDFunc[T]) {
+	c.idempotency = store
+	c.idFunc = idFunc
+}
+
 // ConsumeLoop begins consuming messages from the Redis stream
 func (c *Consumer[T]) ConsumeLoop(ctx context.Context, wg *sync.WaitGroup) error {
-	// initConsumerGroup creates the consumer group if it doesn't exist
-	if err := c.client.XGroupCreateMkStream(
-		ctx,
-		c.config.StreamKey,
-		c.config.ConsumerGroup,
-		"0-0",
-	).Err(); err != nil {
-		// ignore BUSYGROUP error if it already exists
-		if strings.Contains(err.Error(), "BUSYGROUP") {
-			c.logger.Info("consumer group already exists, proceeding")
-		} else {
-			c.logger.Error("failed to create consumer group", "error", err)
-			return fmt.Errorf("failed to create consumer group '%s': %w", c.config.ConsumerGroup, err)
-		}
+	if err := c.ensureGroup(ctx); err != nil {
+		c.logger.Error("failed to create consumer group", "error", err)
+		return err
 	}
 
 	c.logger.Info("consumer group ready; stream consumer initialized; start loop",
@@ -105,9 +163,30 @@ func (c *Consumer[T]) ConsumeLoop(ctx context.Context, wg *sync.WaitGroup) error
 	return nil
 }
 
-// consumeLoop is the main consumption loop with error handling and recovery
+// ensureGroup creates the consumer group if it doesn't exist
+func (c *Consumer[T]) ensureGroup(ctx context.Context) error {
+	if err := c.client.XGroupCreateMkStream(
+		ctx,
+		c.config.StreamKey,
+		c.config.ConsumerGroup,
+		"0-0",
+	).Err(); err != nil {
+		// ignore BUSYGROUP error if it already exists
+		if strings.Contains(err.Error(), "BUSYGROUP") {
+			c.logger.Info("consumer group already exists, proceeding")
+			return nil
+		}
+		return fmt.Errorf("failed to create consumer group '%s': %w", c.config.ConsumerGroup, err)
+	}
+	return nil
+}
+
+// consumeLoop is the main consumption loop. Failed polls are retried with
+// exponential backoff; after repeated failures the circuit breaker opens and
+// the loop waits for its cooldown, then probes Redis before resuming.
+// The loop only stops on shutdown or context cancellation.
 func (c *Consumer[T]) consumeLoop(ctx context.Context) {
-	retryCount := 0
+	attempt := 0
 
 	for {
 		select {
@@ -118,47 +197,60 @@ func (c *Consumer[T]) consumeLoop(ctx context.Context) {
 			c.logger.Info("consume loop stopping due to shutdown signal")
 			return
 		default:
-			// Process pending messages first
-			c.processPendingMessages(ctx, c.ErrCh)
-			c.readMessages(ctx, c.ErrCh)
-
-			select {
-			case err := <-c.ErrCh:
-				c.logger.Warn("error during message processing",
-					"error", err,
-					"retry_count", retryCount,
-				)
-				retryCount++
-
-				if retryCount >= c.config.MaxRetries {
-					c.logger.Error("max retries exceeded; stop consumeLoop.", "error", err)
-					c.ErrCh <- fmt.Errorf("max retries (%d) exceeded: %w", c.config.MaxRetries, err)
-					return
-				}
+		}
 
-				// Exponential backoff
-				backoffDelay := time.Duration(retryCount) * c.config.RetryDelay
-				c.logger.Info("waiting before retry",
-					"delay", backoffDelay,
-					"retry_count", retryCount,
-					"backoff_delay", backoffDelay,
-				)
-
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoffDelay):
-					continue
-				}
-			default:
-				retryCount = 0
+		if !c.breaker.Allow() {
+			if !resilience.Sleep(ctx, c.exitCh, c.breaker.RetryAfter()) {
+				return
 			}
+			continue
 		}
+
+		if err := c.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			attempt++
+			c.breaker.Failure(err)
+			delay := c.retry.Backoff(attempt)
+			c.logger.Warn("stream poll failed; waiting before retry",
+				"error", err,
+				"attempt", attempt,
+				"delay", delay,
+				"breaker", c.breaker.State())
+
+			if !resilience.Sleep(ctx, c.exitCh, delay) {
+				return
+			}
+			continue
+		}
+
+		if attempt > 0 {
+			c.logger.Info("stream consumer recovered", "failed_attempts", attempt)
+			attempt = 0
+		}
+		c.breaker.Success()
 	}
 }
 
+// poll processes pending messages, then reads new ones. A half open breaker
+// pings Redis first so that a dead connection fails fast.
+func (c *Consumer[T]) poll(ctx context.Context) error {
+	if c.breaker.State() == resilience.StateHalfOpen {
+		if err := c.client.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("redis probe failed: %w", err)
+		}
+	}
+
+	if err := c.processPendingMessages(ctx); err != nil {
+		return err
+	}
+	return c.readMessages(ctx)
+}
+
 // readMessages reads new messages from the stream using XREADGROUP
-func (c *Consumer[T]) readMessages(ctx context.Context, errCh chan error) {
+func (c *Consumer[T]) readMessages(ctx context.Context) error {
 	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    c.config.ConsumerGroup,
 		Consumer: c.consumerID,
@@ -167,18 +259,28 @@ func (c *Consumer[T]) readMessages(ctx context.Context, errCh chan error) {
 		Block:    c.config.BlockTime,
 	}).Result()
 	if err != nil {
-		if !errors.Is(err, redis.Nil) {
-			errCh <- fmt.Errorf("XREADGROUP failed: %w", err)
+		if errors.Is(err, redis.Nil) {
+			return nil
 		}
-		return
+		// the group is gone when Redis restarted without persistence; recreate it
+		if strings.Contains(err.Error(), "NOGROUP") {
+			c.logger.Warn("consumer group missing; recreating", "group", c.config.ConsumerGroup)
+			if groupErr := c.ensureGroup(ctx); groupErr != nil {
+				return groupErr
+			}
+		}
+		return fmt.Errorf("XREADGROUP failed: %w", err)
 	}
 
 	for _, stream := range streams {
 		for _, message := range stream.Messages {
-			if err := c.processMessage(message); err != nil {
+			if err := c.processMessage(ctx, message); err != nil {
 				c.logger.Error("failed to process message",
 					"message_id", message.ID,
 					"error", err)
+				if errors.Is(err, errParse) {
+					c.deadLetter(ctx, message, err.Error())
+				}
 				continue
 			}
 
@@ -191,14 +293,15 @@ func (c *Consumer[T]) readMessages(ctx context.Context, errCh chan error) {
 			}
 		}
 	}
+
+	return nil
 }
 
 // processPendingMessages handles messages that were delivered but not acknowledged
-func (c *Consumer[T]) processPendingMessages(ctx context.Context, errCh chan error) {
-	allPending, err := c.getAllPendingMessages(ctx, errCh)
+func (c *Consumer[T]) processPendingMessages(ctx context.Context) error {
+	allPending, err := c.getAllPendingMessages(ctx)
 	if err != nil {
 		c.logger.Error("failed to get all pending messages", "error", err)
-		return
 	}
 
 	for _, pending := range allPending {
@@ -216,6 +319,11 @@ func (c *Consumer[T]) processPendingMessages(ctx context.Context, errCh chan err
 			"idle", pending.Idle,
 			"own_consumer", pending.Consumer == c.consumerID)
 
+		if c.exceededMaxRetries(pending) {
+			c.deadLetterPending(ctx, pending)
+			continue
+		}
+
 		if shouldClaim {
 			messages, err := c.claimMessage(ctx, pending, minIdle)
 			if err != nil {
@@ -229,6 +337,8 @@ func (c *Consumer[T]) processPendingMessages(ctx context.Context, errCh chan err
 			c.processClaimedMessages(ctx, messages)
 		}
 	}
+
+	return err
 }
 
 func (c *Consumer[T]) claimMessage(ctx context.Context, e redis.XPendingExt, minIdle time.Duration) ([]redis.XMessage, error) {
@@ -257,16 +367,16 @@ func (c *Consumer[T]) claimMessage(ctx context.Context, e redis.XPendingExt, min
 	return msgs, nil
 }
 
-// getAllPendingMessages retrieves all pending messages across all consumers
-func (c *Consumer[T]) getAllPendingMessages(ctx context.Context, errCh chan error) ([]redis.XPendingExt, error) {
+// getAllPendingMessages retrieves all pending messages across all consumers.
+// Messages of consumers that could not be listed are skipped and reported in the returned error.
+func (c *Consumer[T]) getAllPendingMessages(ctx context.Context) ([]redis.XPendingExt, error) {
 	totalPending, err := c.client.XPending(ctx, c.config.StreamKey, c.config.ConsumerGroup).Result()
 	if err != nil {
-		c.logger.Error("Failed to get total pending count", "error", err)
-		errCh <- fmt.Errorf("failed to get total pending count: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to get total pending count: %w", err)
 	}
 
 	var allPending []redis.XPendingExt
+	var errs []error
 	for consumer, count := range totalPending.Consumers {
 		if count == 0 {
 			continue
@@ -284,14 +394,14 @@ func (c *Consumer[T]) getAllPendingMessages(ctx context.Context, errCh chan erro
 			c.logger.Error("XPENDINGEXT Failed",
 				slog.Any("args", args),
 				"error", err)
-			errCh <- fmt.Errorf("failed to get detailed pending for consumer %s: %w", consumer, err)
+			errs = append(errs, fmt.Errorf("failed to get detailed pending for consumer %s: %w", consumer, err))
 			continue
 		}
 
 		allPending = append(allPending, detailedPending...)
 	}
 
-	return allPending, nil
+	return allPending, errors.Join(errs...)
 }
 
 // shouldClaimMessage determines if a pending message should be claimed
@@ -300,20 +410,74 @@ func (c *Consumer[T]) shouldClaimMessage(e redis.XPendingExt) (bool, time.Durati
 		return true, 0
 	}
 
-	hasReachedAtMaxRetries := e.RetryCount > int64(c.config.MaxRetries)
 	isIdleTimeout := e.Idle > c.config.MinIdle
-	shouldClaim := hasReachedAtMaxRetries || isIdleTimeout
+	return isIdleTimeout, c.config.MinIdle
+}
+
+// exceededMaxRetries reports whether a pending message was delivered too many times
+func (c *Consumer[T]) exceededMaxRetries(e redis.XPendingExt) bool {
+	return e.RetryCount > int64(c.config.MaxRetries)
+}
+
+// deadLetterPending claims a message that exceeded MaxRetries and moves it to the dead-letter stream
+func (c *Consumer[T]) deadLetterPending(ctx context.Context, e redis.XPendingExt) {
+	messages, err := c.claimMessage(ctx, e, 0)
+	if err != nil {
+		return
+	}
+
+	for _, msg := range messages {
+		c.deadLetter(ctx, msg, fmt.Sprintf("exceeded max retries (%d)", c.config.MaxRetries))
+	}
+}
+
+// deadLetter copies a message to the dead-letter stream and acknowledges the original
+func (c *Consumer[T]) deadLetter(ctx context.Context, msg redis.XMessage, reason string) {
+	values := make(map[string]interface{}, len(msg.Values)+5)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values[DeadLetterFieldSourceStream] = c.config.StreamKey
+	values[DeadLetterFieldSourceID] = msg.ID
+	values[DeadLetterFieldGroup] = c.config.ConsumerGroup
+	values[DeadLetterFieldReason] = reason
+	values[DeadLetterFieldFailedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	if err := c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.config.DeadLetterStreamKey,
+		ID:     "*",
+		Values: values,
+	}).Err(); err != nil {
+		c.logger.Error("failed to move message to dead-letter stream",
+			"message_id", msg.ID,
+			"dead_letter_stream", c.config.DeadLetterStreamKey,
+			"error", err)
+		return
+	}
+
+	if err := c.ackMessage(ctx, msg.ID); err != nil {
+		c.logger.Error("failed to acknowledge dead-lettered message",
+			"message_id", msg.ID,
+			"error", err)
+		return
+	}
 
-	return shouldClaim, c.config.MinIdle
+	c.logger.Warn("message moved to dead-letter stream",
+		"message_id", msg.ID,
+		"dead_letter_stream", c.config.DeadLetterStreamKey,
+		"reason", reason)
 }
 
 // processClaimedMessages processes and acknowledges claimed messages
 func (c *Consumer[T]) processClaimedMessages(ctx context.Context, messages []redis.XMessage) {
 	for _, msg := range messages {
-		if err := c.processMessage(msg); err != nil {
+		if err := c.processMessage(ctx, msg); err != nil {
 			c.logger.Error("failed to process claimed message",
 				slog.String("message_id", msg.ID),
 				slog.String("error", err.Error()))
+			if errors.Is(err, errParse) {
+				c.deadLetter(ctx, msg, err.Error())
+			}
 			continue
 		}
 
@@ -327,7 +491,7 @@ func (c *Consumer[T]) processClaimedMessages(ctx context.Context, messages []red
 }
 
 // processMessage handles a single message
-func (c *Consumer[T]) processMessage(msg redis.XMessage) error {
+func (c *Consumer[T]) processMessage(ctx context.Context, msg redis.XMessage) error {
 	c.logger.Debug("processing message",
 		"message_id", msg.ID,
 		slog.Any("values", msg.Values))
@@ -335,16 +499,69 @@ func (c *Consumer[T]) processMessage(msg redis.XMessage) error {
 	// Parse the message using the injected parse function
 	parsedMsg, err := c.parseFunc(msg)
 	if err != nil {
-		return fmt.Errorf("failed to parse message: %w", err)
+		return fmt.Errorf("%w: %w", errParse, err)
 	}
 
-	// Send to transfer channel
-	c.transferCh <- parsedMsg
+	if c.isDuplicate(ctx, parsedMsg) {
+		c.logger.Info("skipping duplicate message", "message_id", msg.ID)
+		return nil
+	}
+
+	// Send to transfer channel. A message that cannot be handed off before
+	// shutdown stays pending and is redelivered.
+	select {
+	case c.transferCh <- parsedMsg:
+	case <-c.exitCh:
+		return fmt.Errorf("consumer stopped before handing off message %s", msg.ID)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	c.claim(ctx, parsedMsg)
 	c.logger.Info("message processed successfully",
 		"message_id", msg.ID)
 	return nil
 }
 
+// isDuplicate reports whether the message ID was already handed off.
+// Store errors are logged and the message is processed, preferring a duplicate over a loss.
+func (c *Consumer[T]) isDuplicate(ctx context.Context, msg T) bool {
+	if c.idempotency == nil || c.idFunc == nil {
+		return false
+	}
+
+	id := c.idFunc(msg)
+	if id == "" {
+		return false
+	}
+
+	seen, err := c.idempotency.Seen(ctx, idempotency.Scope(c.config.StreamKey, c.config.ConsumerGroup), id)
+	if err != nil {
+		c.logger.Warn("idempotency check failed; processing message anyway",
+			"id", id,
+			"error", err)
+		return false
+	}
+	return seen
+}
+
+// claim records the message ID once the message was handed off
+func (c *Consumer[T]) claim(ctx context.Context, msg T) {
+	if c.idempotency == nil || c.idFunc == nil {
+		return
+	}
+
+	id := c.idFunc(msg)
+	if id == "" {
+		return
+	}
+
+	if _, err := c.idempotency.Claim(ctx, idempotency.Scope(c.config.StreamKey, c.config.ConsumerGroup), id); err != nil {
+		c.logger.Warn("failed to claim handed off message; a redelivery will be handed off again",
+			"id", id,
+			"error", err)
+	}
+}
+
 // ackMessage acknowledges a processed message
 func (c *Consumer[T]) ackMessage(ctx context.Context, messageID string) error {
 	if ackCount, err := c.client.XAck(ctx, c.config.StreamKey, c.config.ConsumerGroup, messageID).Result(); err != nil {
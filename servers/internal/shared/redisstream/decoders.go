@@ -0,0 +1,54 @@
+package redisstream
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DecodeFunc decodes a stream message into an arbitrary value for inspection tools
+type DecodeFunc func(redis.XMessage) (any, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = make(map[string]DecodeFunc)
+)
+
+// RegisterDecoder makes a ParseFunc available to tooling (e.g. streamctl) under name.
+// Packages owning a stream format register their parser from init.
+func RegisterDecoder[T any](name string, parse ParseFunc[T]) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	if _, exists := decoders[name]; exists {
+		panic(fmt.Sprintf("redisstream: decoder %q registered twice", name))
+	}
+
+	decoders[name] = func(msg redis.XMessage) (any, error) {
+		return parse(msg)
+	}
+}
+
+// Decoder returns the decoder registered under name
+func Decoder(name string) (DecodeFunc, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	decode, ok := decoders[name]
+	return decode, ok
+}
+
+// Decoders returns the names of all registered decoders
+func Decoders() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,253 @@
+package redisstream
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeEntry is a stream entry with its fields in insertion order
+type fakeEntry struct {
+	id     string
+	fields []string
+}
+
+// fakeRedis is a minimal Redis server for the stream commands used by the
+// admin, the inspector and the consumer, so they are tested without Redis
+type fakeRedis struct {
+	mu        sync.Mutex
+	streams   map[string][]fakeEntry
+	consumers map[string][]redis.XInfoConsumer
+	acked     []string
+	seq       int
+	// afterCommand runs after each command with the lock held
+	afterCommand func(f *fakeRedis, args []string)
+}
+
+// newFakeRedis starts a fake server and returns a client connected to it
+func newFakeRedis(t *testing.T) (*fakeRedis, *redis.Client) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeRedis{
+		streams:   make(map[string][]fakeEntry),
+		consumers: make(map[string][]redis.XInfoConsumer),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{Addr: listener.Addr().String(), Protocol: 2, DisableIdentity: true})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+	})
+	return f, client
+}
+
+// add appends an entry to a stream and returns its ID. Must be called with f.mu held.
+func (f *fakeRedis) add(key string, fields ...string) string {
+	f.seq++
+	id := fmt.Sprintf("1700000000000-%d", f.seq)
+	f.streams[key] = append(f.streams[key], fakeEntry{id: id, fields: fields})
+	return id
+}
+
+// entries returns the entries of a stream
+func (f *fakeRedis) entries(key string) []fakeEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeEntry(nil), f.streams[key]...)
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.exec(w, args)
+		if f.afterCommand != nil {
+			f.afterCommand(f, args)
+		}
+		f.mu.Unlock()
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+// exec runs a command. Must be called with f.mu held.
+func (f *fakeRedis) exec(w *bufio.Writer, args []string) {
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		fmt.Fprint(w, "+PONG\r\n")
+	case "XADD":
+		// XADD key * field value ...
+		id := f.add(args[1], args[3:]...)
+		writeBulk(w, id)
+	case "XRANGE", "XREVRANGE":
+		start, end := args[2], args[3]
+		reverse := strings.ToUpper(args[0]) == "XREVRANGE"
+		if reverse {
+			start, end = end, start
+		}
+		count := -1
+		if len(args) == 6 {
+			count, _ = strconv.Atoi(args[5])
+		}
+		var matched []fakeEntry
+		for _, e := range f.streams[args[1]] {
+			if inRange(e.id, start, end) {
+				matched = append(matched, e)
+			}
+		}
+		if reverse {
+			for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+				matched[i], matched[j] = matched[j], matched[i]
+			}
+		}
+		if count >= 0 && len(matched) > count {
+			matched = matched[:count]
+		}
+		fmt.Fprintf(w, "*%d\r\n", len(matched))
+		for _, e := range matched {
+			fmt.Fprint(w, "*2\r\n")
+			writeBulk(w, e.id)
+			fmt.Fprintf(w, "*%d\r\n", len(e.fields))
+			for _, field := range e.fields {
+				writeBulk(w, field)
+			}
+		}
+	case "XDEL":
+		deleted := 0
+		for _, id := range args[2:] {
+			entries := f.streams[args[1]]
+			for i, e := range entries {
+				if e.id == id {
+					f.streams[args[1]] = append(entries[:i], entries[i+1:]...)
+					deleted++
+					break
+				}
+			}
+		}
+		fmt.Fprintf(w, ":%d\r\n", deleted)
+	case "XLEN":
+		fmt.Fprintf(w, ":%d\r\n", len(f.streams[args[1]]))
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := f.streams[key]; ok {
+				delete(f.streams, key)
+				deleted++
+			}
+		}
+		fmt.Fprintf(w, ":%d\r\n", deleted)
+	case "XACK":
+		f.acked = append(f.acked, args[3:]...)
+		fmt.Fprintf(w, ":%d\r\n", len(args)-3)
+	case "XINFO":
+		// XINFO CONSUMERS key group
+		consumers := f.consumers[args[2]+"/"+args[3]]
+		fmt.Fprintf(w, "*%d\r\n", len(consumers))
+		for _, c := range consumers {
+			fmt.Fprint(w, "*6\r\n")
+			writeBulk(w, "name")
+			writeBulk(w, c.Name)
+			writeBulk(w, "pending")
+			fmt.Fprintf(w, ":%d\r\n", c.Pending)
+			writeBulk(w, "idle")
+			fmt.Fprintf(w, ":%d\r\n", c.Idle.Milliseconds())
+		}
+	case "XGROUP":
+		// XGROUP DELCONSUMER key group consumer
+		key := args[2] + "/" + args[3]
+		consumers := f.consumers[key]
+		for i, c := range consumers {
+			if c.Name == args[4] {
+				f.consumers[key] = append(consumers[:i], consumers[i+1:]...)
+				break
+			}
+		}
+		fmt.Fprint(w, ":0\r\n")
+	default:
+		fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func writeBulk(w *bufio.Writer, s string) {
+	fmt.Fprintf(w, "$%d\r\n%s\r\n", len(s), s)
+}
+
+// inRange reports whether id is between start and end, which may be "-", "+"
+// or exclusive IDs starting with "("
+func inRange(id, start, end string) bool {
+	switch {
+	case start == "-":
+	case strings.HasPrefix(start, "("):
+		if CompareIDs(id, start[1:]) <= 0 {
+			return false
+		}
+	default:
+		if CompareIDs(id, start) < 0 {
+			return false
+		}
+	}
+
+	switch {
+	case end == "+":
+		return true
+	case strings.HasPrefix(end, "("):
+		return CompareIDs(id, end[1:]) < 0
+	default:
+		return CompareIDs(id, end) <= 0
+	}
+}
@@ -0,0 +1,308 @@
+package redisstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
+)
+
+// InspectTarget identifies a stream and the consumer group to inspect on it
+type InspectTarget struct {
+	StreamKey     string
+	ConsumerGroup string
+}
+
+// InspectorConfig holds configuration for the stream inspector
+type InspectorConfig struct {
+	Targets  []InspectTarget
+	Interval time.Duration
+
+	// IdleConsumerAfter marks consumers idle for longer than this as idle
+	IdleConsumerAfter time.Duration
+	// DeadConsumerAfter removes consumers without pending messages that have
+	// been idle for longer than this. Zero disables removal.
+	DeadConsumerAfter time.Duration
+
+	// MaxLag and MaxPendingAge mark a group as degraded when exceeded. Zero disables the check.
+	MaxLag        int64
+	MaxPendingAge time.Duration
+}
+
+// ConsumerStats describes a single consumer inside a group
+type ConsumerStats struct {
+	Name    string        `json:"name"`
+	Pending int64         `json:"pending"`
+	Idle    time.Duration `json:"idle"`
+}
+
+// GroupStats describes the state of a consumer group on a stream
+type GroupStats struct {
+	StreamKey        string          `json:"stream_key"`
+	ConsumerGroup    string          `json:"consumer_group"`
+	StreamLength     int64           `json:"stream_length"`
+	LastGeneratedID  string          `json:"last_generated_id"`
+	LastDeliveredID  string          `json:"last_delivered_id"`
+	Lag              int64           `json:"lag"`
+	Pending          int64           `json:"pending"`
+	OldestPendingID  string          `json:"oldest_pending_id,omitempty"`
+	OldestPendingAge time.Duration   `json:"oldest_pending_age"`
+	Consumers        []ConsumerStats `json:"consumers"`
+	IdleConsumers    int             `json:"idle_consumers"`
+	RemovedConsumers []string        `json:"removed_consumers,omitempty"`
+	InspectedAt      time.Time       `json:"inspected_at"`
+}
+
+// Inspector periodically reads XINFO for configured streams and computes
+// consumer lag, pending counts and idle consumers.
+type Inspector struct {
+	client   *redis.Client
+	logger   *slog.Logger
+	registry *health.Registry
+	config   InspectorConfig
+
+	mu       sync.RWMutex
+	snapshot []GroupStats
+}
+
+// NewInspector creates a new stream inspector. registry may be nil.
+func NewInspector(
+	logger *slog.Logger,
+	redisClient *redis.Client,
+	registry *health.Registry,
+	config InspectorConfig,
+) *Inspector {
+	if config.Interval <= 0 {
+		config.Interval = 15 * time.Second
+	}
+
+	return &Inspector{
+		client:   redisClient,
+		logger:   logger,
+		registry: registry,
+		config:   config,
+	}
+}
+
+// Run inspects the configured streams every Interval until ctx is cancelled
+func (i *Inspector) Run(ctx context.Context) {
+	i.logger.Info("stream inspector started",
+		"targets", len(i.config.Targets),
+		"interval", i.config.Interval)
+
+	ticker := time.NewTicker(i.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		i.inspectAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			i.logger.Info("stream inspector stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Snapshot returns the result of the latest inspection
+func (i *Inspector) Snapshot() []GroupStats {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	snapshot := make([]GroupStats, len(i.snapshot))
+	copy(snapshot, i.snapshot)
+	return snapshot
+}
+
+func (i *Inspector) inspectAll(ctx context.Context) {
+	results := make([]GroupStats, 0, len(i.config.Targets))
+
+	for _, target := range i.config.Targets {
+		component := fmt.Sprintf("stream:%s/%s", target.StreamKey, target.ConsumerGroup)
+
+		stats, err := i.Inspect(ctx, target)
+		if err != nil {
+			i.logger.Error("failed to inspect stream",
+				"stream_key", target.StreamKey,
+				"group", target.ConsumerGroup,
+				"error", err)
+			i.report(component, health.StatusDown, err.Error())
+			continue
+		}
+
+		if i.config.DeadConsumerAfter > 0 {
+			removed, err := i.RemoveDeadConsumers(ctx, target, i.config.DeadConsumerAfter)
+			if err != nil {
+				i.logger.Warn("failed to remove dead consumers",
+					"stream_key", target.StreamKey,
+					"group", target.ConsumerGroup,
+					"error", err)
+			}
+			stats.RemovedConsumers = removed
+		}
+
+		i.report(component, i.evaluate(stats), stats)
+		results = append(results, stats)
+	}
+
+	i.mu.Lock()
+	i.snapshot = results
+	i.mu.Unlock()
+}
+
+// Inspect reads XINFO STREAM/GROUPS/CONSUMERS and XPENDING for a single target
+func (i *Inspector) Inspect(ctx context.Context, target InspectTarget) (GroupStats, error) {
+	stats := GroupStats{
+		StreamKey:     target.StreamKey,
+		ConsumerGroup: target.ConsumerGroup,
+		InspectedAt:   time.Now(),
+	}
+
+	info, err := i.client.XInfoStream(ctx, target.StreamKey).Result()
+	if err != nil {
+		return stats, fmt.Errorf("XINFO STREAM failed: %w", err)
+	}
+	stats.StreamLength = info.Length
+	stats.LastGeneratedID = info.LastGeneratedID
+
+	groups, err := i.client.XInfoGroups(ctx, target.StreamKey).Result()
+	if err != nil {
+		return stats, fmt.Errorf("XINFO GROUPS failed: %w", err)
+	}
+
+	var group *redis.XInfoGroup
+	for idx := range groups {
+		if groups[idx].Name == target.ConsumerGroup {
+			group = &groups[idx]
+			break
+		}
+	}
+	if group == nil {
+		return stats, fmt.Errorf("consumer group '%s' not found on stream '%s'", target.ConsumerGroup, target.StreamKey)
+	}
+
+	stats.LastDeliveredID = group.LastDeliveredID
+	stats.Pending = group.Pending
+	stats.Lag = computeLag(*group, info)
+
+	consumers, err := i.client.XInfoConsumers(ctx, target.StreamKey, target.ConsumerGroup).Result()
+	if err != nil {
+		return stats, fmt.Errorf("XINFO CONSUMERS failed: %w", err)
+	}
+
+	stats.Consumers = make([]ConsumerStats, 0, len(consumers))
+	for _, c := range consumers {
+		stats.Consumers = append(stats.Consumers, ConsumerStats{
+			Name:    c.Name,
+			Pending: c.Pending,
+			Idle:    c.Idle,
+		})
+		if i.config.IdleConsumerAfter > 0 && c.Idle > i.config.IdleConsumerAfter {
+			stats.IdleConsumers++
+		}
+	}
+
+	if stats.Pending > 0 {
+		pending, err := i.client.XPending(ctx, target.StreamKey, target.ConsumerGroup).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return stats, fmt.Errorf("XPENDING failed: %w", err)
+		}
+		if pending != nil && pending.Lower != "" {
+			stats.OldestPendingID = pending.Lower
+			if t, err := IDTime(pending.Lower); err == nil {
+				stats.OldestPendingAge = time.Since(t)
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// RemoveDeadConsumers deletes consumers that have no pending messages and have
+// been idle for longer than minIdle. Consumers still owning pending messages are
+// left alone so that their entries can be claimed by live consumers first.
+func (i *Inspector) RemoveDeadConsumers(ctx context.Context, target InspectTarget, minIdle time.Duration) ([]string, error) {
+	consumers, err := i.client.XInfoConsumers(ctx, target.StreamKey, target.ConsumerGroup).Result()
+	if err != nil {
+		return nil, fmt.Errorf("XINFO CONSUMERS failed: %w", err)
+	}
+
+	var removed []string
+	for _, c := range consumers {
+		if c.Pending > 0 || c.Idle <= minIdle {
+			continue
+		}
+
+		if err := i.client.XGroupDelConsumer(ctx, target.StreamKey, target.ConsumerGroup, c.Name).Err(); err != nil {
+			return removed, fmt.Errorf("XGROUP DELCONSUMER failed for %s: %w", c.Name, err)
+		}
+
+		i.logger.Info("removed dead consumer",
+			"stream_key", target.StreamKey,
+			"group", target.ConsumerGroup,
+			"consumer", c.Name,
+			"idle", c.Idle)
+		removed = append(removed, c.Name)
+	}
+
+	return removed, nil
+}
+
+// evaluate maps group stats to a health status using the configured thresholds
+func (i *Inspector) evaluate(stats GroupStats) health.Status {
+	if i.config.MaxLag > 0 && stats.Lag > i.config.MaxLag {
+		return health.StatusDegraded
+	}
+	if i.config.MaxPendingAge > 0 && stats.OldestPendingAge > i.config.MaxPendingAge {
+		return health.StatusDegraded
+	}
+	return health.StatusUp
+}
+
+func (i *Inspector) report(component string, status health.Status, detail any) {
+	if i.registry == nil {
+		return
+	}
+	i.registry.Set(component, status, detail)
+}
+
+// computeLag returns the number of entries not yet delivered to the group.
+// Redis reports -1 when it cannot determine the lag (e.g. after XDEL); in that
+// case fall back to the entries counters, or 0 when the group is caught up.
+func computeLag(group redis.XInfoGroup, info *redis.XInfoStream) int64 {
+	if group.Lag >= 0 {
+		return group.Lag
+	}
+	if group.LastDeliveredID == info.LastGeneratedID {
+		return 0
+	}
+	if group.EntriesRead > 0 && info.EntriesAdded >= group.EntriesRead {
+		return info.EntriesAdded - group.EntriesRead
+	}
+	return -1
+}
+
+// ParseTargets parses a comma separated list of "<stream>=<group>" pairs
+func ParseTargets(s string) ([]InspectTarget, error) {
+	var targets []InspectTarget
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		stream, group, ok := strings.Cut(pair, "=")
+		if !ok || stream == "" || group == "" {
+			return nil, fmt.Errorf("invalid inspect target %q, expected <stream>=<group>", pair)
+		}
+		targets = append(targets, InspectTarget{StreamKey: stream, ConsumerGroup: group})
+	}
+	return targets, nil
+}
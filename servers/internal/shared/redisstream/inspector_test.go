@@ -0,0 +1,88 @@
+package redisstream
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestComputeLag(t *testing.T) {
+	info := &redis.XInfoStream{LastGeneratedID: "1-10", EntriesAdded: 10}
+	cases := []struct {
+		name  string
+		group redis.XInfoGroup
+		want  int64
+	}{
+		{"reported", redis.XInfoGroup{Lag: 3, LastDeliveredID: "1-7", EntriesRead: 7}, 3},
+		{"unknown and caught up", redis.XInfoGroup{Lag: -1, LastDeliveredID: "1-10"}, 0},
+		{"unknown with entries read", redis.XInfoGroup{Lag: -1, LastDeliveredID: "1-6", EntriesRead: 6}, 4},
+		{"unknown without entries read", redis.XInfoGroup{Lag: -1, LastDeliveredID: "1-6"}, -1},
+		{"unknown with more entries read than added", redis.XInfoGroup{Lag: -1, LastDeliveredID: "1-6", EntriesRead: 12}, -1},
+	}
+
+	for _, c := range cases {
+		if got := computeLag(c.group, info); got != c.want {
+			t.Errorf("%s: computeLag = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    []InspectTarget
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "stats:events=stats-consumers", want: []InspectTarget{{"stats:events", "stats-consumers"}}},
+		{in: " a=g1 , ,b=g2,", want: []InspectTarget{{"a", "g1"}, {"b", "g2"}}},
+		{in: "a", wantErr: true},
+		{in: "a=", wantErr: true},
+		{in: "=g", wantErr: true},
+		{in: "a=g1,b", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseTargets(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseTargets(%q) error = %v, want error %v", c.in, err, c.wantErr)
+			continue
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("ParseTargets(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestRemoveDeadConsumersKeepsPendingOnes(t *testing.T) {
+	f, client := newFakeRedis(t)
+	f.consumers["events/group"] = []redis.XInfoConsumer{
+		{Name: "dead", Idle: time.Hour},
+		{Name: "dead-with-pending", Pending: 2, Idle: time.Hour},
+		{Name: "live", Idle: time.Second},
+	}
+	target := InspectTarget{StreamKey: "events", ConsumerGroup: "group"}
+	inspector := NewInspector(slog.New(slog.DiscardHandler), client, nil, InspectorConfig{Targets: []InspectTarget{target}})
+
+	removed, err := inspector.RemoveDeadConsumers(context.Background(), target, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(removed, []string{"dead"}) {
+		t.Errorf("expected only the idle consumer without pending entries to be removed, got %v", removed)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var left []string
+	for _, c := range f.consumers["events/group"] {
+		left = append(left, c.Name)
+	}
+	if want := []string{"dead-with-pending", "live"}; !slices.Equal(left, want) {
+		t.Errorf("expected %v to be left in the group, got %v", want, left)
+	}
+}
@@ -0,0 +1,62 @@
+package redisstream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Publisher appends messages to Redis streams
+type Publisher struct {
+	client *redis.Client
+	// maxLen trims streams to about this many entries on every XADD; zero keeps every entry
+	maxLen int64
+}
+
+// NewPublisher creates a new Redis stream publisher that never trims streams
+func NewPublisher(redisClient *redis.Client) *Publisher {
+	return &Publisher{client: redisClient}
+}
+
+// NewCappedPublisher creates a Redis stream publisher that trims streams with
+// MAXLEN ~ maxLen. Trimming ignores consumer groups, so maxLen must leave room
+// for the largest expected lag. Zero or less keeps every entry.
+func NewCappedPublisher(redisClient *redis.Client, maxLen int64) *Publisher {
+	return &Publisher{client: redisClient, maxLen: max(maxLen, 0)}
+}
+
+func (p *Publisher) addArgs(streamKey string, values map[string]interface{}) *redis.XAddArgs {
+	return &redis.XAddArgs{
+		Stream: streamKey,
+		MaxLen: p.maxLen,
+		Approx: p.maxLen > 0,
+		ID:     "*",
+		Values: values,
+	}
+}
+
+// Publish adds a message to the stream and returns its ID
+func (p *Publisher) Publish(ctx context.Context, streamKey string, values map[string]interface{}) (string, error) {
+	id, err := p.client.XAdd(ctx, p.addArgs(streamKey, values)).Result()
+	if err != nil {
+		return "", fmt.Errorf("XADD to %s failed: %w", streamKey, err)
+	}
+	return id, nil
+}
+
+// PublishBatch adds all messages to the stream in a single pipeline
+func (p *Publisher) PublishBatch(ctx context.Context, streamKey string, batch []map[string]interface{}) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	pipe := p.client.Pipeline()
+	for _, values := range batch {
+		pipe.XAdd(ctx, p.addArgs(streamKey, values))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("XADD pipeline to %s failed: %w", streamKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package redisstream
+
+import "testing"
+
+func TestCappedPublisherTrimsApproximately(t *testing.T) {
+	args := NewCappedPublisher(nil, 1000).addArgs("s", nil)
+	if args.MaxLen != 1000 || !args.Approx {
+		t.Errorf("expected MAXLEN ~ 1000, got %+v", args)
+	}
+
+	args = NewPublisher(nil).addArgs("s", nil)
+	if args.MaxLen != 0 || args.Approx {
+		t.Errorf("expected no trimming, got %+v", args)
+	}
+}
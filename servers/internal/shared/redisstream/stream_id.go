@@ -0,0 +1,70 @@
+package redisstream
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseID splits a Redis stream entry ID ("<ms>-<seq>") into its parts.
+// An ID without a sequence part is treated as "<ms>-0".
+func ParseID(id string) (ms uint64, seq uint64, err error) {
+	msPart, seqPart, hasSeq := strings.Cut(id, "-")
+
+	ms, err = strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stream id %q: %w", id, err)
+	}
+
+	if hasSeq {
+		seq, err = strconv.ParseUint(seqPart, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid stream id %q: %w", id, err)
+		}
+	}
+
+	return ms, seq, nil
+}
+
+// IDTime returns the wall clock time encoded in a stream entry ID
+func IDTime(id string) (time.Time, error) {
+	ms, _, err := ParseID(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
+
+// IDFromTime builds the smallest stream entry ID at or after t
+func IDFromTime(t time.Time) string {
+	return fmt.Sprintf("%d-0", t.UnixMilli())
+}
+
+// CompareIDs compares two stream entry IDs and returns -1, 0 or 1.
+// Invalid IDs sort before valid ones.
+func CompareIDs(a, b string) int {
+	aMs, aSeq, aErr := ParseID(a)
+	bMs, bSeq, bErr := ParseID(b)
+
+	switch {
+	case aErr != nil && bErr != nil:
+		return 0
+	case aErr != nil:
+		return -1
+	case bErr != nil:
+		return 1
+	case aMs != bMs:
+		if aMs < bMs {
+			return -1
+		}
+		return 1
+	case aSeq != bSeq:
+		if aSeq < bSeq {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
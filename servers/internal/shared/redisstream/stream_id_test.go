@@ -0,0 +1,38 @@
+package redisstream
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareIDs(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1-0", "1-0", 0},
+		{"1-0", "1-1", -1},
+		{"2-0", "1-9", 1},
+		{"1700000000000-5", "1700000000001-0", -1},
+		{"10-0", "9-0", 1},
+		{"invalid", "1-0", -1},
+	}
+
+	for _, c := range cases {
+		if got := CompareIDs(c.a, c.b); got != c.want {
+			t.Errorf("CompareIDs(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestIDTimeRoundTrip(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+
+	got, err := IDTime(IDFromTime(now))
+	if err != nil {
+		t.Fatalf("IDTime returned error: %v", err)
+	}
+	if !got.Equal(now) {
+		t.Errorf("expected %v, got %v", now, got)
+	}
+}
@@ -0,0 +1,154 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
+)
+
+// State is the state of a circuit breaker
+type State string
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = "closed"
+	// StateOpen rejects calls until the cooldown has passed
+	StateOpen State = "open"
+	// StateHalfOpen lets a probe through to test whether the dependency recovered
+	StateHalfOpen State = "half_open"
+)
+
+// BreakerConfig configures a CircuitBreaker
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+	// Cooldown is how long the breaker stays open before allowing a probe
+	Cooldown time.Duration
+}
+
+// CircuitBreaker stops hammering a failing dependency and reports its state
+// to a health registry: closed is up, half open is degraded and open is down.
+type CircuitBreaker struct {
+	name     string
+	config   BreakerConfig
+	registry *health.Registry
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	lastErr  error
+}
+
+// breakerDetail is the health detail published for a breaker
+type breakerDetail struct {
+	State               State  `json:"state"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+}
+
+// NewCircuitBreaker creates a closed breaker. registry may be nil.
+func NewCircuitBreaker(name string, config BreakerConfig, registry *health.Registry) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+
+	b := &CircuitBreaker{
+		name:     name,
+		config:   config,
+		registry: registry,
+		now:      time.Now,
+		state:    StateClosed,
+	}
+	b.report()
+	return b
+}
+
+// Allow reports whether a call may proceed. An open breaker turns half open
+// once the cooldown has passed, letting the next call act as a probe.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.config.Cooldown {
+		b.state = StateHalfOpen
+		b.report()
+	}
+	return b.state != StateOpen
+}
+
+// Success records a successful call and closes the breaker
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	changed := b.state != StateClosed || b.failures > 0
+	b.state = StateClosed
+	b.failures = 0
+	b.lastErr = nil
+	if changed {
+		b.report()
+	}
+}
+
+// Failure records a failed call. A failed probe or reaching the threshold opens the breaker.
+func (b *CircuitBreaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastErr = err
+	if b.state == StateHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+	b.report()
+}
+
+// State returns the current state
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// RetryAfter returns how long an open breaker keeps rejecting calls
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateOpen {
+		return 0
+	}
+	return max(b.config.Cooldown-b.now().Sub(b.openedAt), 0)
+}
+
+// report must be called with b.mu held
+func (b *CircuitBreaker) report() {
+	if b.registry == nil {
+		return
+	}
+
+	detail := breakerDetail{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.lastErr != nil {
+		detail.LastError = b.lastErr.Error()
+	}
+
+	status := health.StatusUp
+	switch b.state {
+	case StateHalfOpen:
+		status = health.StatusDegraded
+	case StateOpen:
+		status = health.StatusDown
+	}
+	b.registry.Set(b.name, status, detail)
+}
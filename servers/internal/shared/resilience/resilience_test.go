@@ -0,0 +1,74 @@
+package resilience
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, w := range want {
+		if got := p.Backoff(i + 1); got != w {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, w, got)
+		}
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		got := p.Backoff(3)
+		if got < 200*time.Millisecond || got > 400*time.Millisecond {
+			t.Fatalf("jittered delay %v outside [200ms, 400ms]", got)
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	registry := health.NewRegistry()
+
+	b := NewCircuitBreaker("consumer", BreakerConfig{FailureThreshold: 2, Cooldown: time.Minute}, registry)
+	b.now = func() time.Time { return now }
+
+	errDown := errors.New("connection refused")
+	b.Failure(errDown)
+	if !b.Allow() || b.State() != StateClosed {
+		t.Fatalf("expected breaker to stay closed below the threshold, got %s", b.State())
+	}
+
+	b.Failure(errDown)
+	if b.Allow() || b.State() != StateOpen {
+		t.Fatalf("expected breaker to open, got %s", b.State())
+	}
+	if registry.Overall() != health.StatusDown {
+		t.Errorf("expected open breaker to report down, got %s", registry.Overall())
+	}
+
+	now = now.Add(time.Minute)
+	if !b.Allow() || b.State() != StateHalfOpen {
+		t.Fatalf("expected breaker to turn half open after cooldown, got %s", b.State())
+	}
+
+	b.Failure(errDown)
+	if b.State() != StateOpen || b.RetryAfter() != time.Minute {
+		t.Fatalf("expected failed probe to reopen breaker, got %s", b.State())
+	}
+
+	now = now.Add(time.Minute)
+	b.Allow()
+	b.Success()
+	if b.State() != StateClosed || registry.Overall() != health.StatusUp {
+		t.Fatalf("expected breaker to close after a successful probe, got %s", b.State())
+	}
+}
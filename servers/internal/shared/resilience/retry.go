@@ -0,0 +1,69 @@
+package resilience
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy computes exponential backoff delays with jitter
+type RetryPolicy struct {
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between retries
+	MaxDelay time.Duration
+	// Multiplier grows the delay after each failed attempt
+	Multiplier float64
+	// Jitter is the fraction of the delay that is randomized, between 0 and 1
+	Jitter float64
+}
+
+// DefaultRetryPolicy doubles the delay from initial up to one minute with 20% jitter
+func DefaultRetryPolicy(initial time.Duration) RetryPolicy {
+	return RetryPolicy{
+		InitialDelay: initial,
+		MaxDelay:     time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// Backoff returns the delay before retry number attempt, starting at 1
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = time.Second
+	}
+	if p.MaxDelay < p.InitialDelay {
+		p.MaxDelay = p.InitialDelay
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 1
+	}
+	attempt = max(attempt, 1)
+
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	delay = math.Min(delay, float64(p.MaxDelay))
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		// spread the delay uniformly over [delay*(1-jitter), delay]
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+// Sleep waits for d and reports false when ctx or stop ended the wait first
+func Sleep(ctx context.Context, stop <-chan struct{}, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-stop:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,221 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// State is the lifecycle of an alert
+type State string
+
+const (
+	// StatePending means the condition holds but not yet for the rule's For duration
+	StatePending  State = "pending"
+	StateFiring   State = "firing"
+	StateResolved State = "resolved"
+)
+
+// Alert is the state of a rule for one target
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Target    string    `json:"target,omitempty"`
+	Metric    Metric    `json:"metric"`
+	Severity  string    `json:"severity"`
+	State     State     `json:"state"`
+	Value     float64   `json:"value"`
+	Op        Op        `json:"op"`
+	Threshold float64   `json:"threshold"`
+	Summary   string    `json:"summary"`
+	Since     time.Time `json:"since"`
+	FiredAt   time.Time `json:"fired_at,omitzero"`
+	// ResolvedAt is set on resolved notifications
+	ResolvedAt time.Time `json:"resolved_at,omitzero"`
+}
+
+// alertKey identifies an alert
+type alertKey struct {
+	rule   string
+	target string
+}
+
+// alertState tracks an alert between evaluations
+type alertState struct {
+	alert      Alert
+	notifiedAt time.Time
+}
+
+// Engine evaluates rules periodically and notifies on state changes.
+//
+// An alert fires once its condition held for the rule's For duration and is
+// re-sent only after RepeatInterval, so each transition is notified once.
+// It resolves as soon as the condition or its sample is gone.
+type Engine struct {
+	logger    *slog.Logger
+	source    Source
+	rules     []Rule
+	notifiers map[string]Notifier
+
+	mu     sync.RWMutex
+	states map[alertKey]*alertState
+}
+
+// NewEngine creates an engine notifying named notifiers selected by each rule
+func NewEngine(logger *slog.Logger, source Source, rules []Rule, notifiers map[string]Notifier) (*Engine, error) {
+	for _, rule := range rules {
+		for _, name := range rule.Notify {
+			if _, ok := notifiers[name]; !ok {
+				return nil, fmt.Errorf("alert rule %q uses unknown notifier %q", rule.Name, name)
+			}
+		}
+	}
+
+	return &Engine{
+		logger:    logger,
+		source:    source,
+		rules:     rules,
+		notifiers: notifiers,
+		states:    make(map[alertKey]*alertState),
+	}, nil
+}
+
+// Run evaluates the rules every interval until ctx is done
+func (e *Engine) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.Evaluate(ctx, now)
+		}
+	}
+}
+
+// Evaluate evaluates every rule at now
+func (e *Engine) Evaluate(ctx context.Context, now time.Time) {
+	for _, rule := range e.rules {
+		samples, err := e.source.Samples(ctx, rule, now)
+		if err != nil {
+			// Keep the current states rather than resolving on a failed query
+			e.logger.Warn("failed to evaluate alert rule", "rule", rule.Name, "error", err)
+			continue
+		}
+		e.apply(ctx, rule, samples, now)
+	}
+}
+
+func (e *Engine) apply(ctx context.Context, rule Rule, samples []Sample, now time.Time) {
+	var notify []Alert
+
+	e.mu.Lock()
+	seen := make(map[alertKey]bool, len(samples))
+	for _, sample := range samples {
+		key := alertKey{rule: rule.Name, target: sample.Target}
+		if !rule.Op.Holds(sample.Value, rule.Threshold) {
+			continue
+		}
+		seen[key] = true
+
+		st, ok := e.states[key]
+		if !ok {
+			st = &alertState{alert: Alert{
+				Rule:      rule.Name,
+				Target:    sample.Target,
+				Metric:    rule.Metric,
+				Severity:  rule.Severity,
+				State:     StatePending,
+				Op:        rule.Op,
+				Threshold: rule.Threshold,
+				Since:     now,
+			}}
+			e.states[key] = st
+		}
+		st.alert.Value = sample.Value
+		st.alert.Summary = summary(rule, sample)
+
+		switch {
+		case st.alert.State == StatePending && now.Sub(st.alert.Since) >= time.Duration(rule.For):
+			st.alert.State = StateFiring
+			st.alert.FiredAt = now
+		case st.alert.State == StateFiring && rule.RepeatInterval > 0 && now.Sub(st.notifiedAt) >= time.Duration(rule.RepeatInterval):
+		default:
+			continue
+		}
+		st.notifiedAt = now
+		notify = append(notify, st.alert)
+	}
+
+	for key, st := range e.states {
+		if key.rule != rule.Name || seen[key] {
+			continue
+		}
+		delete(e.states, key)
+		// Pending alerts never notified, so they resolve silently
+		if st.alert.State == StateFiring {
+			st.alert.State = StateResolved
+			st.alert.ResolvedAt = now
+			notify = append(notify, st.alert)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, alert := range notify {
+		e.notify(ctx, rule, alert)
+	}
+}
+
+func (e *Engine) notify(ctx context.Context, rule Rule, alert Alert) {
+	names := rule.Notify
+	if len(names) == 0 {
+		for name := range e.notifiers {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if err := e.notifiers[name].Notify(ctx, alert); err != nil {
+			e.logger.Error("failed to send alert notification",
+				"rule", alert.Rule,
+				"target", alert.Target,
+				"notifier", name,
+				"error", err)
+		}
+	}
+}
+
+// Active returns pending and firing alerts ordered by rule and target
+func (e *Engine) Active() []Alert {
+	e.mu.RLock()
+	alerts := make([]Alert, 0, len(e.states))
+	for _, st := range e.states {
+		alerts = append(alerts, st.alert)
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Rule != alerts[j].Rule {
+			return alerts[i].Rule < alerts[j].Rule
+		}
+		return alerts[i].Target < alerts[j].Target
+	})
+	return alerts
+}
+
+// Rules returns the loaded rules
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+func summary(rule Rule, sample Sample) string {
+	subject := string(rule.Metric)
+	if sample.Target != "" {
+		subject += " of " + sample.Target
+	}
+	return fmt.Sprintf("%s is %g (%s %g over %s)", subject, sample.Value, rule.Op, rule.Threshold, time.Duration(rule.Window))
+}
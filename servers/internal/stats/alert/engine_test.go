@@ -0,0 +1,98 @@
+package alert
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	samples []Sample
+}
+
+func (s *fakeSource) Samples(context.Context, Rule, time.Time) ([]Sample, error) {
+	return s.samples, nil
+}
+
+type recordingNotifier struct {
+	alerts []Alert
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, alert Alert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func TestEngineLifecycle(t *testing.T) {
+	ctx := context.Background()
+	rules, err := ParseRules([]byte(`
+rules:
+  - name: slow-endpoints
+    metric: latency_p99
+    dimension: endpoint
+    threshold: 500
+    for: 1m
+    repeat_interval: 10m
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := &fakeSource{}
+	notifier := &recordingNotifier{}
+	engine, err := NewEngine(slog.New(slog.DiscardHandler), source, rules, map[string]Notifier{"test": notifier})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC)
+	step := func(offset time.Duration, samples ...Sample) {
+		source.samples = samples
+		engine.Evaluate(ctx, start.Add(offset))
+	}
+
+	step(0, Sample{Target: "GET /v1/items", Value: 800}, Sample{Target: "GET /v1/users", Value: 100})
+	if active := engine.Active(); len(active) != 1 || active[0].State != StatePending {
+		t.Fatalf("expected one pending alert, got %+v", active)
+	}
+
+	step(time.Minute, Sample{Target: "GET /v1/items", Value: 900})
+	step(2*time.Minute, Sample{Target: "GET /v1/items", Value: 900})
+	if len(notifier.alerts) != 1 || notifier.alerts[0].State != StateFiring || notifier.alerts[0].Target != "GET /v1/items" {
+		t.Fatalf("expected a single firing notification, got %+v", notifier.alerts)
+	}
+
+	step(12*time.Minute, Sample{Target: "GET /v1/items", Value: 900})
+	if len(notifier.alerts) != 2 {
+		t.Fatalf("expected the firing alert to repeat, got %d notifications", len(notifier.alerts))
+	}
+
+	step(13*time.Minute, Sample{Target: "GET /v1/items", Value: 100})
+	if len(notifier.alerts) != 3 || notifier.alerts[2].State != StateResolved {
+		t.Fatalf("expected a resolved notification, got %+v", notifier.alerts)
+	}
+	if active := engine.Active(); len(active) != 0 {
+		t.Errorf("expected no active alerts, got %+v", active)
+	}
+}
+
+func TestParseRulesRejectsInvalidRules(t *testing.T) {
+	tests := map[string]string{
+		"unknown metric":    "rules:\n  - name: a\n    metric: cpu\n",
+		"duplicate name":    "rules:\n  - name: a\n    metric: event_count\n  - name: a\n    metric: event_count\n",
+		"value without dim": "rules:\n  - name: a\n    metric: error_rate\n    value: GET /\n",
+		"bad duration":      "rules:\n  - name: a\n    metric: error_rate\n    window: soon\n",
+	}
+	for name, data := range tests {
+		if _, err := ParseRules([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestExampleRulesAreValid(t *testing.T) {
+	if _, err := LoadRules("../../../cmd/stats/alert-rules.example.yaml"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,122 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+)
+
+// StreamKey is the stream alert notifications are published to
+const StreamKey = "stats:alerts"
+
+// Notifier delivers alert state changes
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// Compile-time checks to ensure the notifiers implement Notifier
+var (
+	_ Notifier = (*LogNotifier)(nil)
+	_ Notifier = (*WebhookNotifier)(nil)
+	_ Notifier = (*StreamNotifier)(nil)
+)
+
+// LogNotifier logs alerts
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier creates a notifier logging firing alerts at warn level and resolved ones at info level
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs the alert
+func (n *LogNotifier) Notify(ctx context.Context, alert Alert) error {
+	level := slog.LevelWarn
+	if alert.State == StateResolved {
+		level = slog.LevelInfo
+	}
+
+	n.logger.Log(ctx, level, "alert "+string(alert.State),
+		"rule", alert.Rule,
+		"target", alert.Target,
+		"severity", alert.Severity,
+		"value", alert.Value,
+		"threshold", alert.Threshold,
+		"summary", alert.Summary)
+	return nil
+}
+
+// WebhookNotifier posts alerts as JSON
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to url
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts the alert and fails on non-2xx responses
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post alert webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alert webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// StreamNotifier publishes alerts to a stream other services can consume
+type StreamNotifier struct {
+	publisher broker.Publisher
+	streamKey string
+}
+
+// NewStreamNotifier creates a notifier publishing to StreamKey
+func NewStreamNotifier(publisher broker.Publisher) *StreamNotifier {
+	return &StreamNotifier{publisher: publisher, streamKey: StreamKey}
+}
+
+// Notify publishes the alert with its rule and state as separate fields for filtering
+func (n *StreamNotifier) Notify(ctx context.Context, alert Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	if _, err := n.publisher.Publish(ctx, n.streamKey, map[string]interface{}{
+		"data":  string(data),
+		"rule":  alert.Rule,
+		"state": string(alert.State),
+	}); err != nil {
+		return fmt.Errorf("failed to publish alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+	"gopkg.in/yaml.v3"
+)
+
+// Metric is the value a rule is evaluated on
+type Metric string
+
+const (
+	// MetricErrorRate is the share of failed API calls or performance events (0..1)
+	MetricErrorRate Metric = "error_rate"
+	// MetricLatencyP50, P90 and P99 are latency percentiles in milliseconds
+	MetricLatencyP50 Metric = "latency_p50"
+	MetricLatencyP90 Metric = "latency_p90"
+	MetricLatencyP99 Metric = "latency_p99"
+	// MetricEventCount is the number of events in the window
+	MetricEventCount Metric = "event_count"
+	// MetricConsumerLag is the number of entries a consumer group has not read yet
+	MetricConsumerLag Metric = "consumer_lag"
+)
+
+// Op compares a metric with the threshold
+type Op string
+
+const (
+	OpGreater      Op = ">"
+	OpGreaterEqual Op = ">="
+	OpLess         Op = "<"
+	OpLessEqual    Op = "<="
+)
+
+// Holds reports whether value op threshold is true
+func (o Op) Holds(value, threshold float64) bool {
+	switch o {
+	case OpGreater:
+		return value > threshold
+	case OpGreaterEqual:
+		return value >= threshold
+	case OpLess:
+		return value < threshold
+	default:
+		return value <= threshold
+	}
+}
+
+// Duration reads Go duration strings such as "5m" from YAML
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", node.Value, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Rule is a declarative alert condition
+type Rule struct {
+	Name     string `yaml:"name" json:"name"`
+	Metric   Metric `yaml:"metric" json:"metric"`
+	Severity string `yaml:"severity" json:"severity,omitempty"`
+	// Dimension and Value scope error_rate, latency and event_count metrics.
+	// With a dimension and no value, every value is evaluated separately.
+	Dimension string `yaml:"dimension" json:"dimension,omitempty"`
+	Value     string `yaml:"value" json:"value,omitempty"`
+	// EventType scopes event_count
+	EventType stats.EventType `yaml:"event_type" json:"event_type,omitempty"`
+	// Stream scopes consumer_lag to a stream, or to a group with "stream/group"
+	Stream    string  `yaml:"stream" json:"stream,omitempty"`
+	Op        Op      `yaml:"op" json:"op,omitempty"`
+	Threshold float64 `yaml:"threshold" json:"threshold"`
+	// Window is the range metrics are computed over
+	Window Duration `yaml:"window" json:"window,omitempty"`
+	// For is how long the condition must hold before the alert fires
+	For Duration `yaml:"for" json:"for,omitempty"`
+	// MinCount ignores error_rate and latency samples with fewer observations
+	MinCount uint64 `yaml:"min_count" json:"min_count,omitempty"`
+	// RepeatInterval re-sends a firing alert; zero notifies once per transition
+	RepeatInterval Duration `yaml:"repeat_interval" json:"repeat_interval,omitempty"`
+	// Notify names the notifiers to use; empty uses all of them
+	Notify []string `yaml:"notify" json:"notify,omitempty"`
+}
+
+// Validate checks the rule and fills in defaults
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	switch r.Metric {
+	case MetricErrorRate, MetricLatencyP50, MetricLatencyP90, MetricLatencyP99:
+		switch r.Dimension {
+		case "", store.DimensionEndpoint, store.DimensionOperation:
+		default:
+			return fmt.Errorf("%s: dimension must be endpoint or operation for %s", r.Name, r.Metric)
+		}
+	case MetricEventCount:
+		switch r.Dimension {
+		case "", store.DimensionEndpoint, store.DimensionAction, store.DimensionOperation:
+		default:
+			return fmt.Errorf("%s: dimension must be endpoint, action or operation for %s", r.Name, r.Metric)
+		}
+	case MetricConsumerLag:
+		if r.Dimension != "" {
+			return fmt.Errorf("%s: %s has no dimensions", r.Name, r.Metric)
+		}
+	default:
+		return fmt.Errorf("%s: unknown metric %q", r.Name, r.Metric)
+	}
+	if r.Value != "" && r.Dimension == "" {
+		return fmt.Errorf("%s: value requires a dimension", r.Name)
+	}
+
+	switch r.Op {
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+	case "":
+		r.Op = OpGreater
+	default:
+		return fmt.Errorf("%s: unknown op %q", r.Name, r.Op)
+	}
+
+	if r.Window <= 0 {
+		r.Window = Duration(5 * time.Minute)
+	}
+	if r.Severity == "" {
+		r.Severity = "warning"
+	}
+	return nil
+}
+
+// rulesFile is the layout of the rules file
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules reads and validates the rules of a YAML file
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates YAML rules
+func ParseRules(data []byte) ([]Rule, error) {
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules: %w", err)
+	}
+
+	names := make(map[string]bool, len(file.Rules))
+	for i := range file.Rules {
+		rule := &file.Rules[i]
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid alert rule %d: %w", i, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate alert rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	return file.Rules, nil
+}
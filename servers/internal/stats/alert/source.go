@@ -0,0 +1,139 @@
+package alert
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// maxTargets bounds the dimension values a single rule evaluates
+const maxTargets = 1000
+
+// Sample is the value of a rule metric for one target
+type Sample struct {
+	// Target is the dimension value or stream/group; empty for overall metrics
+	Target string
+	Value  float64
+}
+
+// Source computes rule metrics
+type Source interface {
+	Samples(ctx context.Context, rule Rule, now time.Time) ([]Sample, error)
+}
+
+// Compile-time check to ensure StatsSource implements Source
+var _ Source = (*StatsSource)(nil)
+
+// StatsSource reads metrics from the rollups and consumer lag from stream inspectors
+type StatsSource struct {
+	querier store.Querier
+	// streams returns the latest consumer group stats; nil disables consumer_lag
+	streams func() []redisstream.GroupStats
+}
+
+// NewStatsSource creates a source over querier and the group stats returned by streams
+func NewStatsSource(querier store.Querier, streams func() []redisstream.GroupStats) *StatsSource {
+	return &StatsSource{querier: querier, streams: streams}
+}
+
+// Samples computes the rule metric over its window ending at now
+func (s *StatsSource) Samples(ctx context.Context, rule Rule, now time.Time) ([]Sample, error) {
+	from := now.Add(-time.Duration(rule.Window))
+	q := store.Query{
+		From:        from,
+		To:          now,
+		Granularity: store.AutoGranularity(from, now),
+		EventType:   rule.EventType,
+		Value:       rule.Value,
+		Limit:       maxTargets,
+	}
+
+	switch rule.Metric {
+	case MetricEventCount:
+		return s.eventCounts(ctx, rule, q)
+	case MetricConsumerLag:
+		return s.consumerLag(rule), nil
+	default:
+		return s.latency(ctx, rule, q)
+	}
+}
+
+func (s *StatsSource) eventCounts(ctx context.Context, rule Rule, q store.Query) ([]Sample, error) {
+	if rule.Dimension == "" {
+		points, err := s.querier.EventCounts(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		var total int64
+		for _, p := range points {
+			total += p.Count
+		}
+		return []Sample{{Value: float64(total)}}, nil
+	}
+
+	values, err := s.querier.TopValues(ctx, q, rule.Dimension)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]Sample, 0, len(values))
+	for _, v := range values {
+		if rule.Value == "" || v.Value == rule.Value {
+			samples = append(samples, Sample{Target: v.Value, Value: float64(v.Count)})
+		}
+	}
+	// A missing value counted zero events, which matters for "<" rules
+	if rule.Value != "" && len(samples) == 0 {
+		samples = append(samples, Sample{Target: rule.Value})
+	}
+	return samples, nil
+}
+
+func (s *StatsSource) latency(ctx context.Context, rule Rule, q store.Query) ([]Sample, error) {
+	values, err := s.querier.Latency(ctx, q, rule.Dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]Sample, 0, len(values))
+	for _, v := range values {
+		if v.Count < max(rule.MinCount, 1) {
+			continue
+		}
+
+		sample := Sample{Target: v.Value}
+		switch rule.Metric {
+		case MetricErrorRate:
+			sample.Value = v.ErrorRate
+		case MetricLatencyP50:
+			sample.Value = v.P50Ms
+		case MetricLatencyP90:
+			sample.Value = v.P90Ms
+		case MetricLatencyP99:
+			sample.Value = v.P99Ms
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func (s *StatsSource) consumerLag(rule Rule) []Sample {
+	if s.streams == nil {
+		return nil
+	}
+
+	stream, group, _ := strings.Cut(rule.Stream, "/")
+	var samples []Sample
+	for _, g := range s.streams() {
+		if (stream != "" && g.StreamKey != stream) || (group != "" && g.ConsumerGroup != group) {
+			continue
+		}
+		samples = append(samples, Sample{
+			Target: g.StreamKey + "/" + g.ConsumerGroup,
+			Value:  float64(g.Lag),
+		})
+	}
+	return samples
+}
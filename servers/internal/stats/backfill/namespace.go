@@ -0,0 +1,158 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NamespacePrefix starts the name of every namespace, so a backfill can never
+// replace a schema of the application or of Supabase
+const NamespacePrefix = "stats_backfill_"
+
+// namespaceName restricts namespaces to plain lowercase identifiers with the prefix
+var namespaceName = regexp.MustCompile(`^` + NamespacePrefix + `[a-z0-9_]{1,48}$`)
+
+// namespaceMarker is the schema comment identifying namespaces created by OpenNamespace.
+// Only schemas carrying it are ever dropped.
+const namespaceMarker = "stats backfill namespace"
+
+// namespaceTables are recomputed by a backfill. processed_events holds the
+// claims of the counted events so the live consumer skips them after the swap.
+var namespaceTables = []string{"stats_rollups", "stats_latency_buckets", "processed_events"}
+
+// Namespace is a Postgres schema holding empty copies of the rollup tables.
+// A store on its Pool writes there instead of the live tables.
+type Namespace struct {
+	name  string
+	live  *pgxpool.Pool
+	pool  *pgxpool.Pool
+	ident string
+}
+
+// ValidateNamespace checks that name is a namespace OpenNamespace may create
+func ValidateNamespace(name string) error {
+	if !namespaceName.MatchString(name) {
+		return fmt.Errorf("invalid namespace %q: expected %s followed by lowercase letters, digits or underscores", name, NamespacePrefix)
+	}
+	return nil
+}
+
+// OpenNamespace creates the schema name with fresh rollup tables, replacing
+// leftovers of a previous backfill, and a pool whose search_path points to it.
+// An existing schema that was not created by OpenNamespace is left untouched.
+func OpenNamespace(ctx context.Context, live *pgxpool.Pool, name string) (*Namespace, error) {
+	if err := ValidateNamespace(name); err != nil {
+		return nil, err
+	}
+	ident := pgx.Identifier{name}.Sanitize()
+
+	if err := dropNamespace(ctx, live, name, ident); err != nil {
+		return nil, err
+	}
+	if _, err := live.Exec(ctx, "CREATE SCHEMA "+ident); err != nil {
+		return nil, fmt.Errorf("failed to create namespace: %w", err)
+	}
+	if _, err := live.Exec(ctx, "COMMENT ON SCHEMA "+ident+" IS '"+namespaceMarker+"'"); err != nil {
+		return nil, fmt.Errorf("failed to mark namespace: %w", err)
+	}
+	for _, table := range namespaceTables {
+		if _, err := live.Exec(ctx, fmt.Sprintf("CREATE TABLE %s.%s (LIKE public.%s INCLUDING ALL)", ident, table, table)); err != nil {
+			return nil, fmt.Errorf("failed to create %s in namespace: %w", table, err)
+		}
+	}
+
+	cfg := live.Config()
+	cfg.ConnConfig.RuntimeParams["search_path"] = name
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace pool: %w", err)
+	}
+
+	return &Namespace{name: name, live: live, pool: pool, ident: ident}, nil
+}
+
+// Pool returns a pool resolving the rollup tables to the namespace
+func (n *Namespace) Pool() *pgxpool.Pool {
+	return n.pool
+}
+
+// Swap replaces the live rollups of buckets in [from, to) with the
+// namespace's in a single transaction, so readers see either the old or
+// the new counts. Buckets outside the range keep their live counts.
+func (n *Namespace) Swap(ctx context.Context, from, to time.Time) (int64, error) {
+	tx, err := n.live.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statements := []string{
+		`DELETE FROM public.stats_rollups WHERE bucket_start >= $1 AND bucket_start < $2`,
+		`INSERT INTO public.stats_rollups (granularity, bucket_start, event_type, dimension, dimension_value, count, updated_at)
+		SELECT granularity, bucket_start, event_type, dimension, dimension_value, count, updated_at
+		FROM ` + n.ident + `.stats_rollups WHERE bucket_start >= $1 AND bucket_start < $2`,
+		`DELETE FROM public.stats_latency_buckets WHERE bucket_start >= $1 AND bucket_start < $2`,
+		`INSERT INTO public.stats_latency_buckets (granularity, bucket_start, dimension, dimension_value, histogram_bucket, count, errors, updated_at)
+		SELECT granularity, bucket_start, dimension, dimension_value, histogram_bucket, count, errors, updated_at
+		FROM ` + n.ident + `.stats_latency_buckets WHERE bucket_start >= $1 AND bucket_start < $2`,
+	}
+
+	var rows int64
+	for _, stmt := range statements {
+		tag, err := tx.Exec(ctx, stmt, from, to)
+		if err != nil {
+			return 0, fmt.Errorf("failed to swap rollups: %w", err)
+		}
+		if tag.Insert() {
+			rows += tag.RowsAffected()
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO public.processed_events (scope, event_id, processed_at, expires_at)
+		SELECT scope, event_id, processed_at, expires_at FROM `+n.ident+`.processed_events
+		ON CONFLICT DO NOTHING`); err != nil {
+		return 0, fmt.Errorf("failed to copy processed events: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit rollup swap: %w", err)
+	}
+	return rows, nil
+}
+
+// Close closes the namespace pool. drop also removes the schema.
+func (n *Namespace) Close(ctx context.Context, drop bool) error {
+	n.pool.Close()
+	if !drop {
+		return nil
+	}
+	return dropNamespace(ctx, n.live, n.name, n.ident)
+}
+
+// dropNamespace drops the schema name if it exists and carries the marker of
+// a namespace, and refuses to drop any other schema
+func dropNamespace(ctx context.Context, live *pgxpool.Pool, name, ident string) error {
+	var comment *string
+	err := live.QueryRow(ctx,
+		`SELECT obj_description(oid, 'pg_namespace') FROM pg_namespace WHERE nspname = $1`, name).Scan(&comment)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to inspect namespace: %w", err)
+	}
+	if comment == nil || *comment != namespaceMarker {
+		return fmt.Errorf("schema %q exists but was not created by a backfill; refusing to drop it", name)
+	}
+
+	if _, err := live.Exec(ctx, "DROP SCHEMA "+ident+" CASCADE"); err != nil {
+		return fmt.Errorf("failed to drop namespace: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package backfill
+
+import "testing"
+
+func TestValidateNamespace(t *testing.T) {
+	for _, name := range []string{"stats_backfill_default", "stats_backfill_2026_10"} {
+		if err := ValidateNamespace(name); err != nil {
+			t.Errorf("expected %q to be valid: %v", name, err)
+		}
+	}
+	for _, name := range []string{"public", "auth", "storage", "extensions", "stats_backfill", "stats_backfill_", "stats_backfill_X", "stats_backfill_a;drop"} {
+		if err := ValidateNamespace(name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
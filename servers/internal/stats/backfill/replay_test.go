@@ -0,0 +1,143 @@
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/consumer"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+func TestReplayArchiveWithinWindow(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// One event the day before, two inside the window, one without a timestamp
+	// dated by its stream ID, and an undecodable entry
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := json.NewEncoder(f)
+	for i, at := range []time.Time{day.Add(-time.Hour), day.Add(time.Hour), day.Add(2 * time.Hour), {}} {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = fmt.Sprintf("event-%d", i)
+		event.Timestamp = at
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := redisstream.IDFromTime(day.Add(time.Duration(i) * time.Hour))
+		if err := enc.Encode(map[string]any{"id": id, "values": map[string]any{"data": string(data)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Encode(map[string]any{"id": "1-0", "values": map[string]any{}}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	rollups := store.NewMemoryStore()
+	processor := consumer.NewEventProcessor(slog.New(slog.DiscardHandler), nil, rollups, nil, nil)
+	window := Window{From: day, To: day.Add(24 * time.Hour)}
+
+	res, err := Replay(ctx, NewArchiveSource(path), processor, rollups, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Read != 5 || res.Processed != 3 || res.Skipped != 1 || res.Invalid != 1 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	points, err := rollups.EventCounts(ctx, store.Query{From: window.From, To: window.To, Granularity: store.Day})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Count != 3 {
+		t.Fatalf("expected 3 events counted on %s, got %+v", day.Format(time.DateOnly), points)
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	w := Window{From: day, To: day.Add(24 * time.Hour)}
+
+	if !w.Contains(day) || w.Contains(day.Add(24*time.Hour)) || w.Contains(day.Add(-time.Nanosecond)) {
+		t.Fatal("window bounds should be [From, To)")
+	}
+	if !(Window{}).Contains(day) {
+		t.Fatal("zero window should be open")
+	}
+}
+
+// writeArchive writes one login event per stream ID time to an archive
+func writeArchive(t *testing.T, at ...time.Time) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for i, ts := range at {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = fmt.Sprintf("event-%d", i)
+		event.Timestamp = ts
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Encode(map[string]any{"id": redisstream.IDFromTime(ts), "values": map[string]any{"data": string(data)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestCheckCoverage(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	window := Window{From: day, To: day.Add(48 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		events  []time.Time
+		wantErr bool
+	}{
+		{"empty source", nil, true},
+		{"no event in the window", []time.Time{day.Add(-time.Hour)}, true},
+		{"trimmed past the window start", []time.Time{day.Add(30 * time.Hour)}, true},
+		{"covers the window", []time.Time{day.Add(-time.Hour), day.Add(30 * time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		source := NewArchiveSource(writeArchive(t, tt.events...))
+		rollups := store.NewMemoryStore()
+		processor := consumer.NewEventProcessor(slog.New(slog.DiscardHandler), nil, rollups, nil, nil)
+
+		res, err := Replay(ctx, source, processor, rollups, window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := CheckCoverage(ctx, source, window, res); (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package backfill
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+)
+
+// pageSize is the number of entries read per XRANGE call
+const pageSize = 1000
+
+// Source yields stream messages in order
+type Source interface {
+	Each(ctx context.Context, fn func(redis.XMessage) error) error
+
+	// Oldest returns the ID of the oldest entry the source holds, or "" when it is empty
+	Oldest(ctx context.Context) (string, error)
+}
+
+// Compile-time checks to ensure the sources implement Source
+var (
+	_ Source = (*StreamSource)(nil)
+	_ Source = (*ArchiveSource)(nil)
+)
+
+// StreamSource reads a stream with XRANGE, which leaves consumer groups untouched
+type StreamSource struct {
+	client    *redis.Client
+	admin     *redisstream.Admin
+	streamKey string
+	start     string
+}
+
+// NewStreamSource reads streamKey from start (inclusive) up to its last entry when reading begins
+func NewStreamSource(client *redis.Client, streamKey, start string) *StreamSource {
+	return &StreamSource{
+		client:    client,
+		admin:     redisstream.NewAdmin(client),
+		streamKey: streamKey,
+		start:     start,
+	}
+}
+
+// Each calls fn for every entry, page by page
+func (s *StreamSource) Each(ctx context.Context, fn func(redis.XMessage) error) error {
+	// Stop at the newest entry when reading begins, so the backfill ends while events keep arriving
+	end, err := s.lastID(ctx)
+	if err != nil || end == "" {
+		return err
+	}
+
+	start := s.start
+	for {
+		msgs, err := s.admin.Range(ctx, s.streamKey, start, end, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			if err := fn(msg); err != nil {
+				return err
+			}
+		}
+		if len(msgs) < pageSize {
+			return nil
+		}
+		start = "(" + msgs[len(msgs)-1].ID
+	}
+}
+
+// Oldest returns the ID of the oldest entry left in the stream, which is later
+// than the start when the stream was trimmed
+func (s *StreamSource) Oldest(ctx context.Context) (string, error) {
+	msgs, err := s.client.XRangeN(ctx, s.streamKey, "-", "+", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("XRANGE failed: %w", err)
+	}
+	if len(msgs) == 0 {
+		return "", nil
+	}
+	return msgs[0].ID, nil
+}
+
+// lastID returns the ID of the newest entry, or "" for an empty stream
+func (s *StreamSource) lastID(ctx context.Context) (string, error) {
+	msgs, err := s.client.XRevRangeN(ctx, s.streamKey, "+", "-", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("XREVRANGE failed: %w", err)
+	}
+	if len(msgs) == 0 {
+		return "", nil
+	}
+	return msgs[0].ID, nil
+}
+
+// ArchiveSource reads JSON lines of {"id": ..., "values": {...}}, as written by
+// "streamctl tail -n N <stream>"
+type ArchiveSource struct {
+	path string
+}
+
+// NewArchiveSource reads the archive at path
+func NewArchiveSource(path string) *ArchiveSource {
+	return &ArchiveSource{path: path}
+}
+
+// errStop ends Each early without an error
+var errStop = errors.New("stop")
+
+// Oldest returns the ID of the first line of the archive
+func (s *ArchiveSource) Oldest(ctx context.Context) (string, error) {
+	var oldest string
+	err := s.Each(ctx, func(msg redis.XMessage) error {
+		oldest = msg.ID
+		return errStop
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return "", err
+	}
+	return oldest, nil
+}
+
+// Each calls fn for every line of the archive
+func (s *ArchiveSource) Each(ctx context.Context, fn func(redis.XMessage) error) error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var entry struct {
+			ID     string         `json:"id"`
+			Values map[string]any `json:"values"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("invalid archive line %d: %w", line, err)
+		}
+		if err := fn(redis.XMessage{ID: entry.ID, Values: entry.Values}); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package behavior
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFunnelWindow is used for funnels without a window
+const DefaultFunnelWindow = 24 * time.Hour
+
+// Funnel is an ordered list of user actions that count as converted when
+// completed within Window of the first one
+type Funnel struct {
+	Name   string        `yaml:"name"`
+	Steps  []string      `yaml:"steps"`
+	Window time.Duration `yaml:"window"`
+}
+
+// Validate checks the funnel and fills in defaults
+func (f *Funnel) Validate() error {
+	if f.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(f.Steps) < 2 {
+		return fmt.Errorf("%s: at least two steps are required", f.Name)
+	}
+	for i, step := range f.Steps {
+		if step == "" {
+			return fmt.Errorf("%s: step %d is empty", f.Name, i)
+		}
+	}
+	if f.Window < 0 {
+		return fmt.Errorf("%s: window must be positive", f.Name)
+	}
+	if f.Window == 0 {
+		f.Window = DefaultFunnelWindow
+	}
+	return nil
+}
+
+// funnelsFile is the layout of the funnels file
+type funnelsFile struct {
+	Funnels []Funnel `yaml:"funnels"`
+}
+
+// LoadFunnels reads and validates the funnels of a YAML file
+func LoadFunnels(path string) ([]Funnel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read funnels: %w", err)
+	}
+	return ParseFunnels(data)
+}
+
+// ParseFunnels parses and validates YAML funnels
+func ParseFunnels(data []byte) ([]Funnel, error) {
+	var file funnelsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse funnels: %w", err)
+	}
+
+	names := make(map[string]bool, len(file.Funnels))
+	for i := range file.Funnels {
+		funnel := &file.Funnels[i]
+		if err := funnel.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid funnel %d: %w", i, err)
+		}
+		if names[funnel.Name] {
+			return nil, fmt.Errorf("duplicate funnel %q", funnel.Name)
+		}
+		names[funnel.Name] = true
+	}
+	return file.Funnels, nil
+}
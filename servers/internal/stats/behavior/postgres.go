@@ -0,0 +1,117 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/sqlc/postgres"
+)
+
+// Compile-time check to ensure PostgresStore implements Store
+var _ Store = (*PostgresStore)(nil)
+
+// PostgresStore keeps cohorts in stats_user_activity, stats_retention and stats_funnel_steps
+type PostgresStore struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewPostgresStore creates a store on pool
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+// RecordActivity implements Store. The activity update and the retention
+// count run in one transaction so a user is counted at most once per day.
+func (s *PostgresStore) RecordActivity(ctx context.Context, userID string, day time.Time) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.queries.WithTx(tx)
+	// No row is returned when the user was already active on day
+	firstSeen, err := q.TouchStatsUserActivity(ctx, sqlc.TouchStatsUserActivityParams{
+		UserID: userID,
+		Day:    date(day),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to touch user activity: %w", err)
+	}
+
+	if err := q.IncrementStatsRetention(ctx, sqlc.IncrementStatsRetentionParams{
+		CohortDate: firstSeen,
+		DayOffset:  int32(daysBetween(firstSeen.Time, day)),
+	}); err != nil {
+		return fmt.Errorf("failed to increment retention: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit user activity: %w", err)
+	}
+	return nil
+}
+
+// RecordFunnelStep implements Store
+func (s *PostgresStore) RecordFunnelStep(ctx context.Context, funnel string, cohort time.Time, step int) error {
+	if err := s.queries.IncrementStatsFunnelStep(ctx, sqlc.IncrementStatsFunnelStepParams{
+		Funnel:     funnel,
+		CohortDate: date(cohort),
+		Step:       int32(step),
+	}); err != nil {
+		return fmt.Errorf("failed to increment funnel step: %w", err)
+	}
+	return nil
+}
+
+// FunnelSteps implements Store
+func (s *PostgresStore) FunnelSteps(ctx context.Context, funnel string, from, to time.Time) ([]StepCount, error) {
+	rows, err := s.queries.ListStatsFunnelSteps(ctx, sqlc.ListStatsFunnelStepsParams{
+		Funnel:   funnel,
+		FromDate: date(from),
+		ToDate:   date(to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list funnel steps: %w", err)
+	}
+
+	counts := make([]StepCount, len(rows))
+	for i, row := range rows {
+		counts[i] = StepCount{Cohort: row.CohortDate.Time, Step: int(row.Step), Users: row.Users}
+	}
+	return counts, nil
+}
+
+// Retention implements Store
+func (s *PostgresStore) Retention(ctx context.Context, from, to time.Time, maxOffset int) ([]RetentionCount, error) {
+	rows, err := s.queries.ListStatsRetention(ctx, sqlc.ListStatsRetentionParams{
+		FromDate:  date(from),
+		ToDate:    date(to),
+		MaxOffset: int32(maxOffset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention: %w", err)
+	}
+
+	counts := make([]RetentionCount, len(rows))
+	for i, row := range rows {
+		counts[i] = RetentionCount{Cohort: row.CohortDate.Time, Offset: int(row.DayOffset), Users: row.Users}
+	}
+	return counts, nil
+}
+
+func date(day time.Time) pgtype.Date {
+	return pgtype.Date{Time: day, Valid: true}
+}
@@ -0,0 +1,210 @@
+package behavior
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists retention cohorts and funnel conversions. Days are UTC midnights.
+type Store interface {
+	// RecordActivity marks userID active on day. The first activity of a day
+	// counts the user in its first-seen cohort at the offset of day.
+	RecordActivity(ctx context.Context, userID string, day time.Time) error
+	// RecordFunnelStep counts a user reaching step of funnel, by the day it entered the funnel
+	RecordFunnelStep(ctx context.Context, funnel string, cohort time.Time, step int) error
+	// FunnelSteps returns the step counts of funnel for cohorts in [from, to)
+	FunnelSteps(ctx context.Context, funnel string, from, to time.Time) ([]StepCount, error)
+	// Retention returns the counts of cohorts in [from, to) up to maxOffset days after first seen
+	Retention(ctx context.Context, from, to time.Time, maxOffset int) ([]RetentionCount, error)
+}
+
+// StepCount is the number of users of a cohort reaching a funnel step
+type StepCount struct {
+	Cohort time.Time `json:"cohort"`
+	Step   int       `json:"step"`
+	Users  int64     `json:"users"`
+}
+
+// RetentionCount is the number of users of a cohort active Offset days after first seen
+type RetentionCount struct {
+	Cohort time.Time `json:"cohort"`
+	Offset int       `json:"offset"`
+	Users  int64     `json:"users"`
+}
+
+// Compile-time check to ensure MemoryStore implements Store
+var _ Store = (*MemoryStore)(nil)
+
+type activity struct {
+	firstSeen  time.Time
+	lastActive time.Time
+}
+
+type stepKey struct {
+	funnel string
+	cohort time.Time
+	step   int
+}
+
+type retentionKey struct {
+	cohort time.Time
+	offset int
+}
+
+// MemoryStore keeps cohorts in memory; they are lost on restart
+type MemoryStore struct {
+	mu        sync.Mutex
+	users     map[string]activity
+	retention map[retentionKey]int64
+	steps     map[stepKey]int64
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:     make(map[string]activity),
+		retention: make(map[retentionKey]int64),
+		steps:     make(map[stepKey]int64),
+	}
+}
+
+// RecordActivity implements Store
+func (s *MemoryStore) RecordActivity(ctx context.Context, userID string, day time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.users[userID]
+	if !ok {
+		a = activity{firstSeen: day}
+	} else if !a.lastActive.Before(day) {
+		return nil
+	}
+	a.lastActive = day
+	s.users[userID] = a
+
+	s.retention[retentionKey{cohort: a.firstSeen, offset: daysBetween(a.firstSeen, day)}]++
+	return nil
+}
+
+// RecordFunnelStep implements Store
+func (s *MemoryStore) RecordFunnelStep(ctx context.Context, funnel string, cohort time.Time, step int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.steps[stepKey{funnel: funnel, cohort: cohort, step: step}]++
+	return nil
+}
+
+// FunnelSteps implements Store
+func (s *MemoryStore) FunnelSteps(ctx context.Context, funnel string, from, to time.Time) ([]StepCount, error) {
+	s.mu.Lock()
+	var counts []StepCount
+	for key, users := range s.steps {
+		if key.funnel == funnel && !key.cohort.Before(from) && key.cohort.Before(to) {
+			counts = append(counts, StepCount{Cohort: key.cohort, Step: key.step, Users: users})
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if !counts[i].Cohort.Equal(counts[j].Cohort) {
+			return counts[i].Cohort.Before(counts[j].Cohort)
+		}
+		return counts[i].Step < counts[j].Step
+	})
+	return counts, nil
+}
+
+// Retention implements Store
+func (s *MemoryStore) Retention(ctx context.Context, from, to time.Time, maxOffset int) ([]RetentionCount, error) {
+	s.mu.Lock()
+	var counts []RetentionCount
+	for key, users := range s.retention {
+		if !key.cohort.Before(from) && key.cohort.Before(to) && key.offset <= maxOffset {
+			counts = append(counts, RetentionCount{Cohort: key.cohort, Offset: key.offset, Users: users})
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if !counts[i].Cohort.Equal(counts[j].Cohort) {
+			return counts[i].Cohort.Before(counts[j].Cohort)
+		}
+		return counts[i].Offset < counts[j].Offset
+	})
+	return counts, nil
+}
+
+// daysBetween returns the number of whole days from from to to
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+// MemoryState is the content of a MemoryStore, used for snapshots
+type MemoryState struct {
+	Users     []UserActivity   `json:"users"`
+	Retention []RetentionCount `json:"retention"`
+	Funnels   []FunnelCount    `json:"funnels"`
+}
+
+// UserActivity is the first-seen and last active day of a user
+type UserActivity struct {
+	UserID     string    `json:"user_id"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastActive time.Time `json:"last_active"`
+}
+
+// FunnelCount is a StepCount of a funnel
+type FunnelCount struct {
+	Funnel string `json:"funnel"`
+	StepCount
+}
+
+// Export copies the content of the store
+func (s *MemoryStore) Export() MemoryState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := MemoryState{
+		Users:     make([]UserActivity, 0, len(s.users)),
+		Retention: make([]RetentionCount, 0, len(s.retention)),
+		Funnels:   make([]FunnelCount, 0, len(s.steps)),
+	}
+	for userID, a := range s.users {
+		state.Users = append(state.Users, UserActivity{UserID: userID, FirstSeen: a.firstSeen, LastActive: a.lastActive})
+	}
+	for key, users := range s.retention {
+		state.Retention = append(state.Retention, RetentionCount{Cohort: key.cohort, Offset: key.offset, Users: users})
+	}
+	for key, users := range s.steps {
+		state.Funnels = append(state.Funnels, FunnelCount{
+			Funnel:    key.funnel,
+			StepCount: StepCount{Cohort: key.cohort, Step: key.step, Users: users},
+		})
+	}
+	return state
+}
+
+// Import replaces the content of the store with state
+func (s *MemoryStore) Import(state MemoryState) {
+	users := make(map[string]activity, len(state.Users))
+	for _, u := range state.Users {
+		users[u.UserID] = activity{firstSeen: u.FirstSeen.UTC(), lastActive: u.LastActive.UTC()}
+	}
+	retention := make(map[retentionKey]int64, len(state.Retention))
+	for _, r := range state.Retention {
+		retention[retentionKey{cohort: r.Cohort.UTC(), offset: r.Offset}] = r.Users
+	}
+	steps := make(map[stepKey]int64, len(state.Funnels))
+	for _, f := range state.Funnels {
+		steps[stepKey{funnel: f.Funnel, cohort: f.Cohort.UTC(), step: f.Step}] = f.Users
+	}
+
+	s.mu.Lock()
+	s.users = users
+	s.retention = retention
+	s.steps = steps
+	s.mu.Unlock()
+}
@@ -0,0 +1,266 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// DateLayout formats cohort days
+const DateLayout = time.DateOnly
+
+// ErrUnknownFunnel is returned for funnels that are not configured
+var ErrUnknownFunnel = errors.New("unknown funnel")
+
+// Querier reads funnel and retention reports
+type Querier interface {
+	Funnels() []Funnel
+	Funnel(ctx context.Context, name string, from, to time.Time) (FunnelReport, error)
+	Retention(ctx context.Context, from, to time.Time, days int) (RetentionReport, error)
+}
+
+// Compile-time check to ensure Tracker implements Querier
+var _ Querier = (*Tracker)(nil)
+
+// progress is a user's attempt at a funnel
+type progress struct {
+	cohort  time.Time
+	started time.Time
+	step    int
+	done    bool
+}
+
+// Tracker turns user actions into funnel conversions and retention cohorts.
+//
+// A user enters a funnel with its first step and advances on the next step
+// only, within the funnel window. Users enter a funnel at most once per day,
+// so counts are distinct users per cohort day. Attempts in progress are kept
+// in memory and lost on restart; completed steps are in the store.
+type Tracker struct {
+	logger  *slog.Logger
+	store   Store
+	funnels []Funnel
+
+	mu       sync.Mutex
+	progress map[string]map[string]*progress
+	prunedAt time.Time
+}
+
+// NewTracker creates a tracker of funnels writing to s
+func NewTracker(logger *slog.Logger, s Store, funnels []Funnel) *Tracker {
+	attempts := make(map[string]map[string]*progress, len(funnels))
+	for _, f := range funnels {
+		attempts[f.Name] = make(map[string]*progress)
+	}
+
+	return &Tracker{
+		logger:   logger,
+		store:    s,
+		funnels:  funnels,
+		progress: attempts,
+	}
+}
+
+// Track records a user action at the given time
+func (t *Tracker) Track(ctx context.Context, userID, action string, at time.Time) error {
+	day := store.Day.Truncate(at)
+
+	type step struct {
+		funnel string
+		cohort time.Time
+		index  int
+	}
+	var steps []step
+
+	t.mu.Lock()
+	if day.After(t.prunedAt) {
+		t.prune(at)
+		t.prunedAt = day
+	}
+	for _, f := range t.funnels {
+		users := t.progress[f.Name]
+		p := users[userID]
+
+		switch {
+		case p != nil && !p.done && at.Sub(p.started) <= f.Window && f.Steps[p.step+1] == action:
+			p.step++
+			p.done = p.step == len(f.Steps)-1
+			steps = append(steps, step{funnel: f.Name, cohort: p.cohort, index: p.step})
+		case action == f.Steps[0] && (p == nil || (p.cohort.Before(day) && (p.done || at.Sub(p.started) > f.Window))):
+			users[userID] = &progress{cohort: day, started: at}
+			steps = append(steps, step{funnel: f.Name, cohort: day, index: 0})
+		}
+	}
+	t.mu.Unlock()
+
+	var errs []error
+	if err := t.store.RecordActivity(ctx, userID, day); err != nil {
+		errs = append(errs, err)
+	}
+	for _, s := range steps {
+		if err := t.store.RecordFunnelStep(ctx, s.funnel, s.cohort, s.index); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// prune drops finished and expired attempts of previous days. Must be called with t.mu held.
+func (t *Tracker) prune(now time.Time) {
+	today := store.Day.Truncate(now)
+	for _, f := range t.funnels {
+		for userID, p := range t.progress[f.Name] {
+			if p.cohort.Before(today) && (p.done || now.Sub(p.started) > f.Window) {
+				delete(t.progress[f.Name], userID)
+			}
+		}
+	}
+}
+
+// Store returns the store the tracker writes to
+func (t *Tracker) Store() Store {
+	return t.store
+}
+
+// Funnels returns the configured funnels
+func (t *Tracker) Funnels() []Funnel {
+	return t.funnels
+}
+
+// FunnelReport is the conversion of a funnel for users entering it in a date range
+type FunnelReport struct {
+	Funnel  string         `json:"funnel"`
+	Window  string         `json:"window"`
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Steps   []FunnelStep   `json:"steps"`
+	Cohorts []FunnelCohort `json:"cohorts"`
+}
+
+// FunnelStep is the number of users reaching a step. Conversion is relative
+// to the first step and StepConversion to the previous one.
+type FunnelStep struct {
+	Step           int     `json:"step"`
+	Action         string  `json:"action"`
+	Users          int64   `json:"users"`
+	Conversion     float64 `json:"conversion"`
+	StepConversion float64 `json:"step_conversion"`
+}
+
+// FunnelCohort holds the users reaching each step among those entering the funnel on Date
+type FunnelCohort struct {
+	Date  string  `json:"date"`
+	Users []int64 `json:"users"`
+}
+
+// Funnel reports the funnel name for cohorts in [from, to)
+func (t *Tracker) Funnel(ctx context.Context, name string, from, to time.Time) (FunnelReport, error) {
+	var funnel *Funnel
+	for i := range t.funnels {
+		if t.funnels[i].Name == name {
+			funnel = &t.funnels[i]
+		}
+	}
+	if funnel == nil {
+		return FunnelReport{}, fmt.Errorf("%w %q", ErrUnknownFunnel, name)
+	}
+
+	counts, err := t.store.FunnelSteps(ctx, name, from, to)
+	if err != nil {
+		return FunnelReport{}, err
+	}
+
+	report := FunnelReport{
+		Funnel:  funnel.Name,
+		Window:  funnel.Window.String(),
+		From:    from.Format(DateLayout),
+		To:      to.Format(DateLayout),
+		Steps:   make([]FunnelStep, len(funnel.Steps)),
+		Cohorts: make([]FunnelCohort, 0),
+	}
+	for i, action := range funnel.Steps {
+		report.Steps[i] = FunnelStep{Step: i, Action: action}
+	}
+	for _, c := range counts {
+		if c.Step >= len(funnel.Steps) {
+			continue
+		}
+		date := c.Cohort.Format(DateLayout)
+		if n := len(report.Cohorts); n == 0 || report.Cohorts[n-1].Date != date {
+			report.Cohorts = append(report.Cohorts, FunnelCohort{Date: date, Users: make([]int64, len(funnel.Steps))})
+		}
+		report.Cohorts[len(report.Cohorts)-1].Users[c.Step] += c.Users
+		report.Steps[c.Step].Users += c.Users
+	}
+	for i := range report.Steps {
+		report.Steps[i].Conversion = ratio(report.Steps[i].Users, report.Steps[0].Users)
+		report.Steps[i].StepConversion = ratio(report.Steps[i].Users, report.Steps[max(i-1, 0)].Users)
+	}
+	return report, nil
+}
+
+// RetentionReport holds daily retention of the users first seen in a date range
+type RetentionReport struct {
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Days    int               `json:"days"`
+	Cohorts []RetentionCohort `json:"cohorts"`
+}
+
+// RetentionCohort holds the users first seen on Date and, per day offset,
+// how many of them were active again and their share of the cohort
+type RetentionCohort struct {
+	Date     string    `json:"date"`
+	Users    int64     `json:"users"`
+	Retained []int64   `json:"retained"`
+	Rates    []float64 `json:"rates"`
+}
+
+// Retention reports the cohorts first seen in [from, to) over days days
+func (t *Tracker) Retention(ctx context.Context, from, to time.Time, days int) (RetentionReport, error) {
+	counts, err := t.store.Retention(ctx, from, to, days)
+	if err != nil {
+		return RetentionReport{}, err
+	}
+
+	report := RetentionReport{
+		From:    from.Format(DateLayout),
+		To:      to.Format(DateLayout),
+		Days:    days,
+		Cohorts: make([]RetentionCohort, 0),
+	}
+	for _, c := range counts {
+		date := c.Cohort.Format(DateLayout)
+		if n := len(report.Cohorts); n == 0 || report.Cohorts[n-1].Date != date {
+			report.Cohorts = append(report.Cohorts, RetentionCohort{
+				Date:     date,
+				Retained: make([]int64, days+1),
+				Rates:    make([]float64, days+1),
+			})
+		}
+		cohort := &report.Cohorts[len(report.Cohorts)-1]
+		cohort.Retained[c.Offset] = c.Users
+		if c.Offset == 0 {
+			cohort.Users = c.Users
+		}
+	}
+	for i := range report.Cohorts {
+		cohort := &report.Cohorts[i]
+		for offset, users := range cohort.Retained {
+			cohort.Rates[offset] = ratio(users, cohort.Users)
+		}
+	}
+	return report, nil
+}
+
+func ratio(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
@@ -0,0 +1,105 @@
+package behavior
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTrackerFunnel(t *testing.T) {
+	ctx := context.Background()
+	funnels, err := ParseFunnels([]byte(`
+funnels:
+  - name: classic
+    steps: [Classic_Play, Classic_Claim, CoinPack1_Purchase]
+    window: 1h
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := NewTracker(slog.New(slog.DiscardHandler), NewMemoryStore(), funnels)
+
+	start := time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC)
+	track := func(user, action string, offset time.Duration) {
+		if err := tracker.Track(ctx, user, action, start.Add(offset)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// u1 converts; u2 claims too late; u3 skips a step; u4 replays the first step
+	track("u1", "Classic_Play", 0)
+	track("u1", "Classic_Claim", 10*time.Minute)
+	track("u1", "CoinPack1_Purchase", 20*time.Minute)
+	track("u2", "Classic_Play", 0)
+	track("u2", "Classic_Claim", 2*time.Hour)
+	track("u3", "Classic_Play", 0)
+	track("u3", "CoinPack1_Purchase", time.Minute)
+	track("u4", "Classic_Play", 0)
+	track("u4", "Classic_Play", time.Minute)
+
+	day := start.Truncate(24 * time.Hour)
+	report, err := tracker.Funnel(ctx, "classic", day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int64{4, 1, 1}
+	for i, step := range report.Steps {
+		if step.Users != want[i] {
+			t.Errorf("step %d: expected %d users, got %d", i, want[i], step.Users)
+		}
+	}
+	if got := report.Steps[2].Conversion; got != 0.25 {
+		t.Errorf("expected 25%% conversion, got %v", got)
+	}
+	if len(report.Cohorts) != 1 || report.Cohorts[0].Date != "2025-03-14" {
+		t.Errorf("unexpected cohorts: %+v", report.Cohorts)
+	}
+
+	if _, err := tracker.Funnel(ctx, "missing", day, day.AddDate(0, 0, 1)); err == nil {
+		t.Error("expected an error for an unknown funnel")
+	}
+}
+
+func TestTrackerRetention(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewTracker(slog.New(slog.DiscardHandler), NewMemoryStore(), nil)
+
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	activity := map[string][]int{
+		"u1": {0, 0, 1, 7},
+		"u2": {0, 7},
+		"u3": {1, 2},
+	}
+	for user, days := range activity {
+		for _, offset := range days {
+			if err := tracker.Track(ctx, user, "login", day.AddDate(0, 0, offset).Add(time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	report, err := tracker.Retention(ctx, day, day.AddDate(0, 0, 2), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cohorts) != 2 {
+		t.Fatalf("expected 2 cohorts, got %+v", report.Cohorts)
+	}
+
+	first := report.Cohorts[0]
+	if first.Date != "2025-03-14" || first.Users != 2 || first.Retained[1] != 1 || first.Retained[7] != 2 || first.Rates[7] != 1 {
+		t.Errorf("unexpected first cohort: %+v", first)
+	}
+	second := report.Cohorts[1]
+	if second.Date != "2025-03-15" || second.Users != 1 || second.Retained[1] != 1 {
+		t.Errorf("unexpected second cohort: %+v", second)
+	}
+}
+
+func TestExampleFunnelsAreValid(t *testing.T) {
+	if _, err := LoadFunnels("../../../cmd/stats/funnels.example.yaml"); err != nil {
+		t.Fatal(err)
+	}
+}
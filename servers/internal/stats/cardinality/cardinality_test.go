@@ -0,0 +1,49 @@
+package cardinality
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"/v1/items/42":             "/v1/items/{id}",
+		"/v1/items/42?expand=true": "/v1/items/{id}",
+		"/v1/users/3f2504e0-4f89-11d3-9a0c-0305e82c3301": "/v1/users/{id}",
+		"/v1/blobs/9b74c9897bac770ffc029102a200c5de":     "/v1/blobs/{id}",
+		"/v1/sessions/aB3dE5fG7hJ9kL1mN3pQ5r":            "/v1/sessions/{id}",
+		"/v1/items/{itemID}":                             "/v1/items/{itemID}",
+		"/v1/user_profile":                               "/v1/user_profile",
+		"/v2/health":                                     "/v2/health",
+		"":                                               "/",
+	}
+	for path, want := range tests {
+		if got := NormalizePath(path); got != want {
+			t.Errorf("NormalizePath(%q): expected %q, got %q", path, want, got)
+		}
+	}
+}
+
+func TestLimiterFoldsOverflowIntoOther(t *testing.T) {
+	l := NewLimiter(map[string]int{"action": 2}, 0)
+
+	for _, value := range []string{"login", "logout", "login"} {
+		if got := l.Admit("action", value); got != value {
+			t.Errorf("expected %q to be admitted, got %q", value, got)
+		}
+	}
+	if got := l.Admit("action", "spam-1"); got != Other {
+		t.Errorf("expected overflow to be folded into %q, got %q", Other, got)
+	}
+	l.Admit("action", "spam-2")
+
+	if got := l.Dropped()["action"]; got != 2 {
+		t.Errorf("expected 2 dropped values, got %d", got)
+	}
+	if got := l.Admit("endpoint", "spam-1"); got != "spam-1" {
+		t.Errorf("expected unbounded dimension to admit %q, got %q", "spam-1", got)
+	}
+	if got := l.Admit("endpoint", strings.Repeat("é", MaxValueLength)); len(got) > MaxValueLength || !strings.HasPrefix(got, "é") {
+		t.Errorf("expected value truncated to %d bytes, got %d", MaxValueLength, len(got))
+	}
+}
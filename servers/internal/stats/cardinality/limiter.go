@@ -0,0 +1,110 @@
+package cardinality
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// Other replaces values of a dimension once its cap is reached
+	Other = "other"
+	// MaxValueLength truncates longer values, in bytes
+	MaxValueLength = 128
+)
+
+// Limiter caps the number of distinct values tracked per dimension.
+//
+// The first values seen are admitted until the cap of their dimension is
+// reached; later values are folded into Other and counted as dropped, so a
+// client sending arbitrary values cannot grow the stats without bound.
+type Limiter struct {
+	mu         sync.Mutex
+	caps       map[string]int
+	defaultCap int
+	seen       map[string]map[string]struct{}
+	dropped    map[string]int64
+}
+
+// NewLimiter creates a limiter with per-dimension caps. Dimensions without a
+// cap use defaultCap; a cap of zero or less leaves the dimension unbounded.
+func NewLimiter(caps map[string]int, defaultCap int) *Limiter {
+	return &Limiter{
+		caps:       caps,
+		defaultCap: defaultCap,
+		seen:       make(map[string]map[string]struct{}),
+		dropped:    make(map[string]int64),
+	}
+}
+
+// Admit returns value, truncated to MaxValueLength, or Other when the
+// dimension is full and value was not seen before
+func (l *Limiter) Admit(dimension, value string) string {
+	return l.admit(dimension, value, true)
+}
+
+// Seed admits a value without counting it as dropped when the dimension is
+// full. It is used to restore values tracked before a restart.
+func (l *Limiter) Seed(dimension, value string) string {
+	return l.admit(dimension, value, false)
+}
+
+func (l *Limiter) admit(dimension, value string, count bool) string {
+	value = truncate(value)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	values, ok := l.seen[dimension]
+	if !ok {
+		values = make(map[string]struct{})
+		l.seen[dimension] = values
+	}
+	if _, ok := values[value]; ok {
+		return value
+	}
+
+	limit, ok := l.caps[dimension]
+	if !ok {
+		limit = l.defaultCap
+	}
+	if limit > 0 && len(values) >= limit {
+		if count {
+			l.dropped[dimension]++
+		}
+		return Other
+	}
+
+	values[value] = struct{}{}
+	return value
+}
+
+// Dropped returns the number of values folded into Other per dimension
+func (l *Limiter) Dropped() map[string]int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make(map[string]int64, len(l.dropped))
+	for dimension, n := range l.dropped {
+		out[dimension] = n
+	}
+	return out
+}
+
+// Size returns the number of distinct values admitted for dimension
+func (l *Limiter) Size(dimension string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.seen[dimension])
+}
+
+// truncate shortens value to at most MaxValueLength bytes without splitting a rune
+func truncate(value string) string {
+	if len(value) <= MaxValueLength {
+		return value
+	}
+	cut := MaxValueLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut]
+}
@@ -0,0 +1,79 @@
+package cardinality
+
+import (
+	"strings"
+)
+
+// IDPlaceholder replaces path segments that look like identifiers
+const IDPlaceholder = "{id}"
+
+// NormalizePath turns a request path into a route template by dropping the
+// query string and replacing identifier-like segments, so "/v1/items/42" and
+// "/v1/items/43" count as "/v1/items/{id}". Route patterns such as
+// "/v1/items/{itemID}" are returned unchanged.
+func NormalizePath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isIdentifier(segment) {
+			segments[i] = IDPlaceholder
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIdentifier reports whether a segment is a number, a UUID, a long hex
+// string or a long token mixing letters and digits
+func isIdentifier(segment string) bool {
+	if segment == "" || strings.HasPrefix(segment, "{") {
+		return false
+	}
+
+	digits, hex, other := 0, 0, 0
+	for _, c := range segment {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'):
+			hex++
+		case c == '-' || c == '_':
+		default:
+			other++
+		}
+	}
+
+	switch {
+	case digits == len(segment):
+		return true
+	case isUUID(segment):
+		return true
+	case other == 0 && digits > 0 && digits+hex >= 16:
+		return true
+	default:
+		// Opaque tokens such as base62 IDs: long and containing digits
+		return len(segment) >= 20 && digits > 0
+	}
+}
+
+func isUUID(segment string) bool {
+	if len(segment) != 36 {
+		return false
+	}
+	for i, c := range segment {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
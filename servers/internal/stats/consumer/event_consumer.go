@@ -9,49 +9,81 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/backends"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/consumer"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/health"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/resilience"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
 )
 
+// brokerBackend selects where events are consumed from: redis, memory or nats
+var brokerBackend = shared.EnvString("STATS_BROKER", string(broker.KindRedis))
+
 // Compile-time check to ensure EventConsumer implements consumer.Consumer interface
 var _ consumer.Consumer = (*EventConsumer)(nil)
 
+func init() {
+	redisstream.RegisterDecoder("stats", ParseEvent)
+}
+
 // EventConsumer consumes events from Redis Streams and processes them
 type EventConsumer struct {
 	logger      *slog.Logger
 	redisClient *redis.Client
-	consumer    *redisstream.Consumer[stats.Event]
+	consumer    broker.StreamConsumer
 	eventCh     chan stats.Event
 	processor   *EventProcessor
+	// wg tracks the stream readers, processing the processor draining eventCh
+	wg         *sync.WaitGroup
+	processing *sync.WaitGroup
 }
 
-// NewEventConsumer creates a new event consumer
+// NewEventConsumer creates a new event consumer on the backend selected by STATS_BROKER.
+// redisClient is only required for the redis backend. The consumer reports its
+// circuit breaker state to registry, which may be nil.
 func NewEventConsumer(
 	logger *slog.Logger,
 	redisClient *redis.Client,
 	processor *EventProcessor,
-) *EventConsumer {
+	registry *health.Registry,
+) (*EventConsumer, error) {
+	kind, err := broker.ParseKind(brokerBackend)
+	if err != nil {
+		return nil, err
+	}
+
 	eventCh := make(chan stats.Event, 100)
 
 	config := redisstream.Config{
-		StreamKey:        "stats:events",
-		ConsumerGroup:    "stats-service",
+		StreamKey:        stats.EventStreamKey,
+		ConsumerGroup:    stats.EventConsumerGroup,
 		ConsumerIDPrefix: "stats-consumer",
 		BatchSize:        10,
 		BlockTime:        5 * time.Second,
 		MaxRetries:       5,
 		RetryDelay:       2 * time.Second,
 		MinIdle:          10 * time.Second,
+		Breaker: resilience.BreakerConfig{
+			FailureThreshold: 5,
+			Cooldown:         30 * time.Second,
+		},
+		Health: registry,
 	}
 
-	consumer := redisstream.NewConsumer(
+	consumer, err := backends.NewStreamConsumer(
+		kind,
 		logger,
 		redisClient,
 		eventCh,
-		parseEvent,
+		ParseEvent,
 		config,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream consumer: %w", err)
+	}
 
 	return &EventConsumer{
 		logger:      logger,
@@ -59,11 +91,13 @@ func NewEventConsumer(
 		consumer:    consumer,
 		eventCh:     eventCh,
 		processor:   processor,
-	}
+		wg:          new(sync.WaitGroup),
+		processing:  new(sync.WaitGroup),
+	}, nil
 }
 
-// parseEvent parses a Redis message into an Event
-func parseEvent(msg redis.XMessage) (stats.Event, error) {
+// ParseEvent parses a stream message into an Event
+func ParseEvent(msg redis.XMessage) (stats.Event, error) {
 	var event stats.Event
 
 	// Extract event data from message
@@ -76,72 +110,77 @@ func parseEvent(msg redis.XMessage) (stats.Event, error) {
 		return event, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
+	event.StreamID = msg.ID
+
+	// Fall back to the stream entry ID so redeliveries can still be deduplicated
+	if event.ID == "" {
+		event.ID = msg.ID
+	}
+
 	return event, nil
 }
 
 // Start begins consuming events
 func (ec *EventConsumer) Start(ctx context.Context) error {
-	wg := new(sync.WaitGroup)
-
-	// Start the Redis stream consumer
-	if err := ec.consumer.ConsumeLoop(ctx, wg); err != nil {
+	// Start the stream consumer
+	if err := ec.consumer.ConsumeLoop(ctx, ec.wg); err != nil {
 		return fmt.Errorf("failed to start consumer loop: %w", err)
 	}
 
 	// Start the event processor
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if err := ec.processEvents(ctx); err != nil {
-			ec.logger.Error("event processor error", "error", err)
-		}
-	}()
+	ec.processing.Go(func() {
+		ec.processEvents(ctx)
+	})
 
 	return nil
 }
 
-// processEvents processes events from the channel
-func (ec *EventConsumer) processEvents(ctx context.Context) error {
+// processEvents processes events until the channel is closed. Events read
+// from the stream are always applied, even after ctx is canceled, so none
+// are lost between being handed off and the shutdown flush.
+func (ec *EventConsumer) processEvents(ctx context.Context) {
 	ec.logger.Info("starting event processor")
+	ctx = context.WithoutCancel(ctx)
 
-	for {
-		select {
-		case <-ctx.Done():
-			ec.logger.Info("stopping event processor")
-			return nil
-		case event, ok := <-ec.eventCh:
-			if !ok {
-				ec.logger.Info("event channel closed")
-				return nil
-			}
-
-			if err := ec.processor.ProcessEvent(ctx, event); err != nil {
-				ec.logger.Error("failed to process event",
-					"event_id", event.ID,
-					"event_type", event.Type,
-					"error", err)
-				// Continue processing other events
-				continue
-			}
-
-			ec.logger.Debug("event processed successfully",
+	for event := range ec.eventCh {
+		if err := ec.processor.ProcessEvent(ctx, event); err != nil {
+			ec.logger.Error("failed to process event",
 				"event_id", event.ID,
-				"event_type", event.Type)
+				"event_type", event.Type,
+				"error", err)
+			// Continue processing other events
+			continue
 		}
+
+		ec.logger.Debug("event processed successfully",
+			"event_id", event.ID,
+			"event_type", event.Type)
 	}
+	ec.logger.Info("event channel closed; event processor stopped")
 }
 
-// Shutdown gracefully stops the consumer
+// Shutdown stops the stream readers, then waits for the processor to apply
+// the events already handed off. The event channel is only closed once no
+// reader can send to it.
 func (ec *EventConsumer) Shutdown(ctx context.Context) error {
 	ec.logger.Info("shutting down event consumer")
 
-	wg := new(sync.WaitGroup)
-	if err := ec.consumer.Shutdown(ctx, wg); err != nil {
+	if err := ec.consumer.Shutdown(ctx, ec.wg); err != nil {
 		return fmt.Errorf("failed to shutdown consumer: %w", err)
 	}
 
-	// Close the event channel
 	close(ec.eventCh)
 
-	return nil
+	done := make(chan struct{})
+	go func() {
+		ec.processing.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain event channel: %w", ctx.Err())
+	}
 }
@@ -2,123 +2,506 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/consumer"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/redisstream"
 	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/cardinality"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/histogram"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/schema"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/snapshot"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
 )
 
 // Compile-time checks to ensure EventProcessor implements the required interfaces
 var (
-	_ consumer.Processor[stats.Event]                            = (*EventProcessor)(nil)
-	_ consumer.MetricsProvider[stats.MetricsSummary]              = (*EventProcessor)(nil)
+	_ consumer.Processor[stats.Event]                                  = (*EventProcessor)(nil)
+	_ consumer.MetricsProvider[stats.MetricsSummary]                   = (*EventProcessor)(nil)
 	_ consumer.ProcessorWithMetrics[stats.Event, stats.MetricsSummary] = (*EventProcessor)(nil)
+	_ snapshot.Source                                                  = (*EventProcessor)(nil)
 )
 
+var (
+	// latencyWindow is the sliding window for percentiles, averages and error rates
+	latencyWindow = shared.EnvDuration("STATS_LATENCY_WINDOW", 5*time.Minute)
+	latencySlots  = 30
+
+	// Caps on distinct values per dimension; later values are counted as "other"
+	maxEndpoints  = shared.EnvInt("STATS_MAX_ENDPOINTS", 1000)
+	maxActions    = shared.EnvInt("STATS_MAX_ACTIONS", 1000)
+	maxOperations = shared.EnvInt("STATS_MAX_OPERATIONS", 500)
+	maxUsers      = shared.EnvInt("STATS_MAX_USERS", 100000)
+
+	// recordTimeout bounds how long an event waits for room in the rollup buffer before it is dropped
+	recordTimeout = shared.EnvDuration("STATS_RECORD_TIMEOUT", 30*time.Second)
+
+	// maxApplied caps the applied stream entry IDs kept for snapshots.
+	// Redeliveries of entries applied before the last maxApplied are counted again.
+	maxApplied = shared.EnvInt("STATS_SNAPSHOT_MAX_APPLIED", 10000)
+)
+
+// idempotencyScope scopes processed event IDs to the stats consumer group
+var idempotencyScope = idempotency.Scope(stats.EventStreamKey, stats.EventConsumerGroup)
+
 // EventProcessor processes and aggregates events
 type EventProcessor struct {
-	logger   *slog.Logger
-	metrics  *stats.MetricsSummary
-	mu       sync.RWMutex
+	logger  *slog.Logger
+	metrics *stats.MetricsSummary
+	mu      sync.RWMutex
+	// idempotency skips events that were already applied; nil disables deduplication.
+	// Events are claimed after they were applied, never before.
+	idempotency idempotency.Store
+	// rollups persists time-bucketed counts
+	rollups store.Store
+	// behavior tracks funnels and retention from user actions; nil disables them
+	behavior *behavior.Tracker
+	// schemas decodes event metadata and dispatches it to the process functions
+	schemas *schema.Registry[*store.Record]
+	// quarantine keeps events that match no schema
+	quarantine schema.Quarantine
+	// limiter caps the distinct values of each dimension
+	limiter *cardinality.Limiter
+	// capped lists the dimensions whose cap was reached, to warn once per dimension
+	capped map[string]bool
 
-	// In a real implementation, you would store these in a database
-	// For this example, we'll use in-memory storage
 	apiCallMetrics map[string]int64
 	userActions    map[string]int64
+
+	// position is the highest stream entry ID applied
+	position string
+	// applied lists the last stream entry IDs applied, oldest first. It grows up to
+	// twice maxApplied before it is trimmed, and only with the memory rollup store,
+	// the one store that is snapshotted.
+	applied []string
+	// restored holds the applied entry IDs of the restored snapshot; they are already
+	// counted. It is cleared once an entry above restoredMax is applied.
+	restored    map[string]bool
+	restoredMax string
+
+	// latency windows of all timed events, per endpoint and per operation
+	latency          *histogram.Window
+	endpointLatency  map[string]*histogram.Window
+	operationLatency map[string]*histogram.Window
 }
 
-// NewEventProcessor creates a new event processor
-func NewEventProcessor(logger *slog.Logger) *EventProcessor {
-	return &EventProcessor{
-		logger: logger,
+// NewEventProcessor creates a new event processor. idempotencyStore may be nil to count redeliveries again.
+// quarantine may be nil to only log events that match no schema and tracker may be nil to skip
+// funnels and retention.
+func NewEventProcessor(
+	logger *slog.Logger,
+	idempotencyStore idempotency.Store,
+	rollups store.Store,
+	quarantine schema.Quarantine,
+	tracker *behavior.Tracker,
+) *EventProcessor {
+	if quarantine == nil {
+		quarantine = schema.NewLogQuarantine(logger)
+	}
+
+	ep := &EventProcessor{
+		logger:      logger,
+		idempotency: idempotencyStore,
+		rollups:     rollups,
+		behavior:    tracker,
+		schemas:     schema.NewRegistry[*store.Record](),
+		quarantine:  quarantine,
+		limiter: cardinality.NewLimiter(map[string]int{
+			store.DimensionEndpoint:  maxEndpoints,
+			store.DimensionAction:    maxActions,
+			store.DimensionOperation: maxOperations,
+			store.DimensionUser:      maxUsers,
+		}, 0),
+		capped: make(map[string]bool),
 		metrics: &stats.MetricsSummary{
 			EventsByType: make(map[stats.EventType]int64),
 			LastUpdated:  time.Now(),
 		},
-		apiCallMetrics: make(map[string]int64),
-		userActions:    make(map[string]int64),
+		apiCallMetrics:   make(map[string]int64),
+		userActions:      make(map[string]int64),
+		latency:          histogram.NewWindow(latencyWindow, latencySlots),
+		endpointLatency:  make(map[string]*histogram.Window),
+		operationLatency: make(map[string]*histogram.Window),
 	}
+
+	schema.Register(ep.schemas, ep.processAPICallEvent)
+	schema.Register(ep.schemas, ep.processUserActionEvent)
+	schema.Register(ep.schemas, ep.processErrorEvent)
+	schema.Register(ep.schemas, ep.processPerformanceEvent)
+
+	return ep
+}
+
+// Schemas lists the event schemas the processor accepts
+func (ep *EventProcessor) Schemas() []schema.Key {
+	return ep.schemas.Keys()
 }
 
 // ProcessEvent processes a single event
 func (ep *EventProcessor) ProcessEvent(ctx context.Context, event stats.Event) error {
-	ep.mu.Lock()
-	defer ep.mu.Unlock()
+	if ep.inSnapshot(event) {
+		ep.logger.Info("skipping event already in the restored snapshot",
+			"event_id", event.ID,
+			"stream_id", event.StreamID)
+		return nil
+	}
+	if ep.isDuplicate(ctx, event) {
+		ep.logger.Info("skipping already processed event",
+			"event_id", event.ID,
+			"event_type", event.Type)
+		return nil
+	}
 
 	ep.logger.Info("processing event",
 		"event_id", event.ID,
 		"event_type", event.Type,
 		"timestamp", event.Timestamp)
 
+	ep.mu.Lock()
+	rec := newRecord(event)
+	err := ep.schemas.Dispatch(ctx, event, &rec)
+	if qerr, ok := schema.AsQuarantine(err); ok {
+		ep.mu.Unlock()
+		if err := ep.quarantineEvent(ctx, event, qerr); err != nil {
+			return err
+		}
+		ep.mu.Lock()
+		ep.metrics.QuarantinedEvents++
+		ep.advance(event.StreamID)
+		ep.mu.Unlock()
+		ep.claim(ctx, event)
+		return nil
+	}
+	if err != nil {
+		// Left pending so the redelivery is counted once the handler succeeds
+		ep.mu.Unlock()
+		return err
+	}
+
+	// The cap only applies to the user rollups; funnels and retention need every user
+	userID := rec.Dimensions[store.DimensionUser]
+	if userID != "" {
+		rec.Dimensions[store.DimensionUser] = ep.admit(store.DimensionUser, userID)
+	}
+
 	// Update total events
 	ep.metrics.TotalEvents++
 	ep.metrics.EventsByType[event.Type]++
 	ep.metrics.LastUpdated = time.Now()
 
-	// Process based on event type
-	switch event.Type {
-	case stats.EventTypeAPICall:
-		return ep.processAPICallEvent(ctx, event)
-	case stats.EventTypeUserAction:
-		return ep.processUserActionEvent(ctx, event)
-	case stats.EventTypeError:
-		return ep.processErrorEvent(ctx, event)
-	case stats.EventTypePerformance:
-		return ep.processPerformanceEvent(ctx, event)
-	default:
-		ep.logger.Warn("unknown event type", "event_type", event.Type)
-		return nil
+	ep.mu.Unlock()
+
+	// Recording may wait for buffer space, so it must not hold ep.mu. The position
+	// moves once the rollup holds the event, so a snapshot never skips an uncounted one.
+	ep.record(ctx, rec)
+	ep.mu.Lock()
+	ep.advance(event.StreamID)
+	ep.mu.Unlock()
+
+	ep.track(ctx, rec, userID)
+	ep.claim(ctx, event)
+	return nil
+}
+
+// quarantineEvent sets aside an event that matches no schema instead of counting it
+func (ep *EventProcessor) quarantineEvent(ctx context.Context, event stats.Event, qerr *schema.QuarantineError) error {
+	ep.logger.Warn("quarantining event",
+		"event_id", event.ID,
+		"event_type", event.Type,
+		"schema_version", event.SchemaVersion,
+		"reason", qerr.Reason,
+		"error", qerr.Err)
+
+	if err := ep.quarantine.Quarantine(ctx, event, qerr); err != nil {
+		return fmt.Errorf("failed to quarantine event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// newRecord creates the rollup record of an event; the process functions add dimensions and latency
+func newRecord(event stats.Event) store.Record {
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	rec := store.Record{
+		EventID:    event.ID,
+		Type:       event.Type,
+		Timestamp:  timestamp,
+		Dimensions: make(map[string]string),
+	}
+	rec.SetUser(event.UserID)
+	return rec
+}
+
+// record adds the event to the persisted rollups, waiting at most recordTimeout
+// for a store that is full
+func (ep *EventProcessor) record(ctx context.Context, rec store.Record) {
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+
+	if err := ep.rollups.Record(ctx, rec); err != nil {
+		ep.logger.Error("failed to record stats rollup",
+			"event_id", rec.EventID,
+			"error", err)
+	}
+}
+
+// track feeds user actions to the funnel and retention tracker. userID is the
+// user before the cardinality cap of the rollups, so new users keep being tracked
+// once the cap is reached.
+func (ep *EventProcessor) track(ctx context.Context, rec store.Record, userID string) {
+	if ep.behavior == nil || rec.Type != stats.EventTypeUserAction || userID == "" {
+		return
+	}
+
+	if err := ep.behavior.Track(ctx, userID, rec.Dimensions[store.DimensionAction], rec.Timestamp); err != nil {
+		ep.logger.Error("failed to track user behavior",
+			"event_id", rec.EventID,
+			"error", err)
+	}
+}
+
+// Restore loads all-time totals from the rollup store so a restarted server resumes its counts
+func (ep *EventProcessor) Restore(ctx context.Context) error {
+	totals, err := ep.rollups.Totals(ctx)
+	if err != nil {
+		return err
+	}
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	ep.metrics.TotalEvents = 0
+	ep.metrics.EventsByType = make(map[stats.EventType]int64, len(totals.EventsByType))
+	for eventType, count := range totals.EventsByType {
+		ep.metrics.TotalEvents += count
+		ep.metrics.EventsByType[eventType] = count
+	}
+
+	ep.apiCallMetrics = ep.seed(store.DimensionEndpoint, totals.ByDimension[store.DimensionEndpoint])
+	ep.userActions = ep.seed(store.DimensionAction, totals.ByDimension[store.DimensionAction])
+
+	ep.logger.Info("restored stats totals", "total_events", ep.metrics.TotalEvents)
+	return nil
+}
+
+// seed admits restored counts busiest first, so the values that were tracked
+// before a restart keep their names and the rest is folded into "other".
+// Must be called with ep.mu held.
+func (ep *EventProcessor) seed(dimension string, counts map[string]int64) map[string]int64 {
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return counts[values[i]] > counts[values[j]]
+	})
+
+	seeded := make(map[string]int64, len(counts))
+	for _, value := range values {
+		seeded[ep.limiter.Seed(dimension, value)] += counts[value]
+	}
+	return seeded
+}
+
+// admit returns value or "other" once the cap of dimension is reached. Must be called with ep.mu held.
+func (ep *EventProcessor) admit(dimension, value string) string {
+	admitted := ep.limiter.Admit(dimension, value)
+	if admitted == cardinality.Other && value != cardinality.Other && !ep.capped[dimension] {
+		ep.capped[dimension] = true
+		ep.logger.Warn("stats dimension reached its cardinality cap; counting new values as other",
+			"dimension", dimension,
+			"cap", ep.limiter.Size(dimension))
 	}
+	return admitted
 }
 
-func (ep *EventProcessor) processAPICallEvent(ctx context.Context, event stats.Event) error {
-	var apiCall stats.APICallEvent
+// advance moves the position to streamID if it is newer and records streamID
+// as applied. Must be called with ep.mu held.
+func (ep *EventProcessor) advance(streamID string) {
+	if streamID == "" {
+		return
+	}
+	if redisstream.CompareIDs(streamID, ep.position) > 0 {
+		ep.position = streamID
+	}
+	if ep.restored != nil && redisstream.CompareIDs(streamID, ep.restoredMax) > 0 {
+		// The consumer moved past the snapshot, its redeliveries were handled
+		ep.restored = nil
+	}
+	if _, ok := ep.rollups.(*store.MemoryStore); ok {
+		ep.applied = append(ep.applied, streamID)
+		if len(ep.applied) >= 2*maxApplied {
+			ep.applied = append(ep.applied[:0:0], ep.lastApplied()...)
+		}
+	}
+}
+
+// lastApplied returns the last maxApplied applied entry IDs. Must be called with ep.mu held.
+func (ep *EventProcessor) lastApplied() []string {
+	if len(ep.applied) > maxApplied {
+		return ep.applied[len(ep.applied)-maxApplied:]
+	}
+	return ep.applied
+}
+
+// inSnapshot reports whether event was applied to the restored snapshot, so a
+// redelivery after a restart is not counted twice. Entries are not applied in ID
+// order (pending entries are claimed and redelivered later), so this looks the
+// ID up instead of comparing it with the snapshot position.
+func (ep *EventProcessor) inSnapshot(event stats.Event) bool {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+
+	if event.StreamID == "" {
+		return false
+	}
+	return ep.restored[event.StreamID]
+}
 
-	metadataJSON, err := json.Marshal(event.Metadata)
+// Snapshot captures the rollups, funnel and retention cohorts, stream position
+// and the last maxApplied entry IDs applied. It requires the memory rollup store; persistent stores need no snapshots.
+func (ep *EventProcessor) Snapshot() (snapshot.State, error) {
+	rollups, ok := ep.rollups.(*store.MemoryStore)
+	if !ok {
+		return snapshot.State{}, errors.New("snapshots require the memory rollup store")
+	}
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	state := snapshot.State{
+		TakenAt:           time.Now(),
+		Position:          ep.position,
+		Applied:           slices.Clone(ep.lastApplied()),
+		QuarantinedEvents: ep.metrics.QuarantinedEvents,
+		Rollups:           rollups.Export(),
+	}
+	if ep.behavior != nil {
+		if cohorts, ok := ep.behavior.Store().(*behavior.MemoryStore); ok {
+			exported := cohorts.Export()
+			state.Cohorts = &exported
+		}
+	}
+	return state, nil
+}
+
+// RestoreSnapshot loads a snapshot into the memory stores and rebuilds the totals
+// from it. Redeliveries of the entries it lists as applied are skipped afterwards.
+func (ep *EventProcessor) RestoreSnapshot(ctx context.Context, state snapshot.State) error {
+	rollups, ok := ep.rollups.(*store.MemoryStore)
+	if !ok {
+		return errors.New("snapshots require the memory rollup store")
+	}
+	rollups.Import(state.Rollups)
+	if ep.behavior != nil && state.Cohorts != nil {
+		if cohorts, ok := ep.behavior.Store().(*behavior.MemoryStore); ok {
+			cohorts.Import(*state.Cohorts)
+		}
+	}
+
+	ep.mu.Lock()
+	ep.position = state.Position
+	ep.restored = make(map[string]bool, len(state.Applied))
+	ep.restoredMax = ""
+	for _, id := range state.Applied {
+		ep.restored[id] = true
+		if redisstream.CompareIDs(id, ep.restoredMax) > 0 {
+			ep.restoredMax = id
+		}
+	}
+	// Keep them for the next snapshot
+	ep.applied = slices.Clone(state.Applied)
+	ep.metrics.QuarantinedEvents = state.QuarantinedEvents
+	ep.mu.Unlock()
+
+	return ep.Restore(ctx)
+}
+
+// isDuplicate reports whether the event was already applied.
+// Store errors are logged and the event is applied, preferring a double count over a loss.
+func (ep *EventProcessor) isDuplicate(ctx context.Context, event stats.Event) bool {
+	if ep.idempotency == nil || event.ID == "" {
+		return false
+	}
+
+	seen, err := ep.idempotency.Seen(ctx, idempotencyScope, event.ID)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		ep.logger.Warn("idempotency check failed; processing event anyway",
+			"event_id", event.ID,
+			"error", err)
+		return false
+	}
+	return seen
+}
+
+// claim records the event as applied. It runs only after the event was
+// counted or quarantined, so an event that failed, or whose processing was
+// interrupted, is applied again when redelivered.
+func (ep *EventProcessor) claim(ctx context.Context, event stats.Event) {
+	if ep.idempotency == nil || event.ID == "" {
+		return
 	}
 
-	if err := json.Unmarshal(metadataJSON, &apiCall); err != nil {
-		return fmt.Errorf("failed to unmarshal API call event: %w", err)
+	claimed, err := ep.idempotency.Claim(ctx, idempotencyScope, event.ID)
+	if err != nil {
+		ep.logger.Warn("failed to record applied event; a redelivery will be counted again",
+			"event_id", event.ID,
+			"error", err)
+		return
+	}
+	if !claimed {
+		ep.logger.Warn("event was applied concurrently by another consumer", "event_id", event.ID)
 	}
+}
 
+func (ep *EventProcessor) processAPICallEvent(ctx context.Context, event stats.Event, apiCall stats.APICallEvent, rec *store.Record) error {
 	// Track API call metrics
-	endpoint := fmt.Sprintf("%s %s", apiCall.Method, apiCall.Path)
+	// Paths are normalized to route templates so IDs do not create new endpoints
+	endpoint := ep.admit(store.DimensionEndpoint,
+		fmt.Sprintf("%s %s", strings.ToUpper(apiCall.Method), cardinality.NormalizePath(apiCall.Path)))
 	ep.apiCallMetrics[endpoint]++
 
+	failed := apiCall.StatusCode >= 500 || apiCall.ErrorMessage != ""
+	ep.observe(ep.endpointLatency, endpoint, apiCall.Duration, failed)
+
+	rec.Dimensions[store.DimensionEndpoint] = endpoint
+	rec.SetUser(apiCall.UserID)
+	rec.Latency = &store.Latency{
+		Dimension: store.DimensionEndpoint,
+		Value:     endpoint,
+		Duration:  apiCall.Duration,
+		Failed:    failed,
+	}
+
 	ep.logger.Debug("processed API call event",
 		"method", apiCall.Method,
 		"path", apiCall.Path,
 		"status_code", apiCall.StatusCode,
 		"duration", apiCall.Duration)
 
-	// In a real implementation, you would:
-	// - Store in database
-	// - Update time-series metrics
-	// - Trigger alerts if needed
-
 	return nil
 }
 
-func (ep *EventProcessor) processUserActionEvent(ctx context.Context, event stats.Event) error {
-	var userAction stats.UserActionEvent
-
-	metadataJSON, err := json.Marshal(event.Metadata)
-	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
-	}
-
-	if err := json.Unmarshal(metadataJSON, &userAction); err != nil {
-		return fmt.Errorf("failed to unmarshal user action event: %w", err)
-	}
-
+func (ep *EventProcessor) processUserActionEvent(ctx context.Context, event stats.Event, userAction stats.UserActionEvent, rec *store.Record) error {
 	// Track user action metrics
-	ep.userActions[userAction.Action]++
+	action := ep.admit(store.DimensionAction, userAction.Action)
+	ep.userActions[action]++
+
+	rec.Dimensions[store.DimensionAction] = action
+	rec.SetUser(userAction.UserID)
 
 	ep.logger.Debug("processed user action event",
 		"action", userAction.Action,
@@ -128,17 +511,8 @@ func (ep *EventProcessor) processUserActionEvent(ctx context.Context, event stat
 	return nil
 }
 
-func (ep *EventProcessor) processErrorEvent(ctx context.Context, event stats.Event) error {
-	var errorEvent stats.ErrorEvent
-
-	metadataJSON, err := json.Marshal(event.Metadata)
-	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
-	}
-
-	if err := json.Unmarshal(metadataJSON, &errorEvent); err != nil {
-		return fmt.Errorf("failed to unmarshal error event: %w", err)
-	}
+func (ep *EventProcessor) processErrorEvent(ctx context.Context, event stats.Event, errorEvent stats.ErrorEvent, rec *store.Record) error {
+	rec.SetUser(errorEvent.UserID)
 
 	ep.logger.Error("processed error event",
 		"message", errorEvent.Message,
@@ -149,21 +523,22 @@ func (ep *EventProcessor) processErrorEvent(ctx context.Context, event stats.Eve
 	// - Store error details
 	// - Update error rate metrics
 	// - Send to error tracking service (e.g., Sentry)
-	// - Trigger alerts
+	//
+	// Alerts on error counts are raised by event_count rules of the alert engine
 
 	return nil
 }
 
-func (ep *EventProcessor) processPerformanceEvent(ctx context.Context, event stats.Event) error {
-	var perfEvent stats.PerformanceEvent
+func (ep *EventProcessor) processPerformanceEvent(ctx context.Context, event stats.Event, perfEvent stats.PerformanceEvent, rec *store.Record) error {
+	operation := ep.admit(store.DimensionOperation, perfEvent.Operation)
+	ep.observe(ep.operationLatency, operation, perfEvent.Duration, !perfEvent.Success)
 
-	metadataJSON, err := json.Marshal(event.Metadata)
-	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
-	}
-
-	if err := json.Unmarshal(metadataJSON, &perfEvent); err != nil {
-		return fmt.Errorf("failed to unmarshal performance event: %w", err)
+	rec.Dimensions[store.DimensionOperation] = operation
+	rec.Latency = &store.Latency{
+		Dimension: store.DimensionOperation,
+		Value:     operation,
+		Duration:  perfEvent.Duration,
+		Failed:    !perfEvent.Success,
 	}
 
 	ep.logger.Debug("processed performance event",
@@ -174,6 +549,31 @@ func (ep *EventProcessor) processPerformanceEvent(ctx context.Context, event sta
 	return nil
 }
 
+// observe records a duration in the overall window and in the window of key.
+// Must be called with ep.mu held.
+func (ep *EventProcessor) observe(windows map[string]*histogram.Window, key string, d time.Duration, failed bool) {
+	now := time.Now()
+	ep.latency.Record(now, d, failed)
+
+	w, ok := windows[key]
+	if !ok {
+		w = histogram.NewWindow(latencyWindow, latencySlots)
+		windows[key] = w
+	}
+	w.Record(now, d, failed)
+}
+
+// latencySnapshot summarizes the non-empty windows. Must be called with ep.mu held.
+func latencySnapshot(windows map[string]*histogram.Window, now time.Time) map[string]stats.LatencyStats {
+	out := make(map[string]stats.LatencyStats, len(windows))
+	for key, w := range windows {
+		if s := w.Snapshot(now); s.Count > 0 {
+			out[key] = stats.NewLatencyStats(s)
+		}
+	}
+	return out
+}
+
 // GetMetrics returns the current metrics summary
 func (ep *EventProcessor) GetMetrics() stats.MetricsSummary {
 	ep.mu.RLock()
@@ -183,12 +583,32 @@ func (ep *EventProcessor) GetMetrics() stats.MetricsSummary {
 	metricsCopy := stats.MetricsSummary{
 		TotalEvents:  ep.metrics.TotalEvents,
 		EventsByType: make(map[stats.EventType]int64),
+		APICalls:     make(map[string]int64, len(ep.apiCallMetrics)),
+		UserActions:  make(map[string]int64, len(ep.userActions)),
 		LastUpdated:  ep.metrics.LastUpdated,
 	}
+	metricsCopy.QuarantinedEvents = ep.metrics.QuarantinedEvents
+	metricsCopy.DroppedDimensions = ep.limiter.Dropped()
+	if counter, ok := ep.rollups.(store.DropCounter); ok {
+		metricsCopy.DroppedRollups = counter.Dropped()
+	}
 
 	for k, v := range ep.metrics.EventsByType {
 		metricsCopy.EventsByType[k] = v
 	}
+	for k, v := range ep.apiCallMetrics {
+		metricsCopy.APICalls[k] = v
+	}
+	for k, v := range ep.userActions {
+		metricsCopy.UserActions[k] = v
+	}
+
+	now := time.Now()
+	overall := ep.latency.Snapshot(now)
+	metricsCopy.AverageDuration = stats.NewLatencyStats(overall).AvgMs
+	metricsCopy.ErrorRate = overall.ErrorRate
+	metricsCopy.EndpointLatency = latencySnapshot(ep.endpointLatency, now)
+	metricsCopy.OperationLatency = latencySnapshot(ep.operationLatency, now)
 
 	return metricsCopy
 }
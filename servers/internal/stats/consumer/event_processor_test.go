@@ -0,0 +1,386 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/schema"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/snapshot"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+func newTestProcessor() *EventProcessor {
+	logger := slog.New(slog.DiscardHandler)
+	tracker := behavior.NewTracker(logger, behavior.NewMemoryStore(), nil)
+	return NewEventProcessor(logger, nil, store.NewMemoryStore(), nil, tracker)
+}
+
+func TestSnapshotRestoreSkipsCountedEvents(t *testing.T) {
+	ctx := context.Background()
+
+	events := make([]stats.Event, 3)
+	for i := range events {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = fmt.Sprintf("event-%d", i)
+		event.StreamID = fmt.Sprintf("1700000000000-%d", i)
+		event.Timestamp = time.Now()
+		events[i] = event
+	}
+
+	before := newTestProcessor()
+	for _, event := range events[:2] {
+		if err := before.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err := before.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := snapshot.Encode(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := snapshot.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := newTestProcessor()
+	if err := after.RestoreSnapshot(ctx, decoded); err != nil {
+		t.Fatal(err)
+	}
+	// The second event is redelivered after the restart, then a new one arrives
+	for _, event := range events[1:] {
+		if err := after.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metrics := after.GetMetrics()
+	if metrics.TotalEvents != 3 || metrics.UserActions["login"] != 3 {
+		t.Errorf("expected 3 logins after restore, got total %d and %d logins", metrics.TotalEvents, metrics.UserActions["login"])
+	}
+
+	now := time.Now()
+	report, err := after.behavior.Retention(ctx, store.Day.Truncate(now), store.Day.Truncate(now).AddDate(0, 0, 1), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cohorts) != 1 || report.Cohorts[0].Users != 1 {
+		t.Errorf("expected the retention cohort to be restored, got %+v", report.Cohorts)
+	}
+}
+
+func TestSnapshotRestoreAppliesOlderPendingEntries(t *testing.T) {
+	ctx := context.Background()
+
+	events := make([]stats.Event, 3)
+	for i := range events {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = fmt.Sprintf("event-%d", i)
+		event.StreamID = fmt.Sprintf("1700000000000-%d", i)
+		event.Timestamp = time.Now()
+		events[i] = event
+	}
+
+	// The second entry is still pending on another consumer when the snapshot is taken
+	before := newTestProcessor()
+	for _, event := range []stats.Event{events[0], events[2]} {
+		if err := before.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state, err := before.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := newTestProcessor()
+	if err := after.RestoreSnapshot(ctx, state); err != nil {
+		t.Fatal(err)
+	}
+	// It is claimed after the restart, along with a redelivery of the third one
+	for _, event := range events[1:] {
+		if err := after.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metrics := after.GetMetrics()
+	if metrics.UserActions["login"] != 3 {
+		t.Errorf("expected 3 logins after restore, got %d", metrics.UserActions["login"])
+	}
+}
+
+type failingQuarantine struct {
+	calls int
+}
+
+func (q *failingQuarantine) Quarantine(ctx context.Context, event stats.Event, qerr *schema.QuarantineError) error {
+	q.calls++
+	return errors.New("stream unavailable")
+}
+
+func TestEventsAreClaimedOnlyOnceApplied(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+	quarantine := &failingQuarantine{}
+	ep := NewEventProcessor(logger, idempotency.NewMemoryStore(time.Minute), store.NewMemoryStore(), quarantine, nil)
+
+	unknown := stats.Event{ID: "unknown-1", Type: "unknown_type", Timestamp: time.Now()}
+	for range 2 {
+		if err := ep.ProcessEvent(ctx, unknown); err == nil {
+			t.Fatal("expected the failed quarantine to be reported")
+		}
+	}
+	if quarantine.calls != 2 {
+		t.Fatalf("expected the redelivered event to be quarantined again, got %d attempts", quarantine.calls)
+	}
+	if quarantined := ep.GetMetrics().QuarantinedEvents; quarantined != 0 {
+		t.Fatalf("expected failed quarantines not to be counted, got %d", quarantined)
+	}
+
+	event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.ID = "login-1"
+	for range 2 {
+		if err := ep.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if total := ep.GetMetrics().TotalEvents; total != 1 {
+		t.Fatalf("expected the applied event to be counted once, got %d", total)
+	}
+}
+
+func TestUsersOverTheCapAreTracked(t *testing.T) {
+	ctx := context.Background()
+	defer func(previous int) { maxUsers = previous }(maxUsers)
+	maxUsers = 1
+	ep := newTestProcessor()
+
+	for _, userID := range []string{"u1", "u2"} {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: userID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = "login-" + userID
+		event.Timestamp = time.Now()
+		if err := ep.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	report, err := ep.behavior.Retention(ctx, store.Day.Truncate(now), store.Day.Truncate(now).AddDate(0, 0, 1), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cohorts) != 1 || report.Cohorts[0].Users != 2 {
+		t.Errorf("expected both users in the retention cohort, got %+v", report.Cohorts)
+	}
+}
+
+func TestFullRollupBufferDoesNotBlockMetrics(t *testing.T) {
+	defer func(timeout time.Duration) { recordTimeout = timeout }(recordTimeout)
+	recordTimeout = 50 * time.Millisecond
+
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+	// Nothing ever flushes the store, as if Postgres were down
+	rollups := store.NewPostgresStore(logger, nil, store.PostgresConfig{BatchSize: 1, MaxBuffered: 1})
+	ep := NewEventProcessor(logger, nil, rollups, nil, nil)
+
+	event := func(i int) stats.Event {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = fmt.Sprintf("event-%d", i)
+		event.Timestamp = time.Now()
+		return event
+	}
+
+	if err := ep.ProcessEvent(ctx, event(0)); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- ep.ProcessEvent(ctx, event(1)) }()
+
+	metrics := make(chan stats.MetricsSummary, 1)
+	go func() { metrics <- ep.GetMetrics() }()
+	select {
+	case <-metrics:
+	case <-time.After(recordTimeout / 2):
+		t.Fatal("GetMetrics blocked while the rollup buffer was full")
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ProcessEvent did not give up on the full buffer")
+	}
+	if rollups.Dropped() != 1 {
+		t.Errorf("expected 1 dropped rollup record, got %d", rollups.Dropped())
+	}
+	if got := ep.GetMetrics().DroppedRollups; got != 1 {
+		t.Errorf("expected the metrics to report 1 dropped rollup record, got %d", got)
+	}
+}
+
+// countingActivity counts the activities recorded by the behavior tracker
+type countingActivity struct {
+	*behavior.MemoryStore
+	calls int
+}
+
+func (s *countingActivity) RecordActivity(ctx context.Context, userID string, day time.Time) error {
+	s.calls++
+	return s.MemoryStore.RecordActivity(ctx, userID, day)
+}
+
+func TestRedeliveredEventsAreCountedOnceWithPostgresRollups(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+	activity := &countingActivity{MemoryStore: behavior.NewMemoryStore()}
+	tracker := behavior.NewTracker(logger, activity, nil)
+	// Records are only buffered, the store is never flushed
+	rollups := store.NewPostgresStore(logger, nil, store.PostgresConfig{})
+	ep := NewEventProcessor(logger, idempotency.NewMemoryStore(time.Minute), rollups, nil, tracker)
+
+	event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.ID = "login-1"
+	event.StreamID = "1-0"
+	for range 2 {
+		if err := ep.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metrics := ep.GetMetrics()
+	if metrics.TotalEvents != 1 || metrics.EventsByType[stats.EventTypeUserAction] != 1 {
+		t.Errorf("expected the redelivered event to be counted once, got %d (%v)", metrics.TotalEvents, metrics.EventsByType)
+	}
+	if activity.calls != 1 {
+		t.Errorf("expected the redelivered event to be tracked once, got %d", activity.calls)
+	}
+}
+
+func TestHandlerErrorsAreNotCounted(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+	rollups := store.NewMemoryStore()
+	claims := idempotency.NewMemoryStore(time.Minute)
+	ep := NewEventProcessor(logger, claims, rollups, nil, nil)
+	ep.schemas = schema.NewRegistry[*store.Record]()
+	schema.Register(ep.schemas, func(ctx context.Context, event stats.Event, payload stats.UserActionEvent, rec *store.Record) error {
+		return errors.New("handler failed")
+	})
+
+	event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.ID = "login-1"
+	event.StreamID = "1-0"
+	if err := ep.ProcessEvent(ctx, event); err == nil {
+		t.Fatal("expected the handler error to be returned")
+	}
+
+	if total := ep.GetMetrics().TotalEvents; total != 0 {
+		t.Errorf("expected the failed event not to be counted, got %d", total)
+	}
+	totals, err := rollups.Totals(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(totals.EventsByType) != 0 {
+		t.Errorf("expected the failed event not to be recorded, got %v", totals.EventsByType)
+	}
+	if ep.position != "" {
+		t.Errorf("expected the position to stay behind the failed event, got %q", ep.position)
+	}
+	if seen, _ := claims.Seen(ctx, idempotencyScope, event.ID); seen {
+		t.Error("expected the failed event not to be claimed")
+	}
+}
+
+func TestSnapshotAppliedEntriesAreCapped(t *testing.T) {
+	ctx := context.Background()
+	defer func(previous int) { maxApplied = previous }(maxApplied)
+	maxApplied = 2
+
+	events := make([]stats.Event, 6)
+	for i := range events {
+		event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event.ID = fmt.Sprintf("event-%d", i)
+		event.StreamID = fmt.Sprintf("1700000000000-%d", i)
+		event.Timestamp = time.Now()
+		events[i] = event
+	}
+
+	before := newTestProcessor()
+	for _, event := range events[:5] {
+		if err := before.ProcessEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(before.applied) >= 2*maxApplied {
+		t.Errorf("expected the applied entries to be trimmed, got %d", len(before.applied))
+	}
+	state, err := before.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1700000000000-3", "1700000000000-4"}; !slices.Equal(state.Applied, want) {
+		t.Fatalf("expected the snapshot to keep %v, got %v", want, state.Applied)
+	}
+
+	after := newTestProcessor()
+	if err := after.RestoreSnapshot(ctx, state); err != nil {
+		t.Fatal(err)
+	}
+	if err := after.ProcessEvent(ctx, events[4]); err != nil {
+		t.Fatal(err)
+	}
+	if after.restored == nil {
+		t.Fatal("expected the restored entries to be kept until the consumer moves past them")
+	}
+	if err := after.ProcessEvent(ctx, events[5]); err != nil {
+		t.Fatal(err)
+	}
+	if after.restored != nil {
+		t.Error("expected the restored entries to be dropped once the consumer moved past them")
+	}
+	if total := after.GetMetrics().TotalEvents; total != 6 {
+		t.Errorf("expected 6 events after restore, got %d", total)
+	}
+}
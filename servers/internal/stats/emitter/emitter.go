@@ -0,0 +1,306 @@
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+// Compile-time check to ensure Emitter can be closed on graceful exit
+var _ shared.Closer = (*Emitter)(nil)
+
+// OverflowPolicy decides what happens to an event when the buffer is full
+type OverflowPolicy string
+
+const (
+	// DropNewest discards the event being emitted
+	DropNewest OverflowPolicy = "drop_newest"
+	// DropOldest discards the oldest buffered event to make room
+	DropOldest OverflowPolicy = "drop_oldest"
+	// Block waits up to Config.BlockTimeout for room, then drops the event
+	Block OverflowPolicy = "block"
+)
+
+// ParseOverflowPolicy validates a policy name read from configuration
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch p := OverflowPolicy(s); p {
+	case DropNewest, DropOldest, Block:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown overflow policy %q (expected drop_newest, drop_oldest or block)", s)
+	}
+}
+
+// Config configures an Emitter
+type Config struct {
+	// StreamKey is the stream events are published to
+	StreamKey string
+	// BufferSize bounds the events waiting to be published
+	BufferSize int
+	// BatchSize is the maximum number of events published in one round trip
+	BatchSize int
+	// FlushInterval is the maximum time an event waits for its batch to fill
+	FlushInterval time.Duration
+	// PublishTimeout bounds a single batch publish
+	PublishTimeout time.Duration
+	Policy         OverflowPolicy
+	// BlockTimeout is how long Emit waits for room under the Block policy
+	BlockTimeout time.Duration
+}
+
+// Counters are the lifetime totals of an Emitter
+type Counters struct {
+	Emitted   uint64 `json:"emitted"`
+	Dropped   uint64 `json:"dropped"`
+	Published uint64 `json:"published"`
+	Failed    uint64 `json:"failed"`
+}
+
+// Emitter publishes stats events asynchronously. Emit only enqueues into a
+// bounded buffer, and a background loop publishes the buffer in batches, so
+// callers never wait on the broker longer than the overflow policy allows.
+type Emitter struct {
+	logger    *slog.Logger
+	publisher broker.Publisher
+	config    Config
+
+	queue chan stats.Event
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+	// mu is held shared by Emit while it enqueues and exclusively to set closed,
+	// so no event is enqueued after Run drained the buffer
+	mu     sync.RWMutex
+	closed bool
+
+	emitted   atomic.Uint64
+	dropped   atomic.Uint64
+	published atomic.Uint64
+	failed    atomic.Uint64
+}
+
+// New creates an emitter. Call Run to start publishing.
+func New(logger *slog.Logger, publisher broker.Publisher, config Config) *Emitter {
+	if config.StreamKey == "" {
+		config.StreamKey = stats.EventStreamKey
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.PublishTimeout <= 0 {
+		config.PublishTimeout = 5 * time.Second
+	}
+	if config.Policy == "" {
+		config.Policy = DropNewest
+	}
+	if config.BlockTimeout <= 0 {
+		config.BlockTimeout = 100 * time.Millisecond
+	}
+
+	return &Emitter{
+		logger:    logger,
+		publisher: publisher,
+		config:    config,
+		queue:     make(chan stats.Event, config.BufferSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Emit enqueues an event and reports whether it was accepted. A missing ID or
+// timestamp is filled in so the stats consumer can deduplicate redeliveries.
+func (e *Emitter) Emit(event stats.Event) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		e.dropped.Add(1)
+		return false
+	}
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	select {
+	case e.queue <- event:
+		e.emitted.Add(1)
+		return true
+	default:
+	}
+
+	switch e.config.Policy {
+	case DropOldest:
+		select {
+		case <-e.queue:
+			e.dropped.Add(1)
+		default:
+		}
+		select {
+		case e.queue <- event:
+			e.emitted.Add(1)
+			return true
+		default:
+		}
+	case Block:
+		timer := time.NewTimer(e.config.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case e.queue <- event:
+			e.emitted.Add(1)
+			return true
+		case <-timer.C:
+		case <-e.stop:
+		}
+	}
+
+	e.dropped.Add(1)
+	return false
+}
+
+// Counters returns the lifetime totals
+func (e *Emitter) Counters() Counters {
+	return Counters{
+		Emitted:   e.emitted.Load(),
+		Dropped:   e.dropped.Load(),
+		Published: e.published.Load(),
+		Failed:    e.failed.Load(),
+	}
+}
+
+// Run publishes buffered events until Close is called or ctx is done
+func (e *Emitter) Run(ctx context.Context) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]stats.Event, 0, e.config.BatchSize)
+	for {
+		select {
+		case event := <-e.queue:
+			batch = append(batch, event)
+			if len(batch) < e.config.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-e.stop:
+			e.shutdown()
+			e.drain(batch)
+			return
+		case <-ctx.Done():
+			e.shutdown()
+			e.drain(batch)
+			return
+		}
+
+		e.publish(batch)
+		batch = batch[:0]
+	}
+}
+
+// shutdown stops Emit from enqueueing. Emit calls blocked under the Block policy
+// are woken by stop, and the lock waits for the ones in progress.
+func (e *Emitter) shutdown() {
+	e.once.Do(func() { close(e.stop) })
+
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+}
+
+// drain publishes the pending batch and whatever is left in the buffer
+func (e *Emitter) drain(batch []stats.Event) {
+	for {
+		select {
+		case event := <-e.queue:
+			batch = append(batch, event)
+			if len(batch) < e.config.BatchSize {
+				continue
+			}
+			e.publish(batch)
+			batch = batch[:0]
+		default:
+			e.publish(batch)
+			return
+		}
+	}
+}
+
+func (e *Emitter) publish(batch []stats.Event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	messages := make([]map[string]interface{}, 0, len(batch))
+	for _, event := range batch {
+		data, err := json.Marshal(event)
+		if err != nil {
+			e.failed.Add(1)
+			e.logger.Error("failed to marshal stats event", "event_id", event.ID, "error", err)
+			continue
+		}
+		messages = append(messages, map[string]interface{}{"data": string(data)})
+	}
+
+	// Publishing is detached from the Run context so the final drain still reaches the broker
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.PublishTimeout)
+	defer cancel()
+
+	if err := e.publishMessages(ctx, messages); err != nil {
+		e.failed.Add(uint64(len(messages)))
+		e.logger.Warn("failed to publish stats events; dropping batch",
+			"stream", e.config.StreamKey,
+			"events", len(messages),
+			"error", err)
+		return
+	}
+	e.published.Add(uint64(len(messages)))
+}
+
+func (e *Emitter) publishMessages(ctx context.Context, messages []map[string]interface{}) error {
+	if bp, ok := e.publisher.(broker.BatchPublisher); ok {
+		return bp.PublishBatch(ctx, e.config.StreamKey, messages)
+	}
+
+	for _, values := range messages {
+		if _, err := e.publisher.Publish(ctx, e.config.StreamKey, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops accepting events and waits for the buffer to be published
+func (e *Emitter) Close(ctx context.Context) error {
+	e.shutdown()
+
+	select {
+	case <-e.done:
+		c := e.Counters()
+		e.logger.Info("stats emitter stopped",
+			"published", c.Published,
+			"dropped", c.Dropped,
+			"failed", c.Failed)
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to flush stats events: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,110 @@
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker/memory"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+func TestAPICallsPublishesRoutePattern(t *testing.T) {
+	ctx := context.Background()
+	b := memory.New(100)
+	if err := b.EnsureGroup(ctx, stats.EventStreamKey, "test", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	e := New(slog.New(slog.DiscardHandler), b, Config{FlushInterval: 10 * time.Millisecond})
+	go e.Run(ctx)
+
+	r := chi.NewRouter()
+	r.Use(APICalls(e, MiddlewareConfig{UserID: UserIDFromHeader("X-User-ID")}))
+	r.Get("/v1/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		SetError(r.Context(), "item store unavailable")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, path := range []string{"/health", "/v1/items/42"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-User-ID", "u1")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if err := e.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := b.Fetch(ctx, broker.FetchRequest{Stream: stats.EventStreamKey, Group: "test", Consumer: "c", Count: 10})
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("expected one event, got %+v, %v", msgs, err)
+	}
+
+	var event stats.Event
+	if err := json.Unmarshal([]byte(msgs[0].Values["data"].(string)), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.ID == "" || event.Type != stats.EventTypeAPICall || event.UserID != "u1" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	var call stats.APICallEvent
+	if err := json.Unmarshal(event.Metadata, &call); err != nil {
+		t.Fatal(err)
+	}
+	if event.SchemaVersion != call.SchemaVersion() || call.Path != "/v1/items/{id}" ||
+		call.StatusCode != http.StatusServiceUnavailable || call.ErrorMessage != "item store unavailable" {
+		t.Errorf("unexpected payload %+v", call)
+	}
+}
+
+func TestEmitDropsWhenBufferIsFull(t *testing.T) {
+	for _, policy := range []OverflowPolicy{DropNewest, DropOldest, Block} {
+		e := New(slog.New(slog.DiscardHandler), memory.New(100), Config{
+			BufferSize:   2,
+			Policy:       policy,
+			BlockTimeout: time.Millisecond,
+		})
+
+		accepted := 0
+		for i := 0; i < 3; i++ {
+			if e.Emit(stats.Event{Type: stats.EventTypeAPICall}) {
+				accepted++
+			}
+		}
+
+		c := e.Counters()
+		wantAccepted := 2
+		if policy == DropOldest {
+			wantAccepted = 3
+		}
+		if accepted != wantAccepted || c.Dropped != 1 || len(e.queue) != 2 {
+			t.Errorf("%s: accepted %d, counters %+v, buffered %d", policy, accepted, c, len(e.queue))
+		}
+	}
+}
+
+func TestEmitRejectsEventsOnceRunStopped(t *testing.T) {
+	e := New(slog.New(slog.DiscardHandler), memory.New(100), Config{
+		BufferSize:   1,
+		Policy:       Block,
+		BlockTimeout: time.Second,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.Run(ctx)
+
+	if e.Emit(stats.Event{Type: stats.EventTypeAPICall}) {
+		t.Fatal("expected Emit to reject events after Run returned")
+	}
+	if c := e.Counters(); c.Dropped != 1 || len(e.queue) != 0 {
+		t.Errorf("counters %+v, buffered %d", c, len(e.queue))
+	}
+}
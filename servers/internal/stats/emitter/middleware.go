@@ -0,0 +1,121 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+// unmatchedRoute is reported for requests no route matched, so raw paths never become endpoint names
+const unmatchedRoute = "unmatched"
+
+// MiddlewareConfig configures APICalls
+type MiddlewareConfig struct {
+	// UserID extracts the caller from a request; nil reports no user
+	UserID func(r *http.Request) string
+	// Skip excludes requests such as health checks; nil uses SkipOperational
+	Skip func(r *http.Request) bool
+}
+
+// UserIDFromHeader reads the user ID from a request header set by an upstream gateway
+func UserIDFromHeader(header string) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		return r.Header.Get(header)
+	}
+}
+
+// SkipOperational skips health, readiness and profiling endpoints
+func SkipOperational(r *http.Request) bool {
+	return r.URL.Path == "/health" || r.URL.Path == "/ready" || strings.HasPrefix(r.URL.Path, "/debug/")
+}
+
+type errorKey struct{}
+
+// SetError attaches an error message to the api_call event of the request.
+// Handlers call it for failures that are not visible from the status code.
+func SetError(ctx context.Context, msg string) {
+	if holder, ok := ctx.Value(errorKey{}).(*string); ok {
+		*holder = msg
+	}
+}
+
+// APICalls emits an api_call event for every request, named after the chi
+// route pattern. It must be mounted on a chi router.
+func APICalls(e *Emitter, config MiddlewareConfig) func(next http.Handler) http.Handler {
+	if config.Skip == nil {
+		config.Skip = SkipOperational
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if config.Skip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			var errMsg string
+			r = r.WithContext(context.WithValue(r.Context(), errorKey{}, &errMsg))
+
+			defer func() {
+				status := ww.Status()
+				rec := recover()
+				if rec != nil {
+					status = http.StatusInternalServerError
+					errMsg = fmt.Sprintf("panic: %v", rec)
+				}
+				if status == 0 {
+					// nothing was written; net/http replies 200
+					status = http.StatusOK
+				}
+				if errMsg == "" && status >= http.StatusInternalServerError {
+					errMsg = http.StatusText(status)
+				}
+
+				call := stats.APICallEvent{
+					Method:       r.Method,
+					Path:         routePattern(r),
+					StatusCode:   status,
+					Duration:     time.Since(start),
+					ErrorMessage: errMsg,
+				}
+				if config.UserID != nil {
+					call.UserID = config.UserID(r)
+				}
+
+				if event, err := stats.NewEvent(call); err != nil {
+					e.logger.Error("failed to build api_call event", "error", err)
+				} else {
+					event.Timestamp = start
+					event.UserID = call.UserID
+					e.Emit(event)
+				}
+
+				if rec != nil {
+					panic(rec)
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// routePattern returns the matched chi route, e.g. /v1/user-profile/get
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return unmatchedRoute
+	}
+	if pattern := rctx.RoutePattern(); pattern != "" {
+		return pattern
+	}
+	return unmatchedRoute
+}
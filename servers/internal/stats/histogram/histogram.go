@@ -0,0 +1,162 @@
+package histogram
+
+import (
+	"math/bits"
+	"slices"
+	"time"
+)
+
+// subBucketBits sets the precision: every power of two is split into
+// 2^subBucketBits linear buckets, keeping the relative error under ~3%.
+const (
+	subBucketBits  = 5
+	subBucketCount = 1 << subBucketBits
+)
+
+// Histogram is a log-linear (HDR style) histogram of durations recorded
+// with microsecond resolution. Buckets are stored sparsely, so an idle
+// histogram is cheap. It is not safe for concurrent use.
+type Histogram struct {
+	buckets map[int]uint64
+	count   uint64
+	sum     time.Duration
+	min     time.Duration
+	max     time.Duration
+}
+
+// New creates an empty histogram
+func New() *Histogram {
+	return &Histogram{buckets: make(map[int]uint64)}
+}
+
+func bucketIndex(v uint64) int {
+	if v < subBucketCount {
+		return int(v)
+	}
+	exp := bits.Len64(v) - 1
+	shift := exp - subBucketBits
+	mantissa := v >> shift
+	return subBucketCount + shift*subBucketCount + int(mantissa-subBucketCount)
+}
+
+// bucketValue returns the midpoint of a bucket in microseconds
+func bucketValue(idx int) uint64 {
+	if idx < subBucketCount {
+		return uint64(idx)
+	}
+	k := idx - subBucketCount
+	shift := k / subBucketCount
+	mantissa := uint64(k%subBucketCount + subBucketCount)
+	lower := mantissa << shift
+	return lower + (uint64(1)<<shift)/2
+}
+
+// BucketIndex returns the index of the bucket d is recorded in.
+// Indexes are stable, so bucket counts can be persisted and restored with RecordBucket.
+func BucketIndex(d time.Duration) int {
+	return bucketIndex(uint64(max(d, 0).Microseconds()))
+}
+
+// Record adds a duration. Negative durations are recorded as zero.
+func (h *Histogram) Record(d time.Duration) {
+	d = max(d, 0)
+
+	h.buckets[BucketIndex(d)]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// RecordBucket adds n values at the midpoint of bucket idx. Mean, min and max
+// are then approximated from bucket midpoints.
+func (h *Histogram) RecordBucket(idx int, n uint64) {
+	if idx < 0 || n == 0 {
+		return
+	}
+
+	d := time.Duration(bucketValue(idx)) * time.Microsecond
+	h.buckets[idx] += n
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count += n
+	h.sum += d * time.Duration(n)
+}
+
+// Merge adds all values of other
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+
+	for idx, n := range other.buckets {
+		h.buckets[idx] += n
+	}
+	if h.count == 0 || other.min < h.min {
+		h.min = other.min
+	}
+	h.max = max(h.max, other.max)
+	h.count += other.count
+	h.sum += other.sum
+}
+
+// Reset removes all values
+func (h *Histogram) Reset() {
+	clear(h.buckets)
+	h.count = 0
+	h.sum = 0
+	h.min = 0
+	h.max = 0
+}
+
+// Count returns the number of recorded values
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+// Mean returns the exact average of recorded values
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Max returns the largest recorded value
+func (h *Histogram) Max() time.Duration {
+	return h.max
+}
+
+// Quantile returns the approximate value below which q (0..1) of the values fall
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	q = min(max(q, 0), 1)
+
+	indexes := make([]int, 0, len(h.buckets))
+	for idx := range h.buckets {
+		indexes = append(indexes, idx)
+	}
+	slices.Sort(indexes)
+
+	rank := uint64(q*float64(h.count-1)) + 1
+	var seen uint64
+	for _, idx := range indexes {
+		seen += h.buckets[idx]
+		if seen >= rank {
+			v := time.Duration(bucketValue(idx)) * time.Microsecond
+			// the bucket midpoint may fall outside the observed range
+			return min(max(v, h.min), h.max)
+		}
+	}
+	return h.max
+}
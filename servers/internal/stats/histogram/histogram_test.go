@@ -0,0 +1,90 @@
+package histogram
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHistogramQuantiles(t *testing.T) {
+	h := New()
+	for i := 1; i <= 10000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	tests := map[float64]time.Duration{
+		0.50: 5000 * time.Millisecond,
+		0.90: 9000 * time.Millisecond,
+		0.99: 9900 * time.Millisecond,
+	}
+	for q, want := range tests {
+		got := h.Quantile(q)
+		if relErr := math.Abs(float64(got-want)) / float64(want); relErr > 0.03 {
+			t.Errorf("p%v: expected ~%v, got %v (%.1f%% off)", q*100, want, got, relErr*100)
+		}
+	}
+
+	if h.Max() != 10*time.Second {
+		t.Errorf("expected max 10s, got %v", h.Max())
+	}
+	if want := 5000500 * time.Microsecond; h.Mean() != want {
+		t.Errorf("expected mean %v, got %v", want, h.Mean())
+	}
+}
+
+func TestHistogramSmallValuesAreExact(t *testing.T) {
+	h := New()
+	h.Record(7 * time.Microsecond)
+	if got := h.Quantile(0.5); got != 7*time.Microsecond {
+		t.Errorf("expected 7µs, got %v", got)
+	}
+}
+
+func TestWindowExpiresOldSlots(t *testing.T) {
+	w := NewWindow(time.Minute, 6)
+	start := time.Unix(1700000000, 0)
+
+	w.Record(start, 100*time.Millisecond, false)
+	w.Record(start.Add(30*time.Second), 300*time.Millisecond, true)
+
+	s := w.Snapshot(start.Add(30 * time.Second))
+	if s.Count != 2 || s.Errors != 1 || s.ErrorRate != 0.5 {
+		t.Fatalf("unexpected snapshot %+v", s)
+	}
+	if s.Mean != 200*time.Millisecond {
+		t.Errorf("expected mean 200ms, got %v", s.Mean)
+	}
+
+	// the first observation falls out of the window
+	s = w.Snapshot(start.Add(65 * time.Second))
+	if s.Count != 1 || s.Errors != 1 {
+		t.Fatalf("expected only the second observation, got %+v", s)
+	}
+
+	s = w.Snapshot(start.Add(2 * time.Minute))
+	if s.Count != 0 || s.ErrorRate != 0 {
+		t.Fatalf("expected empty window, got %+v", s)
+	}
+}
+
+func TestRecordBucketRestoresPersistedCounts(t *testing.T) {
+	src := New()
+	for i := 1; i <= 1000; i++ {
+		src.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	restored := New()
+	for idx, n := range src.buckets {
+		restored.RecordBucket(idx, n)
+	}
+
+	if restored.Count() != src.Count() {
+		t.Fatalf("expected %d values, got %d", src.Count(), restored.Count())
+	}
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		want, got := src.Quantile(q), restored.Quantile(q)
+		if relErr := math.Abs(float64(got-want)) / float64(want); relErr > 0.03 {
+			t.Errorf("p%v: expected ~%v, got %v", q*100, want, got)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package histogram
+
+import (
+	"sync"
+	"time"
+)
+
+// Snapshot summarizes a window
+type Snapshot struct {
+	Count     uint64
+	Errors    uint64
+	ErrorRate float64
+	Mean      time.Duration
+	P50       time.Duration
+	P90       time.Duration
+	P99       time.Duration
+	Max       time.Duration
+}
+
+type slot struct {
+	// epoch is the slot number since the Unix epoch; stale slots are reset on reuse
+	epoch  int64
+	hist   *Histogram
+	errors uint64
+}
+
+// Window is a sliding window of latencies and errors made of fixed-width
+// slots, so old values expire without keeping individual samples.
+type Window struct {
+	mu    sync.Mutex
+	width time.Duration
+	slots []slot
+}
+
+// NewWindow creates a window covering size, split into n slots
+func NewWindow(size time.Duration, n int) *Window {
+	n = max(n, 1)
+	width := max(size/time.Duration(n), time.Millisecond)
+
+	slots := make([]slot, n)
+	for i := range slots {
+		slots[i] = slot{epoch: -1, hist: New()}
+	}
+	return &Window{width: width, slots: slots}
+}
+
+// Record adds an observation at time now
+func (w *Window) Record(now time.Time, d time.Duration, failed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	epoch := now.UnixNano() / int64(w.width)
+	s := &w.slots[epoch%int64(len(w.slots))]
+	if s.epoch != epoch {
+		s.epoch = epoch
+		s.hist.Reset()
+		s.errors = 0
+	}
+
+	s.hist.Record(d)
+	if failed {
+		s.errors++
+	}
+}
+
+// Snapshot summarizes the observations recorded within the window ending at now
+func (w *Window) Snapshot(now time.Time) Snapshot {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	epoch := now.UnixNano() / int64(w.width)
+	oldest := epoch - int64(len(w.slots)) + 1
+
+	merged := New()
+	var errors uint64
+	for i := range w.slots {
+		s := &w.slots[i]
+		if s.epoch < oldest || s.epoch > epoch {
+			continue
+		}
+		merged.Merge(s.hist)
+		errors += s.errors
+	}
+
+	return Summarize(merged, errors)
+}
+
+// Summarize computes the snapshot of h, of which errors values failed
+func Summarize(h *Histogram, errors uint64) Snapshot {
+	snapshot := Snapshot{
+		Count:  h.Count(),
+		Errors: errors,
+		Mean:   h.Mean(),
+		P50:    h.Quantile(0.50),
+		P90:    h.Quantile(0.90),
+		P99:    h.Quantile(0.99),
+		Max:    h.Max(),
+	}
+	if snapshot.Count > 0 {
+		snapshot.ErrorRate = float64(errors) / float64(snapshot.Count)
+	}
+	return snapshot
+}
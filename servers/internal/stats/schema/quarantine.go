@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/broker"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+// Quarantine fields added next to the event data
+const (
+	QuarantineFieldReason = "reason"
+	QuarantineFieldError  = "error"
+	QuarantineFieldAt     = "quarantined_at"
+)
+
+// Quarantine keeps events that could not be applied, for inspection and replay
+type Quarantine interface {
+	Quarantine(ctx context.Context, event stats.Event, qerr *QuarantineError) error
+}
+
+// Compile-time checks to ensure the quarantines implement Quarantine
+var (
+	_ Quarantine = (*StreamQuarantine)(nil)
+	_ Quarantine = (*LogQuarantine)(nil)
+)
+
+// StreamQuarantine appends quarantined events to a stream
+type StreamQuarantine struct {
+	publisher broker.Publisher
+	streamKey string
+}
+
+// NewStreamQuarantine creates a quarantine publishing to stats.QuarantineStreamKey
+func NewStreamQuarantine(publisher broker.Publisher) *StreamQuarantine {
+	return &StreamQuarantine{publisher: publisher, streamKey: stats.QuarantineStreamKey}
+}
+
+// Quarantine publishes the event with the reason it was rejected
+func (q *StreamQuarantine) Quarantine(ctx context.Context, event stats.Event, qerr *QuarantineError) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quarantined event: %w", err)
+	}
+
+	if _, err := q.publisher.Publish(ctx, q.streamKey, map[string]interface{}{
+		"data":                string(data),
+		QuarantineFieldReason: string(qerr.Reason),
+		QuarantineFieldError:  qerr.Err.Error(),
+		QuarantineFieldAt:     time.Now().UTC().Format(time.RFC3339Nano),
+	}); err != nil {
+		return fmt.Errorf("failed to quarantine event: %w", err)
+	}
+	return nil
+}
+
+// LogQuarantine only logs quarantined events, for setups without a stream to keep them in
+type LogQuarantine struct {
+	logger *slog.Logger
+}
+
+// NewLogQuarantine creates a quarantine that logs events at warn level
+func NewLogQuarantine(logger *slog.Logger) *LogQuarantine {
+	return &LogQuarantine{logger: logger}
+}
+
+// Quarantine logs the event with the reason it was rejected
+func (q *LogQuarantine) Quarantine(ctx context.Context, event stats.Event, qerr *QuarantineError) error {
+	q.logger.Warn("quarantined event",
+		"event_id", event.ID,
+		"event_type", event.Type,
+		"schema_version", event.SchemaVersion,
+		"reason", qerr.Reason,
+		"error", qerr.Err,
+		"metadata", string(event.Metadata))
+	return nil
+}
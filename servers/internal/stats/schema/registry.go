@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+// Reason explains why an event was quarantined
+type Reason string
+
+const (
+	ReasonUnknownType    Reason = "unknown_type"
+	ReasonUnknownVersion Reason = "unknown_version"
+	ReasonDecodeFailed   Reason = "decode_failed"
+	ReasonInvalidPayload Reason = "invalid_payload"
+)
+
+// QuarantineError is returned by Dispatch for events that must not be
+// retried: they match no registered schema or their payload is invalid.
+type QuarantineError struct {
+	Reason Reason
+	Err    error
+}
+
+func (e *QuarantineError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *QuarantineError) Unwrap() error {
+	return e.Err
+}
+
+// Key identifies a schema
+type Key struct {
+	Type    stats.EventType `json:"type"`
+	Version int             `json:"version"`
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%s/v%d", k.Type, k.Version)
+}
+
+// Handler applies a decoded payload. C is per-event state passed through Dispatch.
+type Handler[C any, T stats.Payload] func(ctx context.Context, event stats.Event, payload T, c C) error
+
+// Registry maps event types and schema versions to payload types and handlers
+type Registry[C any] struct {
+	mu       sync.RWMutex
+	dispatch map[Key]func(ctx context.Context, event stats.Event, c C) error
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry[C any]() *Registry[C] {
+	return &Registry[C]{
+		dispatch: make(map[Key]func(ctx context.Context, event stats.Event, c C) error),
+	}
+}
+
+// Register adds the handler for payload type T, keyed by its event type and
+// schema version. Registering a key twice panics.
+func Register[C any, T stats.Payload](r *Registry[C], handler Handler[C, T]) {
+	var zero T
+	key := Key{Type: zero.EventType(), Version: zero.SchemaVersion()}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dispatch[key]; ok {
+		panic("schema: duplicate registration of " + key.String())
+	}
+	r.dispatch[key] = func(ctx context.Context, event stats.Event, c C) error {
+		var payload T
+		if len(event.Metadata) > 0 {
+			if err := json.Unmarshal(event.Metadata, &payload); err != nil {
+				return &QuarantineError{Reason: ReasonDecodeFailed, Err: err}
+			}
+		}
+		if v, ok := any(payload).(stats.Validator); ok {
+			if err := v.Validate(); err != nil {
+				return &QuarantineError{Reason: ReasonInvalidPayload, Err: err}
+			}
+		}
+		return handler(ctx, event, payload, c)
+	}
+}
+
+// Keys lists the registered schemas, sorted by type and version
+func (r *Registry[C]) Keys() []Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]Key, 0, len(r.dispatch))
+	for key := range r.dispatch {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b Key) int {
+		if c := strings.Compare(string(a.Type), string(b.Type)); c != 0 {
+			return c
+		}
+		return a.Version - b.Version
+	})
+	return keys
+}
+
+// Dispatch decodes the event metadata into the registered payload type and
+// calls its handler. Events to quarantine are reported as *QuarantineError;
+// any other error comes from the handler.
+func (r *Registry[C]) Dispatch(ctx context.Context, event stats.Event, c C) error {
+	key := Key{Type: event.Type, Version: max(event.SchemaVersion, 1)}
+
+	r.mu.RLock()
+	dispatch, ok := r.dispatch[key]
+	r.mu.RUnlock()
+
+	if !ok {
+		if r.hasType(event.Type) {
+			return &QuarantineError{Reason: ReasonUnknownVersion, Err: fmt.Errorf("no schema registered for %s", key)}
+		}
+		return &QuarantineError{Reason: ReasonUnknownType, Err: fmt.Errorf("no schema registered for event type %q", event.Type)}
+	}
+	return dispatch(ctx, event, c)
+}
+
+func (r *Registry[C]) hasType(eventType stats.EventType) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for key := range r.dispatch {
+		if key.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// AsQuarantine reports whether err asks for the event to be quarantined
+func AsQuarantine(err error) (*QuarantineError, bool) {
+	var qerr *QuarantineError
+	ok := errors.As(err, &qerr)
+	return qerr, ok
+}
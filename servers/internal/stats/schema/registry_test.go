@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+func TestDispatchDecodesRegisteredPayload(t *testing.T) {
+	r := NewRegistry[*[]string]()
+	Register(r, func(ctx context.Context, event stats.Event, payload stats.UserActionEvent, seen *[]string) error {
+		*seen = append(*seen, payload.Action)
+		return nil
+	})
+
+	event, err := stats.NewEvent(stats.UserActionEvent{Action: "login", UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var seen []string
+	if err := r.Dispatch(context.Background(), event, &seen); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 1 || seen[0] != "login" {
+		t.Errorf("expected handler to see login, got %v", seen)
+	}
+}
+
+func TestDispatchQuarantinesUnknownAndInvalidEvents(t *testing.T) {
+	r := NewRegistry[struct{}]()
+	Register(r, func(ctx context.Context, event stats.Event, payload stats.UserActionEvent, _ struct{}) error {
+		t.Error("handler must not be called")
+		return nil
+	})
+
+	tests := map[Reason]stats.Event{
+		ReasonUnknownType:    {Type: "signup"},
+		ReasonUnknownVersion: {Type: stats.EventTypeUserAction, SchemaVersion: 2},
+		ReasonDecodeFailed:   {Type: stats.EventTypeUserAction, Metadata: []byte(`{"action": 42}`)},
+		ReasonInvalidPayload: {Type: stats.EventTypeUserAction, Metadata: []byte(`{"user_id": "u1"}`)},
+	}
+	for want, event := range tests {
+		qerr, ok := AsQuarantine(r.Dispatch(context.Background(), event, struct{}{}))
+		if !ok || qerr.Reason != want {
+			t.Errorf("expected %s, got %v", want, qerr)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/behavior"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/store"
+)
+
+// Version is the current snapshot format. Bump it on incompatible changes and
+// convert older versions in Decode.
+const Version = 1
+
+// ErrNotFound is returned by Store.Load when no snapshot was saved yet
+var ErrNotFound = errors.New("snapshot not found")
+
+// State is the in-memory stats state at a stream position
+type State struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"taken_at"`
+	// Position is the highest stream entry ID applied to the state
+	Position string `json:"position,omitempty"`
+	// Applied lists the last stream entry IDs applied to the state, so
+	// redeliveries of them are skipped after a restore
+	Applied           []string              `json:"applied,omitempty"`
+	QuarantinedEvents int64                 `json:"quarantined_events"`
+	Rollups           store.MemoryState     `json:"rollups"`
+	Cohorts           *behavior.MemoryState `json:"cohorts,omitempty"`
+}
+
+// Encode serializes state in the current format
+func Encode(state State) ([]byte, error) {
+	state.Version = Version
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return data, nil
+}
+
+// Decode parses a snapshot and rejects unknown versions
+func Decode(data []byte) (State, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return State{}, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if header.Version != Version {
+		return State{}, fmt.Errorf("unsupported snapshot version %d (expected %d)", header.Version, Version)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return state, nil
+}
+
+// Store keeps the latest snapshot
+type Store interface {
+	Save(ctx context.Context, data []byte) error
+	// Load returns ErrNotFound when no snapshot was saved
+	Load(ctx context.Context) ([]byte, error)
+}
+
+// Source captures the state to snapshot
+type Source interface {
+	Snapshot() (State, error)
+}
+
+// Runner saves snapshots of a source periodically
+type Runner struct {
+	logger *slog.Logger
+	store  Store
+	source Source
+}
+
+// NewRunner creates a runner saving snapshots of source to s
+func NewRunner(logger *slog.Logger, s Store, source Source) *Runner {
+	return &Runner{logger: logger, store: s, source: source}
+}
+
+// Run saves a snapshot every interval until ctx is done
+func (r *Runner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Save(ctx); err != nil {
+				r.logger.Error("failed to save stats snapshot", "error", err)
+			}
+		}
+	}
+}
+
+// Save captures and saves a snapshot
+func (r *Runner) Save(ctx context.Context) error {
+	state, err := r.source.Snapshot()
+	if err != nil {
+		return err
+	}
+	data, err := Encode(state)
+	if err != nil {
+		return err
+	}
+	if err := r.store.Save(ctx, data); err != nil {
+		return err
+	}
+
+	r.logger.Debug("saved stats snapshot",
+		"position", state.Position,
+		"bytes", len(data))
+	return nil
+}
+
+// Load reads the latest snapshot. ok is false when there is none.
+func Load(ctx context.Context, s Store) (state State, ok bool, err error) {
+	data, err := s.Load(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return State{}, false, nil
+	}
+	if err != nil {
+		return State{}, false, err
+	}
+
+	state, err = Decode(data)
+	if err != nil {
+		return State{}, false, err
+	}
+	return state, true, nil
+}
@@ -0,0 +1,38 @@
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := NewFileStore(filepath.Join(t.TempDir(), "snapshot.json"))
+
+	if _, ok, err := Load(ctx, s); err != nil || ok {
+		t.Fatalf("expected no snapshot, got ok=%v err=%v", ok, err)
+	}
+
+	data, err := Encode(State{Position: "1700000000000-3", QuarantinedEvents: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(ctx, data); err != nil {
+		t.Fatal(err)
+	}
+
+	state, ok, err := Load(ctx, s)
+	if err != nil || !ok {
+		t.Fatalf("expected a snapshot, got ok=%v err=%v", ok, err)
+	}
+	if state.Version != Version || state.Position != "1700000000000-3" || state.QuarantinedEvents != 2 {
+		t.Errorf("unexpected snapshot: %+v", state)
+	}
+}
+
+func TestDecodeRejectsUnknownVersion(t *testing.T) {
+	if _, err := Decode([]byte(`{"version": 99}`)); err == nil {
+		t.Error("expected an error for an unknown snapshot version")
+	}
+}
@@ -0,0 +1,91 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Compile-time checks to ensure the stores implement Store
+var (
+	_ Store = (*FileStore)(nil)
+	_ Store = (*RedisStore)(nil)
+)
+
+// FileStore keeps the snapshot in a local file
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a store writing to path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Save writes to a temporary file and renames it, so a crash never leaves a partial snapshot
+func (s *FileStore) Save(ctx context.Context, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot file
+func (s *FileStore) Load(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	return data, nil
+}
+
+// RedisStore keeps the snapshot in a Redis key
+type RedisStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisStore creates a store writing to key
+func NewRedisStore(client *redis.Client, key string) *RedisStore {
+	return &RedisStore{client: client, key: key}
+}
+
+// Save overwrites the key
+func (s *RedisStore) Save(ctx context.Context, data []byte) error {
+	if err := s.client.Set(ctx, s.key, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save snapshot to redis: %w", err)
+	}
+	return nil
+}
+
+// Load reads the key
+func (s *RedisStore) Load(ctx context.Context) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot from redis: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/histogram"
+)
+
+// Compile-time check to ensure MemoryStore implements Store
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore keeps rollups in memory. Counts are lost on restart.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	counts  map[Key]int64
+	latency map[LatencyKey]latencyCounts
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counts:  make(map[Key]int64),
+		latency: make(map[LatencyKey]latencyCounts),
+	}
+}
+
+// Record increments the counters of rec
+func (s *MemoryStore) Record(ctx context.Context, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range rec.Keys() {
+		s.counts[key]++
+	}
+	for _, key := range rec.LatencyKeys() {
+		c := s.latency[key]
+		c.count++
+		if rec.Latency.Failed {
+			c.errors++
+		}
+		s.latency[key] = c
+	}
+	return nil
+}
+
+// Totals sums the daily rollups
+func (s *MemoryStore) Totals(ctx context.Context) (Totals, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	totals := newTotals()
+	for key, count := range s.counts {
+		if key.Granularity == Day && key.Dimension != DimensionUser {
+			totals.add(key.EventType, key.Dimension, key.DimensionValue, count)
+		}
+	}
+	return totals, nil
+}
+
+// Flush is a no-op; records are applied immediately
+func (s *MemoryStore) Flush(ctx context.Context) error {
+	return nil
+}
+
+// inRange reports whether a bucket of q.Granularity starting at bucket is selected by q
+func inRange(q Query, g Granularity, bucket time.Time) bool {
+	from, to := q.bucketRange()
+	return g == q.Granularity && !bucket.Before(from) && bucket.Before(to)
+}
+
+// EventCounts returns counts per bucket and event type
+func (s *MemoryStore) EventCounts(ctx context.Context, q Query) ([]CountPoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dimension := ""
+	if q.UserID != "" {
+		dimension = DimensionUser
+	}
+
+	points := make([]CountPoint, 0)
+	for key, count := range s.counts {
+		if !inRange(q, key.Granularity, key.BucketStart) ||
+			key.Dimension != dimension || key.DimensionValue != q.UserID ||
+			(q.EventType != "" && key.EventType != q.EventType) {
+			continue
+		}
+		points = append(points, CountPoint{BucketStart: key.BucketStart, EventType: key.EventType, Count: count})
+	}
+
+	slices.SortFunc(points, func(a, b CountPoint) int {
+		if c := a.BucketStart.Compare(b.BucketStart); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.EventType), string(b.EventType))
+	})
+	return points, nil
+}
+
+// TopValues returns the most frequent values of a dimension
+func (s *MemoryStore) TopValues(ctx context.Context, q Query, dimension string) ([]TopValue, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	totals := make(map[string]int64)
+	for key, count := range s.counts {
+		if !inRange(q, key.Granularity, key.BucketStart) || key.Dimension != dimension ||
+			(q.EventType != "" && key.EventType != q.EventType) {
+			continue
+		}
+		totals[key.DimensionValue] += count
+	}
+
+	values := make([]TopValue, 0, len(totals))
+	for value, count := range totals {
+		values = append(values, TopValue{Value: value, Count: count})
+	}
+	slices.SortFunc(values, func(a, b TopValue) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	if len(values) > q.limit() {
+		values = values[:q.limit()]
+	}
+	return values, nil
+}
+
+// Latency returns latency percentiles of the busiest values of a dimension
+func (s *MemoryStore) Latency(ctx context.Context, q Query, dimension string) ([]LatencyValue, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hists := make(map[string]*histogram.Histogram)
+	errors := make(map[string]uint64)
+	for key, c := range s.latency {
+		if !inRange(q, key.Granularity, key.BucketStart) || key.Dimension != dimension ||
+			(q.Value != "" && key.DimensionValue != q.Value) {
+			continue
+		}
+
+		h, ok := hists[key.DimensionValue]
+		if !ok {
+			h = histogram.New()
+			hists[key.DimensionValue] = h
+		}
+		h.RecordBucket(key.HistogramBucket, uint64(c.count))
+		errors[key.DimensionValue] += uint64(c.errors)
+	}
+	return buildLatency(hists, errors, q.limit()), nil
+}
+
+// MemoryState is the content of a MemoryStore, used for snapshots
+type MemoryState struct {
+	Counts  []CountEntry   `json:"counts"`
+	Latency []LatencyEntry `json:"latency"`
+}
+
+// CountEntry is a rollup counter
+type CountEntry struct {
+	Key   Key   `json:"key"`
+	Count int64 `json:"count"`
+}
+
+// LatencyEntry is a latency histogram counter
+type LatencyEntry struct {
+	Key    LatencyKey `json:"key"`
+	Count  int64      `json:"count"`
+	Errors int64      `json:"errors"`
+}
+
+// Export copies the counters of the store
+func (s *MemoryStore) Export() MemoryState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := MemoryState{
+		Counts:  make([]CountEntry, 0, len(s.counts)),
+		Latency: make([]LatencyEntry, 0, len(s.latency)),
+	}
+	for key, count := range s.counts {
+		state.Counts = append(state.Counts, CountEntry{Key: key, Count: count})
+	}
+	for key, c := range s.latency {
+		state.Latency = append(state.Latency, LatencyEntry{Key: key, Count: c.count, Errors: c.errors})
+	}
+	return state
+}
+
+// Import replaces the counters of the store with state
+func (s *MemoryStore) Import(state MemoryState) {
+	counts := make(map[Key]int64, len(state.Counts))
+	for _, e := range state.Counts {
+		e.Key.BucketStart = e.Key.BucketStart.UTC()
+		counts[e.Key] = e.Count
+	}
+	latency := make(map[LatencyKey]latencyCounts, len(state.Latency))
+	for _, e := range state.Latency {
+		e.Key.BucketStart = e.Key.BucketStart.UTC()
+		latency[e.Key] = latencyCounts{count: e.Count, errors: e.Errors}
+	}
+
+	s.mu.Lock()
+	s.counts = counts
+	s.latency = latency
+	s.mu.Unlock()
+}
@@ -0,0 +1,478 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/database/sqlc/postgres"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/shared/idempotency"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/histogram"
+)
+
+// Compile-time checks to ensure PostgresStore implements Store and DropCounter
+var (
+	_ Store       = (*PostgresStore)(nil)
+	_ DropCounter = (*PostgresStore)(nil)
+)
+
+// rollupScope scopes claims of events counted in stats_rollups. It is separate
+// from the processor's claims, which guard the in-memory metrics and trackers.
+var rollupScope = idempotency.Scope(stats.EventStreamKey, "stats-rollups")
+
+// PostgresConfig configures a PostgresStore
+type PostgresConfig struct {
+	// FlushInterval is the maximum time a record stays buffered
+	FlushInterval time.Duration
+	// BatchSize triggers an early flush once this many records are buffered
+	BatchSize int
+	// MaxBuffered caps the buffer while Postgres is unavailable. Record blocks
+	// while it is full, which holds back the consumer until a flush succeeds.
+	MaxBuffered int
+	// MinuteRetention and HourRetention bound how long fine grained rollups are kept; zero keeps them forever
+	MinuteRetention time.Duration
+	HourRetention   time.Duration
+	// IdempotencyTTL is how long counted event IDs are remembered
+	IdempotencyTTL time.Duration
+	// MaxFlushAttempts is how many flushes in a row may fail before the buffered
+	// records are dropped; zero retries until Postgres is back. Records Postgres
+	// rejects outright are dropped at once.
+	MaxFlushAttempts int
+}
+
+// PostgresStore buffers records and writes them to stats_rollups and
+// stats_latency_buckets in batches.
+//
+// Each batch runs in a single transaction that claims the event IDs in
+// processed_events and upserts the aggregated counters, so a redelivered
+// event is counted at most once and a failed batch is retried as a whole.
+type PostgresStore struct {
+	logger      *slog.Logger
+	pool        *pgxpool.Pool
+	queries     *sqlc.Queries
+	idempotency *idempotency.PostgresStore
+	config      PostgresConfig
+
+	mu     sync.Mutex
+	buffer []Record
+	// drained is closed and replaced after every successful flush to wake blocked Record calls
+	drained chan struct{}
+	flushCh chan struct{}
+	// dropped counts records given up on while waiting for buffer space or after failed flushes
+	dropped atomic.Int64
+	// failures counts the flushes that failed in a row; guarded by flushMu
+	failures int
+	// writeBatch writes records in one transaction; replaced in tests
+	writeBatch func(ctx context.Context, records []Record) error
+	// flushMu serializes flushes so requeued records keep their order
+	flushMu sync.Mutex
+}
+
+// NewPostgresStore creates a buffered Postgres store. Call Run to flush in the background.
+func NewPostgresStore(logger *slog.Logger, pool *pgxpool.Pool, config PostgresConfig) *PostgresStore {
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+	if config.MaxBuffered < config.BatchSize {
+		config.MaxBuffered = config.BatchSize * 100
+	}
+
+	s := &PostgresStore{
+		logger:      logger,
+		pool:        pool,
+		queries:     sqlc.New(pool),
+		idempotency: idempotency.NewPostgresStore(pool, config.IdempotencyTTL),
+		config:      config,
+		drained:     make(chan struct{}),
+		flushCh:     make(chan struct{}, 1),
+	}
+	s.writeBatch = s.write
+	return s
+}
+
+// Record buffers rec until the next flush. When the buffer is full it waits
+// for a flush to free space; if ctx ends first the record is dropped and counted.
+func (s *PostgresStore) Record(ctx context.Context, rec Record) error {
+	warned := false
+	for {
+		s.mu.Lock()
+		if len(s.buffer) < s.config.MaxBuffered {
+			s.buffer = append(s.buffer, rec)
+			full := len(s.buffer) >= s.config.BatchSize
+			s.mu.Unlock()
+
+			if full {
+				s.requestFlush()
+			}
+			return nil
+		}
+		drained := s.drained
+		s.mu.Unlock()
+
+		if !warned {
+			s.logger.Warn("stats rollup buffer full; waiting for a flush", "max_buffered", s.config.MaxBuffered)
+			warned = true
+		}
+		s.requestFlush()
+
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			dropped := s.dropped.Add(1)
+			s.logger.Error("dropped stats rollup record while the buffer was full",
+				"event_id", rec.EventID,
+				"dropped_total", dropped)
+			return fmt.Errorf("stats rollup buffer full: %w", ctx.Err())
+		}
+	}
+}
+
+// Dropped returns the number of records dropped because the buffer stayed full
+func (s *PostgresStore) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *PostgresStore) requestFlush() {
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run flushes on every interval or full batch and prunes expired rollups hourly, until ctx is done
+func (s *PostgresStore) Run(ctx context.Context) {
+	flushTicker := time.NewTicker(s.config.FlushInterval)
+	defer flushTicker.Stop()
+	pruneTicker := time.NewTicker(time.Hour)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-flushTicker.C:
+		case <-s.flushCh:
+		case <-pruneTicker.C:
+			s.prune(ctx)
+			continue
+		}
+
+		if err := s.Flush(ctx); err != nil {
+			s.logger.Error("failed to flush stats rollups", "error", err)
+		}
+	}
+}
+
+// Flush writes all buffered records. When Postgres is unavailable they are put
+// back in the buffer; records it rejects, and records that failed
+// MaxFlushAttempts times in a row, are dropped and reported in the error.
+func (s *PostgresStore) Flush(ctx context.Context) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	records := s.buffer
+	s.buffer = nil
+	s.mu.Unlock()
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	rejected := 0
+	err := s.writeBatch(ctx, records)
+	if err != nil && permanent(err) {
+		records, rejected, err = s.salvage(ctx, records)
+	}
+	if err != nil {
+		s.failures++
+		if s.config.MaxFlushAttempts <= 0 || s.failures < s.config.MaxFlushAttempts {
+			s.mu.Lock()
+			s.buffer = append(records, s.buffer...)
+			s.mu.Unlock()
+			return err
+		}
+		s.drop(records, err)
+		err = fmt.Errorf("dropped %d stats rollup records after %d failed flushes: %w", len(records), s.failures, err)
+	}
+	s.failures = 0
+
+	s.mu.Lock()
+	close(s.drained)
+	s.drained = make(chan struct{})
+	s.mu.Unlock()
+
+	if err == nil && rejected > 0 {
+		err = fmt.Errorf("dropped %d stats rollup records rejected by Postgres", rejected)
+	}
+	return err
+}
+
+// salvage writes records one at a time after Postgres rejected their batch, so
+// only the rejected records are dropped. It stops at the first error that is
+// not a rejection and returns the records left to write, with the number dropped.
+func (s *PostgresStore) salvage(ctx context.Context, records []Record) (rest []Record, rejected int, err error) {
+	for i, rec := range records {
+		err := s.writeBatch(ctx, []Record{rec})
+		if err == nil {
+			continue
+		}
+		if !permanent(err) {
+			return records[i:], rejected, err
+		}
+		s.drop([]Record{rec}, err)
+		rejected++
+	}
+	return nil, rejected, nil
+}
+
+// drop counts and logs records that will never be written
+func (s *PostgresStore) drop(records []Record, err error) {
+	ids := make([]string, 0, min(len(records), 10))
+	for _, rec := range records[:cap(ids)] {
+		ids = append(ids, rec.EventID)
+	}
+
+	dropped := s.dropped.Add(int64(len(records)))
+	s.logger.Error("dropped stats rollup records that could not be written",
+		"records", len(records),
+		"event_ids", ids,
+		"dropped_total", dropped,
+		"error", err)
+}
+
+// permanent reports whether Postgres rejected a write, so retrying the same
+// records fails again. Connection, resource and serialization errors are transient.
+func permanent(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || len(pgErr.Code) < 2 {
+		return false
+	}
+	switch pgErr.Code[:2] {
+	case "08", "40", "53", "57", "58":
+		return false
+	}
+	return true
+}
+
+func (s *PostgresStore) write(ctx context.Context, records []Record) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deltas := make(map[Key]int64)
+	latency := make(map[LatencyKey]latencyCounts)
+	duplicates := 0
+	for _, rec := range records {
+		if rec.EventID != "" {
+			claimed, err := s.idempotency.ClaimTx(ctx, tx, rollupScope, rec.EventID)
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				duplicates++
+				continue
+			}
+		}
+
+		for _, key := range rec.Keys() {
+			deltas[key]++
+		}
+		for _, key := range rec.LatencyKeys() {
+			c := latency[key]
+			c.count++
+			if rec.Latency.Failed {
+				c.errors++
+			}
+			latency[key] = c
+		}
+	}
+
+	q := s.queries.WithTx(tx)
+	for key, count := range deltas {
+		if _, err := q.UpsertStatsRollup(ctx, sqlc.UpsertStatsRollupParams{
+			Granularity:    string(key.Granularity),
+			BucketStart:    pgtype.Timestamptz{Time: key.BucketStart, Valid: true},
+			EventType:      string(key.EventType),
+			Dimension:      key.Dimension,
+			DimensionValue: key.DimensionValue,
+			Count:          count,
+		}); err != nil {
+			return fmt.Errorf("failed to upsert stats rollup: %w", err)
+		}
+	}
+	for key, c := range latency {
+		if _, err := q.UpsertStatsLatencyBucket(ctx, sqlc.UpsertStatsLatencyBucketParams{
+			Granularity:     string(key.Granularity),
+			BucketStart:     pgtype.Timestamptz{Time: key.BucketStart, Valid: true},
+			Dimension:       key.Dimension,
+			DimensionValue:  key.DimensionValue,
+			HistogramBucket: int32(key.HistogramBucket),
+			Count:           c.count,
+			Errors:          c.errors,
+		}); err != nil {
+			return fmt.Errorf("failed to upsert stats latency bucket: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit stats rollups: %w", err)
+	}
+
+	s.logger.Debug("flushed stats rollups",
+		"records", len(records),
+		"duplicates", duplicates,
+		"rows", len(deltas),
+		"latency_rows", len(latency))
+	return nil
+}
+
+// Totals sums the daily rollups
+func (s *PostgresStore) Totals(ctx context.Context) (Totals, error) {
+	rows, err := s.queries.ListStatsTotals(ctx)
+	if err != nil {
+		return Totals{}, fmt.Errorf("failed to list stats totals: %w", err)
+	}
+
+	totals := newTotals()
+	for _, row := range rows {
+		totals.add(stats.EventType(row.EventType), row.Dimension, row.DimensionValue, row.Total)
+	}
+	return totals, nil
+}
+
+// EventCounts returns counts per bucket and event type
+func (s *PostgresStore) EventCounts(ctx context.Context, q Query) ([]CountPoint, error) {
+	from, to := q.bucketRange()
+	dimension := ""
+	if q.UserID != "" {
+		dimension = DimensionUser
+	}
+
+	rows, err := s.queries.ListStatsEventCounts(ctx, sqlc.ListStatsEventCountsParams{
+		Granularity:    string(q.Granularity),
+		Dimension:      dimension,
+		DimensionValue: q.UserID,
+		FromTime:       pgtype.Timestamptz{Time: from, Valid: true},
+		ToTime:         pgtype.Timestamptz{Time: to, Valid: true},
+		EventType:      pgtype.Text{String: string(q.EventType), Valid: q.EventType != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stats event counts: %w", err)
+	}
+
+	points := make([]CountPoint, len(rows))
+	for i, row := range rows {
+		points[i] = CountPoint{
+			BucketStart: row.BucketStart.Time.UTC(),
+			EventType:   stats.EventType(row.EventType),
+			Count:       row.Count,
+		}
+	}
+	return points, nil
+}
+
+// TopValues returns the most frequent values of a dimension
+func (s *PostgresStore) TopValues(ctx context.Context, q Query, dimension string) ([]TopValue, error) {
+	from, to := q.bucketRange()
+	rows, err := s.queries.ListStatsTopValues(ctx, sqlc.ListStatsTopValuesParams{
+		Granularity: string(q.Granularity),
+		Dimension:   dimension,
+		FromTime:    pgtype.Timestamptz{Time: from, Valid: true},
+		ToTime:      pgtype.Timestamptz{Time: to, Valid: true},
+		EventType:   pgtype.Text{String: string(q.EventType), Valid: q.EventType != ""},
+		RowLimit:    int32(q.limit()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top stats values: %w", err)
+	}
+
+	values := make([]TopValue, len(rows))
+	for i, row := range rows {
+		values[i] = TopValue{Value: row.DimensionValue, Count: row.Total}
+	}
+	return values, nil
+}
+
+// Latency returns latency percentiles of the busiest values of a dimension
+func (s *PostgresStore) Latency(ctx context.Context, q Query, dimension string) ([]LatencyValue, error) {
+	from, to := q.bucketRange()
+	rows, err := s.queries.ListStatsLatencyBuckets(ctx, sqlc.ListStatsLatencyBucketsParams{
+		Granularity:    string(q.Granularity),
+		Dimension:      dimension,
+		FromTime:       pgtype.Timestamptz{Time: from, Valid: true},
+		ToTime:         pgtype.Timestamptz{Time: to, Valid: true},
+		DimensionValue: pgtype.Text{String: q.Value, Valid: q.Value != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stats latency buckets: %w", err)
+	}
+
+	hists := make(map[string]*histogram.Histogram)
+	errors := make(map[string]uint64)
+	for _, row := range rows {
+		h, ok := hists[row.DimensionValue]
+		if !ok {
+			h = histogram.New()
+			hists[row.DimensionValue] = h
+		}
+		h.RecordBucket(int(row.HistogramBucket), uint64(row.Count))
+		errors[row.DimensionValue] += uint64(row.Errors)
+	}
+	return buildLatency(hists, errors, q.limit()), nil
+}
+
+// prune deletes rollups past their retention and expired event claims
+func (s *PostgresStore) prune(ctx context.Context) {
+	retention := map[Granularity]time.Duration{
+		Minute: s.config.MinuteRetention,
+		Hour:   s.config.HourRetention,
+	}
+
+	for granularity, keep := range retention {
+		if keep <= 0 {
+			continue
+		}
+
+		before := pgtype.Timestamptz{Time: time.Now().Add(-keep), Valid: true}
+		count, err := s.queries.DeleteStatsRollupsBefore(ctx, sqlc.DeleteStatsRollupsBeforeParams{
+			Granularity: string(granularity),
+			BucketStart: before,
+		})
+		if err != nil {
+			s.logger.Warn("failed to prune stats rollups", "granularity", granularity, "error", err)
+			continue
+		}
+		latencyCount, err := s.queries.DeleteStatsLatencyBucketsBefore(ctx, sqlc.DeleteStatsLatencyBucketsBeforeParams{
+			Granularity: string(granularity),
+			BucketStart: before,
+		})
+		if err != nil {
+			s.logger.Warn("failed to prune stats latency buckets", "granularity", granularity, "error", err)
+			continue
+		}
+		if count > 0 || latencyCount > 0 {
+			s.logger.Debug("pruned stats rollups",
+				"granularity", granularity,
+				"count", count,
+				"latency_count", latencyCount)
+		}
+	}
+
+	if _, err := s.idempotency.Purge(ctx); err != nil {
+		s.logger.Warn("failed to purge processed events", "error", err)
+	}
+}
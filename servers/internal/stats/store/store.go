@@ -0,0 +1,301 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/histogram"
+)
+
+// Granularity is the size of a rollup bucket
+type Granularity string
+
+const (
+	Minute Granularity = "minute"
+	Hour   Granularity = "hour"
+	Day    Granularity = "day"
+)
+
+// Granularities lists every granularity an event is rolled up into
+var Granularities = []Granularity{Minute, Hour, Day}
+
+// ParseGranularity validates a granularity name
+func ParseGranularity(s string) (Granularity, error) {
+	switch g := Granularity(s); g {
+	case Minute, Hour, Day:
+		return g, nil
+	default:
+		return "", fmt.Errorf("unknown granularity %q (expected minute, hour or day)", s)
+	}
+}
+
+// Truncate returns the start of the bucket containing t, in UTC
+func (g Granularity) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch g {
+	case Minute:
+		return t.Truncate(time.Minute)
+	case Hour:
+		return t.Truncate(time.Hour)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Rollup dimensions. Every event is also counted without a dimension.
+const (
+	DimensionEndpoint  = "endpoint"
+	DimensionAction    = "action"
+	DimensionOperation = "operation"
+	// DimensionUser counts events per user. It is excluded from Totals.
+	DimensionUser = "user"
+)
+
+// Record is a processed event to be counted
+type Record struct {
+	// EventID deduplicates redelivered events; empty disables deduplication
+	EventID    string
+	Type       stats.EventType
+	Timestamp  time.Time
+	Dimensions map[string]string
+	// Latency is the duration of API calls and performance events
+	Latency *Latency
+}
+
+// Latency is a timed observation, rolled up into histograms per dimension value
+// and into an overall histogram without a dimension
+type Latency struct {
+	Dimension string
+	Value     string
+	Duration  time.Duration
+	Failed    bool
+}
+
+// SetUser adds the user dimension unless userID is empty or already set
+func (r *Record) SetUser(userID string) {
+	if userID == "" || r.Dimensions[DimensionUser] != "" {
+		return
+	}
+	if r.Dimensions == nil {
+		r.Dimensions = make(map[string]string)
+	}
+	r.Dimensions[DimensionUser] = userID
+}
+
+// Key identifies a single rollup counter
+type Key struct {
+	Granularity    Granularity     `json:"granularity"`
+	BucketStart    time.Time       `json:"bucket_start"`
+	EventType      stats.EventType `json:"event_type"`
+	Dimension      string          `json:"dimension,omitempty"`
+	DimensionValue string          `json:"dimension_value,omitempty"`
+}
+
+// Keys returns every counter the record increments
+func (r Record) Keys() []Key {
+	keys := make([]Key, 0, len(Granularities)*(len(r.Dimensions)+1))
+	for _, g := range Granularities {
+		bucket := g.Truncate(r.Timestamp)
+		keys = append(keys, Key{Granularity: g, BucketStart: bucket, EventType: r.Type})
+		for dim, value := range r.Dimensions {
+			if value == "" {
+				continue
+			}
+			keys = append(keys, Key{
+				Granularity:    g,
+				BucketStart:    bucket,
+				EventType:      r.Type,
+				Dimension:      dim,
+				DimensionValue: value,
+			})
+		}
+	}
+	return keys
+}
+
+// LatencyKey identifies a single histogram bucket counter
+type LatencyKey struct {
+	Granularity     Granularity `json:"granularity"`
+	BucketStart     time.Time   `json:"bucket_start"`
+	Dimension       string      `json:"dimension,omitempty"`
+	DimensionValue  string      `json:"dimension_value,omitempty"`
+	HistogramBucket int         `json:"histogram_bucket"`
+}
+
+// LatencyKeys returns every histogram counter the record increments
+func (r Record) LatencyKeys() []LatencyKey {
+	if r.Latency == nil {
+		return nil
+	}
+
+	idx := histogram.BucketIndex(r.Latency.Duration)
+	keys := make([]LatencyKey, 0, len(Granularities)*2)
+	for _, g := range Granularities {
+		bucket := g.Truncate(r.Timestamp)
+		keys = append(keys, LatencyKey{Granularity: g, BucketStart: bucket, HistogramBucket: idx})
+		if r.Latency.Value != "" {
+			keys = append(keys, LatencyKey{
+				Granularity:     g,
+				BucketStart:     bucket,
+				Dimension:       r.Latency.Dimension,
+				DimensionValue:  r.Latency.Value,
+				HistogramBucket: idx,
+			})
+		}
+	}
+	return keys
+}
+
+// latencyCounts are the aggregated counters of a LatencyKey
+type latencyCounts struct {
+	count  int64
+	errors int64
+}
+
+// Totals are all-time counts used to restore the processor on startup
+type Totals struct {
+	EventsByType map[stats.EventType]int64
+	// ByDimension maps a dimension to its value counts
+	ByDimension map[string]map[string]int64
+}
+
+func newTotals() Totals {
+	return Totals{
+		EventsByType: make(map[stats.EventType]int64),
+		ByDimension:  make(map[string]map[string]int64),
+	}
+}
+
+func (t Totals) add(eventType stats.EventType, dimension, value string, count int64) {
+	if dimension == "" {
+		t.EventsByType[eventType] += count
+		return
+	}
+
+	values, ok := t.ByDimension[dimension]
+	if !ok {
+		values = make(map[string]int64)
+		t.ByDimension[dimension] = values
+	}
+	values[value] += count
+}
+
+// DefaultLimit caps the values returned by TopValues and Latency when Query.Limit is unset
+const DefaultLimit = 10
+
+// Query selects rollups in [From, To) at a granularity
+type Query struct {
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+	// EventType optionally restricts counts to one event type
+	EventType stats.EventType
+	// UserID optionally restricts event counts to one user
+	UserID string
+	// Value optionally restricts latency to one dimension value
+	Value string
+	Limit int
+}
+
+// AutoGranularity picks the finest granularity that keeps a range chartable
+func AutoGranularity(from, to time.Time) Granularity {
+	switch d := to.Sub(from); {
+	case d <= 6*time.Hour:
+		return Minute
+	case d <= 14*24*time.Hour:
+		return Hour
+	default:
+		return Day
+	}
+}
+
+// bucketRange returns the bucket bounds covering the query range
+func (q Query) bucketRange() (time.Time, time.Time) {
+	return q.Granularity.Truncate(q.From), q.To.UTC()
+}
+
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultLimit
+	}
+	return q.Limit
+}
+
+// CountPoint is the number of events of a type in a bucket
+type CountPoint struct {
+	BucketStart time.Time       `json:"bucket_start"`
+	EventType   stats.EventType `json:"event_type"`
+	Count       int64           `json:"count"`
+}
+
+// TopValue is the number of events with a dimension value
+type TopValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// LatencyValue summarizes the latency of a dimension value; Value is empty for the overall latency
+type LatencyValue struct {
+	Value string `json:"value"`
+	stats.LatencyStats
+}
+
+// Querier reads rollups over a time range
+type Querier interface {
+	// EventCounts returns counts per bucket and event type, filtered by event type and user
+	EventCounts(ctx context.Context, q Query) ([]CountPoint, error)
+
+	// TopValues returns the most frequent values of a dimension, filtered by event type
+	TopValues(ctx context.Context, q Query, dimension string) ([]TopValue, error)
+
+	// Latency returns latency percentiles of the busiest values of a dimension.
+	// An empty dimension returns the overall latency of all timed events.
+	Latency(ctx context.Context, q Query, dimension string) ([]LatencyValue, error)
+}
+
+// buildLatency turns histogram bucket counts per dimension value into
+// summaries sorted by count, keeping the busiest limit values
+func buildLatency(hists map[string]*histogram.Histogram, errors map[string]uint64, limit int) []LatencyValue {
+	values := make([]LatencyValue, 0, len(hists))
+	for value, h := range hists {
+		values = append(values, LatencyValue{
+			Value:        value,
+			LatencyStats: stats.NewLatencyStats(histogram.Summarize(h, errors[value])),
+		})
+	}
+
+	slices.SortFunc(values, func(a, b LatencyValue) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	if len(values) > limit {
+		values = values[:limit]
+	}
+	return values
+}
+
+// Store persists rollups of processed events
+type Store interface {
+	Querier
+
+	// Record counts an event. Implementations may buffer it until the next Flush.
+	Record(ctx context.Context, rec Record) error
+
+	// Totals returns all-time counts
+	Totals(ctx context.Context) (Totals, error)
+
+	// Flush writes buffered records
+	Flush(ctx context.Context) error
+}
+
+// DropCounter is implemented by stores that can drop records they could not buffer
+type DropCounter interface {
+	Dropped() int64
+}
@@ -0,0 +1,267 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats"
+)
+
+func TestGranularityTruncate(t *testing.T) {
+	ts := time.Date(2025, 3, 14, 15, 9, 26, 500, time.FixedZone("KST", 9*60*60))
+
+	tests := map[Granularity]time.Time{
+		Minute: time.Date(2025, 3, 14, 6, 9, 0, 0, time.UTC),
+		Hour:   time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC),
+		Day:    time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+	}
+	for g, want := range tests {
+		if got := g.Truncate(ts); !got.Equal(want) {
+			t.Errorf("%s: expected %v, got %v", g, want, got)
+		}
+	}
+}
+
+func TestMemoryStoreTotals(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	now := time.Now()
+
+	records := []Record{
+		{Type: stats.EventTypeAPICall, Timestamp: now, Dimensions: map[string]string{DimensionEndpoint: "GET /v1/items"}},
+		{Type: stats.EventTypeAPICall, Timestamp: now.Add(-48 * time.Hour), Dimensions: map[string]string{DimensionEndpoint: "GET /v1/items"}},
+		{Type: stats.EventTypeUserAction, Timestamp: now, Dimensions: map[string]string{DimensionAction: "login"}},
+		{Type: stats.EventTypeError, Timestamp: now},
+	}
+	for _, rec := range records {
+		if err := s.Record(ctx, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	totals, err := s.Totals(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := totals.EventsByType[stats.EventTypeAPICall]; got != 2 {
+		t.Errorf("expected 2 api calls, got %d", got)
+	}
+	if got := totals.ByDimension[DimensionEndpoint]["GET /v1/items"]; got != 2 {
+		t.Errorf("expected 2 calls to GET /v1/items, got %d", got)
+	}
+	if got := totals.ByDimension[DimensionAction]["login"]; got != 1 {
+		t.Errorf("expected 1 login, got %d", got)
+	}
+	if got := totals.EventsByType[stats.EventTypeError]; got != 1 {
+		t.Errorf("expected 1 error, got %d", got)
+	}
+}
+
+func TestMemoryStoreQueries(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	start := time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC)
+
+	call := func(at time.Time, endpoint, user string, d time.Duration, failed bool) Record {
+		rec := Record{
+			Type:       stats.EventTypeAPICall,
+			Timestamp:  at,
+			Dimensions: map[string]string{DimensionEndpoint: endpoint},
+			Latency:    &Latency{Dimension: DimensionEndpoint, Value: endpoint, Duration: d, Failed: failed},
+		}
+		rec.SetUser(user)
+		return rec
+	}
+	records := []Record{
+		call(start, "GET /v1/items", "u1", 10*time.Millisecond, false),
+		call(start.Add(time.Minute), "GET /v1/items", "u2", 20*time.Millisecond, false),
+		call(start.Add(time.Minute), "POST /v1/items", "u1", 100*time.Millisecond, true),
+		call(start.Add(time.Hour), "GET /v1/items", "u1", 10*time.Millisecond, false),
+	}
+	for _, rec := range records {
+		if err := s.Record(ctx, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := Query{From: start, To: start.Add(30 * time.Minute), Granularity: Minute}
+
+	points, err := s.EventCounts(ctx, Query{From: q.From, To: q.To, Granularity: Minute, UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 || points[0].Count != 1 || points[1].Count != 1 || !points[1].BucketStart.Equal(start.Add(time.Minute)) {
+		t.Errorf("unexpected event counts for u1: %+v", points)
+	}
+
+	top, err := s.TopValues(ctx, q, DimensionEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0] != (TopValue{Value: "GET /v1/items", Count: 2}) {
+		t.Errorf("unexpected top endpoints: %+v", top)
+	}
+
+	overall, err := s.Latency(ctx, q, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overall) != 1 || overall[0].Count != 3 || overall[0].Errors != 1 {
+		t.Fatalf("unexpected overall latency: %+v", overall)
+	}
+
+	byEndpoint, err := s.Latency(ctx, Query{From: q.From, To: q.To, Granularity: Minute, Value: "POST /v1/items"}, DimensionEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byEndpoint) != 1 || byEndpoint[0].ErrorRate != 1 || byEndpoint[0].MaxMs < 97 || byEndpoint[0].MaxMs > 103 {
+		t.Errorf("unexpected endpoint latency: %+v", byEndpoint)
+	}
+
+	totals, err := s.Totals(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := totals.ByDimension[DimensionUser]; ok {
+		t.Error("expected user counts to be excluded from totals")
+	}
+}
+
+func TestPostgresStoreRecordWaitsForBufferSpace(t *testing.T) {
+	s := NewPostgresStore(slog.New(slog.DiscardHandler), nil, PostgresConfig{BatchSize: 1, MaxBuffered: 1})
+	if err := s.Record(context.Background(), Record{EventID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing flushes, so a record that cannot wait is dropped and counted
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Record(ctx, Record{EventID: "b"}); err == nil {
+		t.Fatal("expected an error while the buffer is full")
+	}
+	if s.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped record, got %d", s.Dropped())
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Record(context.Background(), Record{EventID: "c"}) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected Record to block until a flush, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Simulate a successful flush
+	s.mu.Lock()
+	s.buffer = nil
+	close(s.drained)
+	s.drained = make(chan struct{})
+	s.mu.Unlock()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Record to resume after the flush")
+	}
+	if len(s.buffer) != 1 || s.buffer[0].EventID != "c" {
+		t.Errorf("unexpected buffer %+v", s.buffer)
+	}
+}
+
+func TestPostgresStoreFlushDropsRejectedRecords(t *testing.T) {
+	ctx := context.Background()
+	s := NewPostgresStore(slog.New(slog.DiscardHandler), nil, PostgresConfig{BatchSize: 10})
+
+	var written []string
+	s.writeBatch = func(ctx context.Context, records []Record) error {
+		for _, rec := range records {
+			if rec.EventID == "bad" {
+				return &pgconn.PgError{Code: "23514", Message: "check constraint violated"}
+			}
+		}
+		for _, rec := range records {
+			written = append(written, rec.EventID)
+		}
+		return nil
+	}
+
+	for _, id := range []string{"a", "bad", "c"} {
+		if err := s.Record(ctx, Record{EventID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Flush(ctx); err == nil {
+		t.Error("expected Flush to report the rejected record")
+	}
+	if s.Dropped() != 1 || len(s.buffer) != 0 {
+		t.Errorf("expected 1 dropped record and an empty buffer, got %d dropped and %d buffered", s.Dropped(), len(s.buffer))
+	}
+	if len(written) != 2 || written[0] != "a" || written[1] != "c" {
+		t.Errorf("expected the other records to be written, got %v", written)
+	}
+
+	// The next flush is no longer held up by the rejected record
+	if err := s.Record(ctx, Record{EventID: "d"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPostgresStoreFlushGivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := NewPostgresStore(slog.New(slog.DiscardHandler), nil, PostgresConfig{BatchSize: 10, MaxFlushAttempts: 2})
+	s.writeBatch = func(ctx context.Context, records []Record) error {
+		return errors.New("connection refused")
+	}
+
+	for _, id := range []string{"a", "b"} {
+		if err := s.Record(ctx, Record{EventID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Flush(ctx); err == nil {
+		t.Fatal("expected the first flush to fail")
+	}
+	if s.Dropped() != 0 || len(s.buffer) != 2 {
+		t.Fatalf("expected the records to be kept for a retry, got %d dropped and %d buffered", s.Dropped(), len(s.buffer))
+	}
+
+	if err := s.Flush(ctx); err == nil {
+		t.Fatal("expected the second flush to fail")
+	}
+	if s.Dropped() != 2 || len(s.buffer) != 0 {
+		t.Errorf("expected the records to be dropped, got %d dropped and %d buffered", s.Dropped(), len(s.buffer))
+	}
+}
+
+func TestPermanentWriteErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("connection refused"), false},
+		{&pgconn.PgError{Code: "08006"}, false},
+		{&pgconn.PgError{Code: "40001"}, false},
+		{&pgconn.PgError{Code: "53300"}, false},
+		{fmt.Errorf("failed to upsert stats rollup: %w", &pgconn.PgError{Code: "23505"}), true},
+		{&pgconn.PgError{Code: "22021"}, true},
+	}
+	for _, tt := range tests {
+		if got := permanent(tt.err); got != tt.want {
+			t.Errorf("permanent(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -1,24 +1,85 @@
 package stats
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-org/go-monorepo-boilerplate/servers/internal/stats/histogram"
+)
+
+const (
+	// EventStreamKey is the Redis stream the stats server consumes events from
+	EventStreamKey = "stats:events"
+	// EventConsumerGroup is the consumer group used by the stats server
+	EventConsumerGroup = "stats-service"
+	// QuarantineStreamKey receives events that do not match a registered schema
+	QuarantineStreamKey = "stats:events:quarantine"
+)
 
 // EventType represents the type of event being tracked
 type EventType string
 
 const (
-	EventTypeAPICall      EventType = "api_call"
-	EventTypeUserAction   EventType = "user_action"
-	EventTypeError        EventType = "error"
-	EventTypePerformance  EventType = "performance"
+	EventTypeAPICall     EventType = "api_call"
+	EventTypeUserAction  EventType = "user_action"
+	EventTypeError       EventType = "error"
+	EventTypePerformance EventType = "performance"
 )
 
-// Event represents a generic event to be tracked
+// Event represents a generic event to be tracked. Metadata holds the JSON
+// payload of the event type at SchemaVersion; it is decoded once by the
+// consumer into the registered payload type.
 type Event struct {
-	ID        string                 `json:"id"`
-	Type      EventType              `json:"type"`
-	Timestamp time.Time              `json:"timestamp"`
-	UserID    string                 `json:"user_id,omitempty"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ID        string    `json:"id"`
+	Type      EventType `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	UserID    string    `json:"user_id,omitempty"`
+	// SchemaVersion of Metadata; zero is read as version 1
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
+	// StreamID is the entry ID the event was read from; it is not part of the payload
+	StreamID string `json:"-"`
+}
+
+// Payload is the typed metadata of an event type
+type Payload interface {
+	EventType() EventType
+	// SchemaVersion changes whenever the payload changes incompatibly
+	SchemaVersion() int
+}
+
+// Validator is implemented by payloads that check their fields after decoding
+type Validator interface {
+	Validate() error
+}
+
+// Compile-time checks to ensure the event payloads implement Payload and Validator
+var (
+	_ Payload = APICallEvent{}
+	_ Payload = UserActionEvent{}
+	_ Payload = ErrorEvent{}
+	_ Payload = PerformanceEvent{}
+
+	_ Validator = APICallEvent{}
+	_ Validator = UserActionEvent{}
+	_ Validator = ErrorEvent{}
+	_ Validator = PerformanceEvent{}
+)
+
+// NewEvent wraps a payload in an event of its type and schema version
+func NewEvent(payload Payload) (Event, error) {
+	metadata, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal %s payload: %w", payload.EventType(), err)
+	}
+
+	return Event{
+		Type:          payload.EventType(),
+		SchemaVersion: payload.SchemaVersion(),
+		Metadata:      metadata,
+	}, nil
 }
 
 // APICallEvent represents an API call event
@@ -31,6 +92,21 @@ type APICallEvent struct {
 	ErrorMessage string        `json:"error_message,omitempty"`
 }
 
+func (APICallEvent) EventType() EventType { return EventTypeAPICall }
+func (APICallEvent) SchemaVersion() int   { return 1 }
+
+func (e APICallEvent) Validate() error {
+	switch {
+	case e.Method == "" || e.Path == "":
+		return errors.New("method and path are required")
+	case e.StatusCode < 100 || e.StatusCode > 599:
+		return fmt.Errorf("invalid status code %d", e.StatusCode)
+	case e.Duration < 0:
+		return errors.New("duration must not be negative")
+	}
+	return nil
+}
+
 // UserActionEvent represents a user action event
 type UserActionEvent struct {
 	Action   string                 `json:"action"`
@@ -39,6 +115,16 @@ type UserActionEvent struct {
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+func (UserActionEvent) EventType() EventType { return EventTypeUserAction }
+func (UserActionEvent) SchemaVersion() int   { return 1 }
+
+func (e UserActionEvent) Validate() error {
+	if e.Action == "" {
+		return errors.New("action is required")
+	}
+	return nil
+}
+
 // ErrorEvent represents an error event
 type ErrorEvent struct {
 	Message    string `json:"message"`
@@ -47,19 +133,84 @@ type ErrorEvent struct {
 	Context    string `json:"context,omitempty"`
 }
 
+func (ErrorEvent) EventType() EventType { return EventTypeError }
+func (ErrorEvent) SchemaVersion() int   { return 1 }
+
+func (e ErrorEvent) Validate() error {
+	if e.Message == "" {
+		return errors.New("message is required")
+	}
+	return nil
+}
+
 // PerformanceEvent represents a performance metric event
 type PerformanceEvent struct {
-	Operation string        `json:"operation"`
-	Duration  time.Duration `json:"duration"`
-	Success   bool          `json:"success"`
+	Operation string                 `json:"operation"`
+	Duration  time.Duration          `json:"duration"`
+	Success   bool                   `json:"success"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
+func (PerformanceEvent) EventType() EventType { return EventTypePerformance }
+func (PerformanceEvent) SchemaVersion() int   { return 1 }
+
+func (e PerformanceEvent) Validate() error {
+	switch {
+	case e.Operation == "":
+		return errors.New("operation is required")
+	case e.Duration < 0:
+		return errors.New("duration must not be negative")
+	}
+	return nil
+}
+
+// LatencyStats summarizes durations and failures over the latency window
+type LatencyStats struct {
+	Count     uint64  `json:"count"`
+	Errors    uint64  `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+	AvgMs     float64 `json:"avg_ms"`
+	P50Ms     float64 `json:"p50_ms"`
+	P90Ms     float64 `json:"p90_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+// NewLatencyStats converts a histogram snapshot to milliseconds
+func NewLatencyStats(s histogram.Snapshot) LatencyStats {
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+
+	return LatencyStats{
+		Count:     s.Count,
+		Errors:    s.Errors,
+		ErrorRate: s.ErrorRate,
+		AvgMs:     ms(s.Mean),
+		P50Ms:     ms(s.P50),
+		P90Ms:     ms(s.P90),
+		P99Ms:     ms(s.P99),
+		MaxMs:     ms(s.Max),
+	}
+}
+
 // MetricsSummary represents aggregated metrics
 type MetricsSummary struct {
-	TotalEvents      int64                  `json:"total_events"`
-	EventsByType     map[EventType]int64    `json:"events_by_type"`
-	AverageDuration  float64                `json:"average_duration,omitempty"`
-	ErrorRate        float64                `json:"error_rate,omitempty"`
-	LastUpdated      time.Time              `json:"last_updated"`
+	TotalEvents  int64               `json:"total_events"`
+	EventsByType map[EventType]int64 `json:"events_by_type"`
+	APICalls     map[string]int64    `json:"api_calls"`
+	UserActions  map[string]int64    `json:"user_actions"`
+	// AverageDuration (milliseconds) and ErrorRate cover API calls and
+	// performance events within the latency window
+	AverageDuration  float64                 `json:"average_duration,omitempty"`
+	ErrorRate        float64                 `json:"error_rate,omitempty"`
+	EndpointLatency  map[string]LatencyStats `json:"endpoint_latency,omitempty"`
+	OperationLatency map[string]LatencyStats `json:"operation_latency,omitempty"`
+	// QuarantinedEvents counts events that matched no registered schema
+	QuarantinedEvents int64 `json:"quarantined_events"`
+	// DroppedDimensions counts values counted as "other" per dimension after its cardinality cap was reached
+	DroppedDimensions map[string]int64 `json:"dropped_dimensions,omitempty"`
+	// DroppedRollups counts events left out of the persisted rollups because their buffer stayed full
+	DroppedRollups int64     `json:"dropped_rollups,omitempty"`
+	LastUpdated    time.Time `json:"last_updated"`
 }
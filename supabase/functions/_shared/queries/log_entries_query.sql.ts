@@ -0,0 +1,175 @@
+import postgres from "https://deno.land/x/postgresjs@v3.4.7/mod.js";
+
+type Sql = postgres.Sql;
+export const insertLogEntryQuery = `-- name: InsertLogEntry :exec
+INSERT INTO log_entries (
+    log_id,
+    received_at,
+    server_time,
+    severity,
+    service_type,
+    service_name,
+    environment,
+    event_type,
+    request_id,
+    session_id,
+    user_id,
+    peer_ip,
+    data,
+    record
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)`;
+
+export interface InsertLogEntryArgs {
+    logId: string;
+    receivedAt: Date;
+    serverTime: Date | null;
+    severity: string;
+    serviceType: string;
+    serviceName: string;
+    environment: string;
+    eventType: string;
+    requestId: string;
+    sessionId: string;
+    userId: string;
+    peerIp: string;
+    data: string;
+    record: any;
+}
+
+export async function insertLogEntry(sql: Sql, args: InsertLogEntryArgs): Promise<void> {
+    await sql.unsafe(insertLogEntryQuery, [args.logId, args.receivedAt, args.serverTime, args.severity, args.serviceType, args.serviceName, args.environment, args.eventType, args.requestId, args.sessionId, args.userId, args.peerIp, args.data, args.record]);
+}
+
+export const searchLogEntriesQuery = `-- name: SearchLogEntries :many
+SELECT
+    id,
+    log_id,
+    received_at,
+    server_time,
+    severity,
+    service_type,
+    service_name,
+    environment,
+    event_type,
+    request_id,
+    session_id,
+    user_id,
+    peer_ip,
+    data,
+    record
+FROM log_entries
+WHERE received_at >= $1
+    AND received_at < $2
+    AND ($3::TEXT[] IS NULL OR severity = ANY($3::TEXT[]))
+    AND ($4::TEXT IS NULL OR service_name = $4)
+    AND ($5::TEXT IS NULL OR service_type = $5)
+    AND ($6::TEXT IS NULL OR event_type = $6)
+    AND ($7::TEXT IS NULL OR user_id = $7)
+    AND ($8::TEXT IS NULL OR session_id = $8)
+    AND ($9::TEXT IS NULL OR request_id = $9)
+    AND ($10::TEXT IS NULL OR to_tsvector('simple', data) @@ websearch_to_tsquery('simple', $10))
+    AND ($11::TIMESTAMPTZ IS NULL OR (received_at, id) < ($11, $12::BIGINT))
+ORDER BY received_at DESC, id DESC
+LIMIT $13`;
+
+export interface SearchLogEntriesArgs {
+    fromTime: Date;
+    toTime: Date;
+    severities: string[] | null;
+    serviceName: string | null;
+    serviceType: string | null;
+    eventType: string | null;
+    userId: string | null;
+    sessionId: string | null;
+    requestId: string | null;
+    search: string | null;
+    cursorTime: Date | null;
+    cursorId: string | null;
+    rowLimit: number;
+}
+
+export interface SearchLogEntriesRow {
+    id: string;
+    logId: string;
+    receivedAt: Date;
+    serverTime: Date | null;
+    severity: string;
+    serviceType: string;
+    serviceName: string;
+    environment: string;
+    eventType: string;
+    requestId: string;
+    sessionId: string;
+    userId: string;
+    peerIp: string;
+    data: string;
+    record: any;
+}
+
+export async function searchLogEntries(sql: Sql, args: SearchLogEntriesArgs): Promise<SearchLogEntriesRow[]> {
+    return (await sql.unsafe(searchLogEntriesQuery, [args.fromTime, args.toTime, args.severities, args.serviceName, args.serviceType, args.eventType, args.userId, args.sessionId, args.requestId, args.search, args.cursorTime, args.cursorId, args.rowLimit]).values()).map(row => ({
+        id: row[0],
+        logId: row[1],
+        receivedAt: row[2],
+        serverTime: row[3],
+        severity: row[4],
+        serviceType: row[5],
+        serviceName: row[6],
+        environment: row[7],
+        eventType: row[8],
+        requestId: row[9],
+        sessionId: row[10],
+        userId: row[11],
+        peerIp: row[12],
+        data: row[13],
+        record: row[14]
+    }));
+}
+
+export const createLogEntryPartitionsQuery = `-- name: CreateLogEntryPartitions :one
+SELECT create_log_entry_partitions($1, $2)`;
+
+export interface CreateLogEntryPartitionsArgs {
+    fromDay: Date;
+    toDay: Date;
+}
+
+export interface CreateLogEntryPartitionsRow {
+    createLogEntryPartitions: number;
+}
+
+export async function createLogEntryPartitions(sql: Sql, args: CreateLogEntryPartitionsArgs): Promise<CreateLogEntryPartitionsRow | null> {
+    const rows = await sql.unsafe(createLogEntryPartitionsQuery, [args.fromDay, args.toDay]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        createLogEntryPartitions: row[0]
+    };
+}
+
+export const dropLogEntryPartitionsQuery = `-- name: DropLogEntryPartitions :one
+SELECT drop_log_entry_partitions($1)`;
+
+export interface DropLogEntryPartitionsArgs {
+    beforeDay: Date;
+}
+
+export interface DropLogEntryPartitionsRow {
+    dropLogEntryPartitions: number;
+}
+
+export async function dropLogEntryPartitions(sql: Sql, args: DropLogEntryPartitionsArgs): Promise<DropLogEntryPartitionsRow | null> {
+    const rows = await sql.unsafe(dropLogEntryPartitionsQuery, [args.beforeDay]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        dropLogEntryPartitions: row[0]
+    };
+}
+
@@ -0,0 +1,113 @@
+import postgres from "https://deno.land/x/postgresjs@v3.4.7/mod.js";
+
+type Sql = postgres.Sql;
+export const claimProcessedEventQuery = `-- name: ClaimProcessedEvent :one
+INSERT INTO processed_events (
+    scope,
+    event_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (scope, event_id) DO UPDATE
+SET
+    processed_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE processed_events.expires_at < NOW()
+RETURNING event_id`;
+
+export interface ClaimProcessedEventArgs {
+    scope: string;
+    eventId: string;
+    expiresAt: Date;
+}
+
+export interface ClaimProcessedEventRow {
+    eventId: string;
+}
+
+export async function claimProcessedEvent(sql: Sql, args: ClaimProcessedEventArgs): Promise<ClaimProcessedEventRow | null> {
+    const rows = await sql.unsafe(claimProcessedEventQuery, [args.scope, args.eventId, args.expiresAt]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        eventId: row[0]
+    };
+}
+
+export const releaseProcessedEventQuery = `-- name: ReleaseProcessedEvent :one
+DELETE FROM processed_events
+WHERE scope = $1 AND event_id = $2
+RETURNING event_id`;
+
+export interface ReleaseProcessedEventArgs {
+    scope: string;
+    eventId: string;
+}
+
+export interface ReleaseProcessedEventRow {
+    eventId: string;
+}
+
+export async function releaseProcessedEvent(sql: Sql, args: ReleaseProcessedEventArgs): Promise<ReleaseProcessedEventRow | null> {
+    const rows = await sql.unsafe(releaseProcessedEventQuery, [args.scope, args.eventId]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        eventId: row[0]
+    };
+}
+
+export const processedEventExistsQuery = `-- name: ProcessedEventExists :one
+SELECT EXISTS (
+    SELECT 1 FROM processed_events
+    WHERE scope = $1 AND event_id = $2 AND expires_at >= NOW()
+)`;
+
+export interface ProcessedEventExistsArgs {
+    scope: string;
+    eventId: string;
+}
+
+export interface ProcessedEventExistsRow {
+    exists: boolean;
+}
+
+export async function processedEventExists(sql: Sql, args: ProcessedEventExistsArgs): Promise<ProcessedEventExistsRow | null> {
+    const rows = await sql.unsafe(processedEventExistsQuery, [args.scope, args.eventId]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        exists: row[0]
+    };
+}
+
+export const deleteExpiredProcessedEventsQuery = `-- name: DeleteExpiredProcessedEvents :one
+WITH deleted AS (
+    DELETE FROM processed_events
+    WHERE expires_at < NOW()
+    RETURNING event_id
+)
+SELECT COUNT(*) FROM deleted`;
+
+export interface DeleteExpiredProcessedEventsRow {
+    count: string;
+}
+
+export async function deleteExpiredProcessedEvents(sql: Sql): Promise<DeleteExpiredProcessedEventsRow | null> {
+    const rows = await sql.unsafe(deleteExpiredProcessedEventsQuery, []).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        count: row[0]
+    };
+}
+
@@ -0,0 +1,144 @@
+import postgres from "https://deno.land/x/postgresjs@v3.4.7/mod.js";
+
+type Sql = postgres.Sql;
+export const touchStatsUserActivityQuery = `-- name: TouchStatsUserActivity :one
+INSERT INTO stats_user_activity (
+    user_id,
+    first_seen,
+    last_active
+) VALUES (
+    $1, $2, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET last_active = EXCLUDED.last_active
+WHERE stats_user_activity.last_active < EXCLUDED.last_active
+RETURNING first_seen`;
+
+export interface TouchStatsUserActivityArgs {
+    userId: string;
+    day: Date;
+}
+
+export interface TouchStatsUserActivityRow {
+    firstSeen: Date;
+}
+
+export async function touchStatsUserActivity(sql: Sql, args: TouchStatsUserActivityArgs): Promise<TouchStatsUserActivityRow | null> {
+    const rows = await sql.unsafe(touchStatsUserActivityQuery, [args.userId, args.day]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        firstSeen: row[0]
+    };
+}
+
+export const incrementStatsRetentionQuery = `-- name: IncrementStatsRetention :exec
+INSERT INTO stats_retention (
+    cohort_date,
+    day_offset,
+    users
+) VALUES (
+    $1, $2, 1
+)
+ON CONFLICT (cohort_date, day_offset) DO UPDATE
+SET
+    users = stats_retention.users + 1,
+    updated_at = NOW()`;
+
+export interface IncrementStatsRetentionArgs {
+    cohortDate: Date;
+    dayOffset: number;
+}
+
+export async function incrementStatsRetention(sql: Sql, args: IncrementStatsRetentionArgs): Promise<void> {
+    await sql.unsafe(incrementStatsRetentionQuery, [args.cohortDate, args.dayOffset]);
+}
+
+export const incrementStatsFunnelStepQuery = `-- name: IncrementStatsFunnelStep :exec
+INSERT INTO stats_funnel_steps (
+    funnel,
+    cohort_date,
+    step,
+    users
+) VALUES (
+    $1, $2, $3, 1
+)
+ON CONFLICT (funnel, cohort_date, step) DO UPDATE
+SET
+    users = stats_funnel_steps.users + 1,
+    updated_at = NOW()`;
+
+export interface IncrementStatsFunnelStepArgs {
+    funnel: string;
+    cohortDate: Date;
+    step: number;
+}
+
+export async function incrementStatsFunnelStep(sql: Sql, args: IncrementStatsFunnelStepArgs): Promise<void> {
+    await sql.unsafe(incrementStatsFunnelStepQuery, [args.funnel, args.cohortDate, args.step]);
+}
+
+export const listStatsRetentionQuery = `-- name: ListStatsRetention :many
+SELECT
+    cohort_date,
+    day_offset,
+    users
+FROM stats_retention
+WHERE cohort_date >= $1
+    AND cohort_date < $2
+    AND day_offset <= $3
+ORDER BY cohort_date, day_offset`;
+
+export interface ListStatsRetentionArgs {
+    fromDate: Date;
+    toDate: Date;
+    maxOffset: number;
+}
+
+export interface ListStatsRetentionRow {
+    cohortDate: Date;
+    dayOffset: number;
+    users: string;
+}
+
+export async function listStatsRetention(sql: Sql, args: ListStatsRetentionArgs): Promise<ListStatsRetentionRow[]> {
+    return (await sql.unsafe(listStatsRetentionQuery, [args.fromDate, args.toDate, args.maxOffset]).values()).map(row => ({
+        cohortDate: row[0],
+        dayOffset: row[1],
+        users: row[2]
+    }));
+}
+
+export const listStatsFunnelStepsQuery = `-- name: ListStatsFunnelSteps :many
+SELECT
+    cohort_date,
+    step,
+    users
+FROM stats_funnel_steps
+WHERE funnel = $1
+    AND cohort_date >= $2
+    AND cohort_date < $3
+ORDER BY cohort_date, step`;
+
+export interface ListStatsFunnelStepsArgs {
+    funnel: string;
+    fromDate: Date;
+    toDate: Date;
+}
+
+export interface ListStatsFunnelStepsRow {
+    cohortDate: Date;
+    step: number;
+    users: string;
+}
+
+export async function listStatsFunnelSteps(sql: Sql, args: ListStatsFunnelStepsArgs): Promise<ListStatsFunnelStepsRow[]> {
+    return (await sql.unsafe(listStatsFunnelStepsQuery, [args.funnel, args.fromDate, args.toDate]).values()).map(row => ({
+        cohortDate: row[0],
+        step: row[1],
+        users: row[2]
+    }));
+}
+
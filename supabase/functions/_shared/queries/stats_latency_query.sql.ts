@@ -0,0 +1,113 @@
+import postgres from "https://deno.land/x/postgresjs@v3.4.7/mod.js";
+
+type Sql = postgres.Sql;
+export const upsertStatsLatencyBucketQuery = `-- name: UpsertStatsLatencyBucket :one
+INSERT INTO stats_latency_buckets (
+    granularity,
+    bucket_start,
+    dimension,
+    dimension_value,
+    histogram_bucket,
+    count,
+    errors
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (granularity, bucket_start, dimension, dimension_value, histogram_bucket) DO UPDATE
+SET
+    count = stats_latency_buckets.count + EXCLUDED.count,
+    errors = stats_latency_buckets.errors + EXCLUDED.errors,
+    updated_at = NOW()
+RETURNING count`;
+
+export interface UpsertStatsLatencyBucketArgs {
+    granularity: string;
+    bucketStart: Date;
+    dimension: string;
+    dimensionValue: string;
+    histogramBucket: number;
+    count: string;
+    errors: string;
+}
+
+export interface UpsertStatsLatencyBucketRow {
+    count: string;
+}
+
+export async function upsertStatsLatencyBucket(sql: Sql, args: UpsertStatsLatencyBucketArgs): Promise<UpsertStatsLatencyBucketRow | null> {
+    const rows = await sql.unsafe(upsertStatsLatencyBucketQuery, [args.granularity, args.bucketStart, args.dimension, args.dimensionValue, args.histogramBucket, args.count, args.errors]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        count: row[0]
+    };
+}
+
+export const listStatsLatencyBucketsQuery = `-- name: ListStatsLatencyBuckets :many
+SELECT
+    dimension_value,
+    histogram_bucket,
+    SUM(count)::BIGINT AS count,
+    SUM(errors)::BIGINT AS errors
+FROM stats_latency_buckets
+WHERE granularity = $1
+    AND dimension = $2
+    AND bucket_start >= $3
+    AND bucket_start < $4
+    AND ($5::TEXT IS NULL OR dimension_value = $5)
+GROUP BY dimension_value, histogram_bucket`;
+
+export interface ListStatsLatencyBucketsArgs {
+    granularity: string;
+    dimension: string;
+    fromTime: Date;
+    toTime: Date;
+    dimensionValue: string | null;
+}
+
+export interface ListStatsLatencyBucketsRow {
+    dimensionValue: string;
+    histogramBucket: number;
+    count: string;
+    errors: string;
+}
+
+export async function listStatsLatencyBuckets(sql: Sql, args: ListStatsLatencyBucketsArgs): Promise<ListStatsLatencyBucketsRow[]> {
+    return (await sql.unsafe(listStatsLatencyBucketsQuery, [args.granularity, args.dimension, args.fromTime, args.toTime, args.dimensionValue]).values()).map(row => ({
+        dimensionValue: row[0],
+        histogramBucket: row[1],
+        count: row[2],
+        errors: row[3]
+    }));
+}
+
+export const deleteStatsLatencyBucketsBeforeQuery = `-- name: DeleteStatsLatencyBucketsBefore :one
+WITH deleted AS (
+    DELETE FROM stats_latency_buckets
+    WHERE granularity = $1 AND bucket_start < $2
+    RETURNING bucket_start
+)
+SELECT COUNT(*) FROM deleted`;
+
+export interface DeleteStatsLatencyBucketsBeforeArgs {
+    granularity: string;
+    bucketStart: Date;
+}
+
+export interface DeleteStatsLatencyBucketsBeforeRow {
+    count: string;
+}
+
+export async function deleteStatsLatencyBucketsBefore(sql: Sql, args: DeleteStatsLatencyBucketsBeforeArgs): Promise<DeleteStatsLatencyBucketsBeforeRow | null> {
+    const rows = await sql.unsafe(deleteStatsLatencyBucketsBeforeQuery, [args.granularity, args.bucketStart]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        count: row[0]
+    };
+}
+
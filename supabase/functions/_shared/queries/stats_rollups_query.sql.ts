@@ -0,0 +1,170 @@
+import postgres from "https://deno.land/x/postgresjs@v3.4.7/mod.js";
+
+type Sql = postgres.Sql;
+export const upsertStatsRollupQuery = `-- name: UpsertStatsRollup :one
+INSERT INTO stats_rollups (
+    granularity,
+    bucket_start,
+    event_type,
+    dimension,
+    dimension_value,
+    count
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (granularity, bucket_start, event_type, dimension, dimension_value) DO UPDATE
+SET
+    count = stats_rollups.count + EXCLUDED.count,
+    updated_at = NOW()
+RETURNING count`;
+
+export interface UpsertStatsRollupArgs {
+    granularity: string;
+    bucketStart: Date;
+    eventType: string;
+    dimension: string;
+    dimensionValue: string;
+    count: string;
+}
+
+export interface UpsertStatsRollupRow {
+    count: string;
+}
+
+export async function upsertStatsRollup(sql: Sql, args: UpsertStatsRollupArgs): Promise<UpsertStatsRollupRow | null> {
+    const rows = await sql.unsafe(upsertStatsRollupQuery, [args.granularity, args.bucketStart, args.eventType, args.dimension, args.dimensionValue, args.count]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        count: row[0]
+    };
+}
+
+export const listStatsTotalsQuery = `-- name: ListStatsTotals :many
+SELECT
+    event_type,
+    dimension,
+    dimension_value,
+    SUM(count)::BIGINT AS total
+FROM stats_rollups
+WHERE granularity = 'day' AND dimension <> 'user'
+GROUP BY event_type, dimension, dimension_value`;
+
+export interface ListStatsTotalsRow {
+    eventType: string;
+    dimension: string;
+    dimensionValue: string;
+    total: string;
+}
+
+export async function listStatsTotals(sql: Sql): Promise<ListStatsTotalsRow[]> {
+    return (await sql.unsafe(listStatsTotalsQuery, []).values()).map(row => ({
+        eventType: row[0],
+        dimension: row[1],
+        dimensionValue: row[2],
+        total: row[3]
+    }));
+}
+
+export const listStatsEventCountsQuery = `-- name: ListStatsEventCounts :many
+SELECT
+    bucket_start,
+    event_type,
+    count
+FROM stats_rollups
+WHERE granularity = $1
+    AND dimension = $2
+    AND dimension_value = $3
+    AND bucket_start >= $4
+    AND bucket_start < $5
+    AND ($6::TEXT IS NULL OR event_type = $6)
+ORDER BY bucket_start, event_type`;
+
+export interface ListStatsEventCountsArgs {
+    granularity: string;
+    dimension: string;
+    dimensionValue: string;
+    fromTime: Date;
+    toTime: Date;
+    eventType: string | null;
+}
+
+export interface ListStatsEventCountsRow {
+    bucketStart: Date;
+    eventType: string;
+    count: string;
+}
+
+export async function listStatsEventCounts(sql: Sql, args: ListStatsEventCountsArgs): Promise<ListStatsEventCountsRow[]> {
+    return (await sql.unsafe(listStatsEventCountsQuery, [args.granularity, args.dimension, args.dimensionValue, args.fromTime, args.toTime, args.eventType]).values()).map(row => ({
+        bucketStart: row[0],
+        eventType: row[1],
+        count: row[2]
+    }));
+}
+
+export const listStatsTopValuesQuery = `-- name: ListStatsTopValues :many
+SELECT
+    dimension_value,
+    SUM(count)::BIGINT AS total
+FROM stats_rollups
+WHERE granularity = $1
+    AND dimension = $2
+    AND bucket_start >= $3
+    AND bucket_start < $4
+    AND ($5::TEXT IS NULL OR event_type = $5)
+GROUP BY dimension_value
+ORDER BY total DESC, dimension_value
+LIMIT $6`;
+
+export interface ListStatsTopValuesArgs {
+    granularity: string;
+    dimension: string;
+    fromTime: Date;
+    toTime: Date;
+    eventType: string | null;
+    rowLimit: number;
+}
+
+export interface ListStatsTopValuesRow {
+    dimensionValue: string;
+    total: string;
+}
+
+export async function listStatsTopValues(sql: Sql, args: ListStatsTopValuesArgs): Promise<ListStatsTopValuesRow[]> {
+    return (await sql.unsafe(listStatsTopValuesQuery, [args.granularity, args.dimension, args.fromTime, args.toTime, args.eventType, args.rowLimit]).values()).map(row => ({
+        dimensionValue: row[0],
+        total: row[1]
+    }));
+}
+
+export const deleteStatsRollupsBeforeQuery = `-- name: DeleteStatsRollupsBefore :one
+WITH deleted AS (
+    DELETE FROM stats_rollups
+    WHERE granularity = $1 AND bucket_start < $2
+    RETURNING bucket_start
+)
+SELECT COUNT(*) FROM deleted`;
+
+export interface DeleteStatsRollupsBeforeArgs {
+    granularity: string;
+    bucketStart: Date;
+}
+
+export interface DeleteStatsRollupsBeforeRow {
+    count: string;
+}
+
+export async function deleteStatsRollupsBefore(sql: Sql, args: DeleteStatsRollupsBeforeArgs): Promise<DeleteStatsRollupsBeforeRow | null> {
+    const rows = await sql.unsafe(deleteStatsRollupsBeforeQuery, [args.granularity, args.bucketStart]).values();
+    if (rows.length !== 1) {
+        return null;
+    }
+    const row = rows[0];
+    return {
+        count: row[0]
+    };
+}
+
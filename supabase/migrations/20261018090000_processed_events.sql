@@ -0,0 +1,29 @@
+
+  create table "public"."processed_events" (
+    "scope" text not null,
+    "event_id" text not null,
+    "processed_at" timestamp with time zone not null default now(),
+    "expires_at" timestamp with time zone not null
+      );
+
+
+CREATE INDEX idx_processed_events_expires_at ON public.processed_events USING btree (expires_at);
+
+CREATE UNIQUE INDEX processed_events_pkey ON public.processed_events USING btree (scope, event_id);
+
+alter table "public"."processed_events" add constraint "processed_events_pkey" PRIMARY KEY using index "processed_events_pkey";
+
+grant delete on table "public"."processed_events" to "service_role";
+
+grant insert on table "public"."processed_events" to "service_role";
+
+grant references on table "public"."processed_events" to "service_role";
+
+grant select on table "public"."processed_events" to "service_role";
+
+grant trigger on table "public"."processed_events" to "service_role";
+
+grant truncate on table "public"."processed_events" to "service_role";
+
+grant update on table "public"."processed_events" to "service_role";
+
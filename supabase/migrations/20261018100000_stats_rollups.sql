@@ -0,0 +1,36 @@
+
+  create table "public"."stats_rollups" (
+    "granularity" text not null,
+    "bucket_start" timestamp with time zone not null,
+    "event_type" text not null,
+    "dimension" text not null default ''::text,
+    "dimension_value" text not null default ''::text,
+    "count" bigint not null default 0,
+    "updated_at" timestamp with time zone not null default now()
+      );
+
+
+CREATE INDEX idx_stats_rollups_range ON public.stats_rollups USING btree (granularity, dimension, bucket_start);
+
+CREATE UNIQUE INDEX stats_rollups_pkey ON public.stats_rollups USING btree (granularity, bucket_start, event_type, dimension, dimension_value);
+
+alter table "public"."stats_rollups" add constraint "stats_rollups_pkey" PRIMARY KEY using index "stats_rollups_pkey";
+
+alter table "public"."stats_rollups" add constraint "stats_rollups_granularity_check" CHECK ((granularity = ANY (ARRAY['minute'::text, 'hour'::text, 'day'::text]))) not valid;
+
+alter table "public"."stats_rollups" validate constraint "stats_rollups_granularity_check";
+
+grant delete on table "public"."stats_rollups" to "service_role";
+
+grant insert on table "public"."stats_rollups" to "service_role";
+
+grant references on table "public"."stats_rollups" to "service_role";
+
+grant select on table "public"."stats_rollups" to "service_role";
+
+grant trigger on table "public"."stats_rollups" to "service_role";
+
+grant truncate on table "public"."stats_rollups" to "service_role";
+
+grant update on table "public"."stats_rollups" to "service_role";
+
@@ -0,0 +1,36 @@
+  create table "public"."stats_latency_buckets" (
+    "granularity" text not null,
+    "bucket_start" timestamp with time zone not null,
+    "dimension" text not null default ''::text,
+    "dimension_value" text not null default ''::text,
+    "histogram_bucket" integer not null,
+    "count" bigint not null default 0,
+    "errors" bigint not null default 0,
+    "updated_at" timestamp with time zone not null default now()
+      );
+
+
+CREATE INDEX idx_stats_latency_buckets_range ON public.stats_latency_buckets USING btree (granularity, dimension, bucket_start);
+
+CREATE UNIQUE INDEX stats_latency_buckets_pkey ON public.stats_latency_buckets USING btree (granularity, bucket_start, dimension, dimension_value, histogram_bucket);
+
+alter table "public"."stats_latency_buckets" add constraint "stats_latency_buckets_pkey" PRIMARY KEY using index "stats_latency_buckets_pkey";
+
+alter table "public"."stats_latency_buckets" add constraint "stats_latency_buckets_granularity_check" CHECK ((granularity = ANY (ARRAY['minute'::text, 'hour'::text, 'day'::text]))) not valid;
+
+alter table "public"."stats_latency_buckets" validate constraint "stats_latency_buckets_granularity_check";
+
+grant delete on table "public"."stats_latency_buckets" to "service_role";
+
+grant insert on table "public"."stats_latency_buckets" to "service_role";
+
+grant references on table "public"."stats_latency_buckets" to "service_role";
+
+grant select on table "public"."stats_latency_buckets" to "service_role";
+
+grant trigger on table "public"."stats_latency_buckets" to "service_role";
+
+grant truncate on table "public"."stats_latency_buckets" to "service_role";
+
+grant update on table "public"."stats_latency_buckets" to "service_role";
+
@@ -0,0 +1,86 @@
+  create table "public"."stats_funnel_steps" (
+    "funnel" text not null,
+    "cohort_date" date not null,
+    "step" integer not null,
+    "users" bigint not null default 0,
+    "updated_at" timestamp with time zone not null default now()
+      );
+
+
+  create table "public"."stats_retention" (
+    "cohort_date" date not null,
+    "day_offset" integer not null,
+    "users" bigint not null default 0,
+    "updated_at" timestamp with time zone not null default now()
+      );
+
+
+  create table "public"."stats_user_activity" (
+    "user_id" text not null,
+    "first_seen" date not null,
+    "last_active" date not null
+      );
+
+
+CREATE UNIQUE INDEX stats_funnel_steps_pkey ON public.stats_funnel_steps USING btree (funnel, cohort_date, step);
+
+CREATE UNIQUE INDEX stats_retention_pkey ON public.stats_retention USING btree (cohort_date, day_offset);
+
+CREATE UNIQUE INDEX stats_user_activity_pkey ON public.stats_user_activity USING btree (user_id);
+
+alter table "public"."stats_funnel_steps" add constraint "stats_funnel_steps_pkey" PRIMARY KEY using index "stats_funnel_steps_pkey";
+
+alter table "public"."stats_retention" add constraint "stats_retention_pkey" PRIMARY KEY using index "stats_retention_pkey";
+
+alter table "public"."stats_user_activity" add constraint "stats_user_activity_pkey" PRIMARY KEY using index "stats_user_activity_pkey";
+
+alter table "public"."stats_funnel_steps" add constraint "stats_funnel_steps_step_check" CHECK ((step >= 0)) not valid;
+
+alter table "public"."stats_funnel_steps" validate constraint "stats_funnel_steps_step_check";
+
+alter table "public"."stats_retention" add constraint "stats_retention_day_offset_check" CHECK ((day_offset >= 0)) not valid;
+
+alter table "public"."stats_retention" validate constraint "stats_retention_day_offset_check";
+
+grant delete on table "public"."stats_funnel_steps" to "service_role";
+
+grant insert on table "public"."stats_funnel_steps" to "service_role";
+
+grant references on table "public"."stats_funnel_steps" to "service_role";
+
+grant select on table "public"."stats_funnel_steps" to "service_role";
+
+grant trigger on table "public"."stats_funnel_steps" to "service_role";
+
+grant truncate on table "public"."stats_funnel_steps" to "service_role";
+
+grant update on table "public"."stats_funnel_steps" to "service_role";
+
+grant delete on table "public"."stats_retention" to "service_role";
+
+grant insert on table "public"."stats_retention" to "service_role";
+
+grant references on table "public"."stats_retention" to "service_role";
+
+grant select on table "public"."stats_retention" to "service_role";
+
+grant trigger on table "public"."stats_retention" to "service_role";
+
+grant truncate on table "public"."stats_retention" to "service_role";
+
+grant update on table "public"."stats_retention" to "service_role";
+
+grant delete on table "public"."stats_user_activity" to "service_role";
+
+grant insert on table "public"."stats_user_activity" to "service_role";
+
+grant references on table "public"."stats_user_activity" to "service_role";
+
+grant select on table "public"."stats_user_activity" to "service_role";
+
+grant trigger on table "public"."stats_user_activity" to "service_role";
+
+grant truncate on table "public"."stats_user_activity" to "service_role";
+
+grant update on table "public"."stats_user_activity" to "service_role";
+
@@ -0,0 +1,44 @@
+create sequence "public"."log_entries_id_seq";
+
+
+  create table "public"."log_entries" (
+    "id" bigint not null default nextval('public.log_entries_id_seq'::regclass),
+    "log_id" text not null,
+    "received_at" timestamp with time zone not null,
+    "server_time" timestamp with time zone,
+    "severity" text not null,
+    "service_type" text not null,
+    "service_name" text not null default ''::text,
+    "environment" text not null default ''::text,
+    "event_type" text not null,
+    "request_id" text not null default ''::text,
+    "session_id" text not null default ''::text,
+    "user_id" text not null default ''::text,
+    "peer_ip" text not null default ''::text,
+    "data" text not null default ''::text,
+    "record" jsonb not null
+      );
+
+
+alter sequence "public"."log_entries_id_seq" owned by "public"."log_entries"."id";
+
+CREATE INDEX idx_log_entries_received_at ON public.log_entries USING btree (received_at DESC);
+
+CREATE UNIQUE INDEX log_entries_pkey ON public.log_entries USING btree (id);
+
+alter table "public"."log_entries" add constraint "log_entries_pkey" PRIMARY KEY using index "log_entries_pkey";
+
+grant delete on table "public"."log_entries" to "service_role";
+
+grant insert on table "public"."log_entries" to "service_role";
+
+grant references on table "public"."log_entries" to "service_role";
+
+grant select on table "public"."log_entries" to "service_role";
+
+grant trigger on table "public"."log_entries" to "service_role";
+
+grant truncate on table "public"."log_entries" to "service_role";
+
+grant update on table "public"."log_entries" to "service_role";
+
@@ -0,0 +1,122 @@
+alter table "public"."log_entries" rename to "log_entries_unpartitioned";
+
+alter index "public"."log_entries_pkey" rename to "log_entries_unpartitioned_pkey";
+
+alter index "public"."idx_log_entries_received_at" rename to "idx_log_entries_unpartitioned_received_at";
+
+alter sequence "public"."log_entries_id_seq" owned by none;
+
+
+  create table "public"."log_entries" (
+    "id" bigint not null default nextval('public.log_entries_id_seq'::regclass),
+    "log_id" text not null,
+    "received_at" timestamp with time zone not null,
+    "server_time" timestamp with time zone,
+    "severity" text not null,
+    "service_type" text not null,
+    "service_name" text not null default ''::text,
+    "environment" text not null default ''::text,
+    "event_type" text not null,
+    "request_id" text not null default ''::text,
+    "session_id" text not null default ''::text,
+    "user_id" text not null default ''::text,
+    "peer_ip" text not null default ''::text,
+    "data" text not null default ''::text,
+    "record" jsonb not null
+      ) partition by range ("received_at");
+
+
+alter sequence "public"."log_entries_id_seq" owned by "public"."log_entries"."id";
+
+alter table "public"."log_entries" add constraint "log_entries_pkey" PRIMARY KEY ("id", "received_at");
+
+CREATE INDEX idx_log_entries_received_at ON public.log_entries USING btree (received_at DESC, id DESC);
+
+CREATE INDEX idx_log_entries_service ON public.log_entries USING btree (service_name, received_at DESC);
+
+CREATE INDEX idx_log_entries_event_type ON public.log_entries USING btree (event_type, received_at DESC);
+
+CREATE INDEX idx_log_entries_severity ON public.log_entries USING btree (severity, received_at DESC);
+
+CREATE INDEX idx_log_entries_user_id ON public.log_entries USING btree (user_id, received_at DESC) WHERE (user_id <> ''::text);
+
+CREATE INDEX idx_log_entries_session_id ON public.log_entries USING btree (session_id, received_at DESC) WHERE (session_id <> ''::text);
+
+CREATE INDEX idx_log_entries_request_id ON public.log_entries USING btree (request_id) WHERE (request_id <> ''::text);
+
+CREATE INDEX idx_log_entries_data_search ON public.log_entries USING gin (to_tsvector('simple'::regconfig, data));
+
+set check_function_bodies = off;
+
+CREATE OR REPLACE FUNCTION public.create_log_entry_partitions(from_day date, to_day date)
+ RETURNS integer
+ LANGUAGE plpgsql
+AS $function$
+DECLARE
+    day date := from_day;
+    partition_name text;
+    created integer := 0;
+BEGIN
+    WHILE day < to_day LOOP
+        partition_name := 'log_entries_' || to_char(day, 'YYYYMMDD');
+        IF to_regclass('public.' || partition_name) IS NULL THEN
+            EXECUTE format(
+                'CREATE TABLE public.%I PARTITION OF public.log_entries FOR VALUES FROM (%L) TO (%L)',
+                partition_name,
+                day::timestamp AT TIME ZONE 'UTC',
+                (day + 1)::timestamp AT TIME ZONE 'UTC');
+            created := created + 1;
+        END IF;
+        day := day + 1;
+    END LOOP;
+    RETURN created;
+END;
+$function$
+;
+
+CREATE OR REPLACE FUNCTION public.drop_log_entry_partitions(before_day date)
+ RETURNS integer
+ LANGUAGE plpgsql
+AS $function$
+DECLARE
+    partition_name text;
+    dropped integer := 0;
+BEGIN
+    FOR partition_name IN
+        SELECT c.relname
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'public.log_entries'::regclass
+            AND c.relname ~ '^log_entries_[0-9]{8}$'
+            AND to_date(substr(c.relname, 13), 'YYYYMMDD') < before_day
+    LOOP
+        EXECUTE format('DROP TABLE public.%I', partition_name);
+        dropped := dropped + 1;
+    END LOOP;
+    RETURN dropped;
+END;
+$function$
+;
+
+select public.create_log_entry_partitions(
+    coalesce((select (min(received_at) at time zone 'UTC')::date from public.log_entries_unpartitioned), (now() at time zone 'UTC')::date),
+    (now() at time zone 'UTC')::date + 7);
+
+insert into "public"."log_entries" select * from "public"."log_entries_unpartitioned";
+
+drop table "public"."log_entries_unpartitioned";
+
+grant delete on table "public"."log_entries" to "service_role";
+
+grant insert on table "public"."log_entries" to "service_role";
+
+grant references on table "public"."log_entries" to "service_role";
+
+grant select on table "public"."log_entries" to "service_role";
+
+grant trigger on table "public"."log_entries" to "service_role";
+
+grant truncate on table "public"."log_entries" to "service_role";
+
+grant update on table "public"."log_entries" to "service_role";
+
@@ -0,0 +1,57 @@
+-- name: InsertLogEntry :exec
+INSERT INTO log_entries (
+    log_id,
+    received_at,
+    server_time,
+    severity,
+    service_type,
+    service_name,
+    environment,
+    event_type,
+    request_id,
+    session_id,
+    user_id,
+    peer_ip,
+    data,
+    record
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+);
+
+-- name: SearchLogEntries :many
+SELECT
+    id,
+    log_id,
+    received_at,
+    server_time,
+    severity,
+    service_type,
+    service_name,
+    environment,
+    event_type,
+    request_id,
+    session_id,
+    user_id,
+    peer_ip,
+    data,
+    record
+FROM log_entries
+WHERE received_at >= sqlc.arg(from_time)
+    AND received_at < sqlc.arg(to_time)
+    AND (sqlc.narg(severities)::TEXT[] IS NULL OR severity = ANY(sqlc.narg(severities)::TEXT[]))
+    AND (sqlc.narg(service_name)::TEXT IS NULL OR service_name = sqlc.narg(service_name))
+    AND (sqlc.narg(service_type)::TEXT IS NULL OR service_type = sqlc.narg(service_type))
+    AND (sqlc.narg(event_type)::TEXT IS NULL OR event_type = sqlc.narg(event_type))
+    AND (sqlc.narg(user_id)::TEXT IS NULL OR user_id = sqlc.narg(user_id))
+    AND (sqlc.narg(session_id)::TEXT IS NULL OR session_id = sqlc.narg(session_id))
+    AND (sqlc.narg(request_id)::TEXT IS NULL OR request_id = sqlc.narg(request_id))
+    AND (sqlc.narg(search)::TEXT IS NULL OR to_tsvector('simple', data) @@ websearch_to_tsquery('simple', sqlc.narg(search)))
+    AND (sqlc.narg(cursor_time)::TIMESTAMPTZ IS NULL OR (received_at, id) < (sqlc.narg(cursor_time), sqlc.narg(cursor_id)::BIGINT))
+ORDER BY received_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CreateLogEntryPartitions :one
+SELECT create_log_entry_partitions(sqlc.arg(from_day), sqlc.arg(to_day));
+
+-- name: DropLogEntryPartitions :one
+SELECT drop_log_entry_partitions(sqlc.arg(before_day));
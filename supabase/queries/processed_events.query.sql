@@ -0,0 +1,33 @@
+-- name: ClaimProcessedEvent :one
+INSERT INTO processed_events (
+    scope,
+    event_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (scope, event_id) DO UPDATE
+SET
+    processed_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE processed_events.expires_at < NOW()
+RETURNING event_id;
+
+-- name: ReleaseProcessedEvent :one
+DELETE FROM processed_events
+WHERE scope = $1 AND event_id = $2
+RETURNING event_id;
+
+-- name: ProcessedEventExists :one
+SELECT EXISTS (
+    SELECT 1 FROM processed_events
+    WHERE scope = $1 AND event_id = $2 AND expires_at >= NOW()
+);
+
+-- name: DeleteExpiredProcessedEvents :one
+WITH deleted AS (
+    DELETE FROM processed_events
+    WHERE expires_at < NOW()
+    RETURNING event_id
+)
+SELECT COUNT(*) FROM deleted;
@@ -0,0 +1,61 @@
+-- name: TouchStatsUserActivity :one
+INSERT INTO stats_user_activity (
+    user_id,
+    first_seen,
+    last_active
+) VALUES (
+    sqlc.arg(user_id), sqlc.arg(day), sqlc.arg(day)
+)
+ON CONFLICT (user_id) DO UPDATE
+SET last_active = EXCLUDED.last_active
+WHERE stats_user_activity.last_active < EXCLUDED.last_active
+RETURNING first_seen;
+
+-- name: IncrementStatsRetention :exec
+INSERT INTO stats_retention (
+    cohort_date,
+    day_offset,
+    users
+) VALUES (
+    $1, $2, 1
+)
+ON CONFLICT (cohort_date, day_offset) DO UPDATE
+SET
+    users = stats_retention.users + 1,
+    updated_at = NOW();
+
+-- name: IncrementStatsFunnelStep :exec
+INSERT INTO stats_funnel_steps (
+    funnel,
+    cohort_date,
+    step,
+    users
+) VALUES (
+    $1, $2, $3, 1
+)
+ON CONFLICT (funnel, cohort_date, step) DO UPDATE
+SET
+    users = stats_funnel_steps.users + 1,
+    updated_at = NOW();
+
+-- name: ListStatsRetention :many
+SELECT
+    cohort_date,
+    day_offset,
+    users
+FROM stats_retention
+WHERE cohort_date >= sqlc.arg(from_date)
+    AND cohort_date < sqlc.arg(to_date)
+    AND day_offset <= sqlc.arg(max_offset)
+ORDER BY cohort_date, day_offset;
+
+-- name: ListStatsFunnelSteps :many
+SELECT
+    cohort_date,
+    step,
+    users
+FROM stats_funnel_steps
+WHERE funnel = sqlc.arg(funnel)
+    AND cohort_date >= sqlc.arg(from_date)
+    AND cohort_date < sqlc.arg(to_date)
+ORDER BY cohort_date, step;
@@ -0,0 +1,40 @@
+-- name: UpsertStatsLatencyBucket :one
+INSERT INTO stats_latency_buckets (
+    granularity,
+    bucket_start,
+    dimension,
+    dimension_value,
+    histogram_bucket,
+    count,
+    errors
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (granularity, bucket_start, dimension, dimension_value, histogram_bucket) DO UPDATE
+SET
+    count = stats_latency_buckets.count + EXCLUDED.count,
+    errors = stats_latency_buckets.errors + EXCLUDED.errors,
+    updated_at = NOW()
+RETURNING count;
+
+-- name: ListStatsLatencyBuckets :many
+SELECT
+    dimension_value,
+    histogram_bucket,
+    SUM(count)::BIGINT AS count,
+    SUM(errors)::BIGINT AS errors
+FROM stats_latency_buckets
+WHERE granularity = sqlc.arg(granularity)
+    AND dimension = sqlc.arg(dimension)
+    AND bucket_start >= sqlc.arg(from_time)
+    AND bucket_start < sqlc.arg(to_time)
+    AND (sqlc.narg(dimension_value)::TEXT IS NULL OR dimension_value = sqlc.narg(dimension_value))
+GROUP BY dimension_value, histogram_bucket;
+
+-- name: DeleteStatsLatencyBucketsBefore :one
+WITH deleted AS (
+    DELETE FROM stats_latency_buckets
+    WHERE granularity = $1 AND bucket_start < $2
+    RETURNING bucket_start
+)
+SELECT COUNT(*) FROM deleted;
@@ -0,0 +1,62 @@
+-- name: UpsertStatsRollup :one
+INSERT INTO stats_rollups (
+    granularity,
+    bucket_start,
+    event_type,
+    dimension,
+    dimension_value,
+    count
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (granularity, bucket_start, event_type, dimension, dimension_value) DO UPDATE
+SET
+    count = stats_rollups.count + EXCLUDED.count,
+    updated_at = NOW()
+RETURNING count;
+
+-- name: ListStatsTotals :many
+SELECT
+    event_type,
+    dimension,
+    dimension_value,
+    SUM(count)::BIGINT AS total
+FROM stats_rollups
+WHERE granularity = 'day' AND dimension <> 'user'
+GROUP BY event_type, dimension, dimension_value;
+
+-- name: ListStatsEventCounts :many
+SELECT
+    bucket_start,
+    event_type,
+    count
+FROM stats_rollups
+WHERE granularity = sqlc.arg(granularity)
+    AND dimension = sqlc.arg(dimension)
+    AND dimension_value = sqlc.arg(dimension_value)
+    AND bucket_start >= sqlc.arg(from_time)
+    AND bucket_start < sqlc.arg(to_time)
+    AND (sqlc.narg(event_type)::TEXT IS NULL OR event_type = sqlc.narg(event_type))
+ORDER BY bucket_start, event_type;
+
+-- name: ListStatsTopValues :many
+SELECT
+    dimension_value,
+    SUM(count)::BIGINT AS total
+FROM stats_rollups
+WHERE granularity = sqlc.arg(granularity)
+    AND dimension = sqlc.arg(dimension)
+    AND bucket_start >= sqlc.arg(from_time)
+    AND bucket_start < sqlc.arg(to_time)
+    AND (sqlc.narg(event_type)::TEXT IS NULL OR event_type = sqlc.narg(event_type))
+GROUP BY dimension_value
+ORDER BY total DESC, dimension_value
+LIMIT sqlc.arg(row_limit);
+
+-- name: DeleteStatsRollupsBefore :one
+WITH deleted AS (
+    DELETE FROM stats_rollups
+    WHERE granularity = $1 AND bucket_start < $2
+    RETURNING bucket_start
+)
+SELECT COUNT(*) FROM deleted;
@@ -38,6 +38,91 @@ CREATE TABLE IF NOT EXISTS transactions (
     notes TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
+-- Processed stream events, used to skip redelivered messages
+CREATE TABLE IF NOT EXISTS processed_events (
+    scope TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, event_id)
+);
+-- Time-bucketed event counts per event type and dimension (endpoint, action, ...)
+CREATE TABLE IF NOT EXISTS stats_rollups (
+    granularity TEXT NOT NULL CHECK (granularity IN ('minute', 'hour', 'day')),
+    bucket_start TIMESTAMPTZ NOT NULL,
+    event_type TEXT NOT NULL,
+    dimension TEXT NOT NULL DEFAULT '',
+    dimension_value TEXT NOT NULL DEFAULT '',
+    count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (
+        granularity,
+        bucket_start,
+        event_type,
+        dimension,
+        dimension_value
+    )
+);
+-- Latency histograms per bucket and dimension, using the bucket layout of internal/stats/histogram
+CREATE TABLE IF NOT EXISTS stats_latency_buckets (
+    granularity TEXT NOT NULL CHECK (granularity IN ('minute', 'hour', 'day')),
+    bucket_start TIMESTAMPTZ NOT NULL,
+    dimension TEXT NOT NULL DEFAULT '',
+    dimension_value TEXT NOT NULL DEFAULT '',
+    histogram_bucket INTEGER NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    errors BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (
+        granularity,
+        bucket_start,
+        dimension,
+        dimension_value,
+        histogram_bucket
+    )
+);
+-- First and last active day per user, feeding the retention cohorts
+CREATE TABLE IF NOT EXISTS stats_user_activity (
+    user_id TEXT PRIMARY KEY,
+    first_seen DATE NOT NULL,
+    last_active DATE NOT NULL
+);
+-- Users of each first-seen cohort active again day_offset days later
+CREATE TABLE IF NOT EXISTS stats_retention (
+    cohort_date DATE NOT NULL,
+    day_offset INTEGER NOT NULL CHECK (day_offset >= 0),
+    users BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (cohort_date, day_offset)
+);
+-- Users reaching each step of a funnel, by the day they entered it
+CREATE TABLE IF NOT EXISTS stats_funnel_steps (
+    funnel TEXT NOT NULL,
+    cohort_date DATE NOT NULL,
+    step INTEGER NOT NULL CHECK (step >= 0),
+    users BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (funnel, cohort_date, step)
+);
+-- Logs persisted by the logging server's Postgres sink, partitioned by day
+CREATE TABLE IF NOT EXISTS log_entries (
+    id BIGSERIAL,
+    log_id TEXT NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL,
+    server_time TIMESTAMPTZ,
+    severity TEXT NOT NULL,
+    service_type TEXT NOT NULL,
+    service_name TEXT NOT NULL DEFAULT '',
+    environment TEXT NOT NULL DEFAULT '',
+    event_type TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    session_id TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL DEFAULT '',
+    peer_ip TEXT NOT NULL DEFAULT '',
+    data TEXT NOT NULL DEFAULT '',
+    record JSONB NOT NULL,
+    PRIMARY KEY (id, received_at)
+) PARTITION BY RANGE (received_at);
 -- Indexes for performance
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email)
 WHERE deleted_at IS NULL;
@@ -48,11 +133,67 @@ CREATE INDEX IF NOT EXISTS idx_items_name ON items(name);
 CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions(user_id);
 CREATE INDEX IF NOT EXISTS idx_transactions_item_id ON transactions(item_id);
 CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_processed_events_expires_at ON processed_events(expires_at);
+CREATE INDEX IF NOT EXISTS idx_stats_rollups_range ON stats_rollups(granularity, dimension, bucket_start);
+CREATE INDEX IF NOT EXISTS idx_stats_latency_buckets_range ON stats_latency_buckets(granularity, dimension, bucket_start);
+CREATE INDEX IF NOT EXISTS idx_log_entries_received_at ON log_entries(received_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_log_entries_service ON log_entries(service_name, received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_log_entries_event_type ON log_entries(event_type, received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_log_entries_severity ON log_entries(severity, received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_log_entries_user_id ON log_entries(user_id, received_at DESC)
+WHERE user_id <> '';
+CREATE INDEX IF NOT EXISTS idx_log_entries_session_id ON log_entries(session_id, received_at DESC)
+WHERE session_id <> '';
+CREATE INDEX IF NOT EXISTS idx_log_entries_request_id ON log_entries(request_id)
+WHERE request_id <> '';
+CREATE INDEX IF NOT EXISTS idx_log_entries_data_search ON log_entries USING gin (to_tsvector('simple', data));
 -- Updated_at trigger function
 CREATE OR REPLACE FUNCTION update_updated_at_column() RETURNS TRIGGER AS $$ BEGIN NEW.updated_at = NOW();
 RETURN NEW;
 END;
 $$ language 'plpgsql';
+-- Daily log_entries partitions (log_entries_YYYYMMDD) for [from_day, to_day), in UTC
+CREATE OR REPLACE FUNCTION create_log_entry_partitions(from_day DATE, to_day DATE) RETURNS INTEGER AS $$
+DECLARE
+    day DATE := from_day;
+    partition_name TEXT;
+    created INTEGER := 0;
+BEGIN
+    WHILE day < to_day LOOP
+        partition_name := 'log_entries_' || to_char(day, 'YYYYMMDD');
+        IF to_regclass('public.' || partition_name) IS NULL THEN
+            EXECUTE format(
+                'CREATE TABLE public.%I PARTITION OF public.log_entries FOR VALUES FROM (%L) TO (%L)',
+                partition_name,
+                day::TIMESTAMP AT TIME ZONE 'UTC',
+                (day + 1)::TIMESTAMP AT TIME ZONE 'UTC');
+            created := created + 1;
+        END IF;
+        day := day + 1;
+    END LOOP;
+    RETURN created;
+END;
+$$ language 'plpgsql';
+-- Drops daily log_entries partitions of days before before_day
+CREATE OR REPLACE FUNCTION drop_log_entry_partitions(before_day DATE) RETURNS INTEGER AS $$
+DECLARE
+    partition_name TEXT;
+    dropped INTEGER := 0;
+BEGIN
+    FOR partition_name IN
+        SELECT c.relname
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'public.log_entries'::regclass
+            AND c.relname ~ '^log_entries_[0-9]{8}$'
+            AND to_date(substr(c.relname, 13), 'YYYYMMDD') < before_day
+    LOOP
+        EXECUTE format('DROP TABLE public.%I', partition_name);
+        dropped := dropped + 1;
+    END LOOP;
+    RETURN dropped;
+END;
+$$ language 'plpgsql';
 -- Apply updated_at trigger to tables
 DROP TRIGGER IF EXISTS update_users_updated_at ON users;
 CREATE TRIGGER update_users_updated_at BEFORE
@@ -64,5 +205,17 @@ UPDATE ON items FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 COMMENT ON TABLE users IS 'Application users with soft delete support';
 COMMENT ON TABLE items IS 'Items available in the system (inventory, products, etc.)';
 COMMENT ON TABLE transactions IS 'Transaction history for items and users';
+COMMENT ON TABLE processed_events IS 'Idempotency records for stream events, expired rows are purged periodically';
+COMMENT ON TABLE stats_rollups IS 'Event counts per minute, hour and day bucket';
+COMMENT ON COLUMN stats_rollups.dimension IS 'Empty for per event type totals';
+COMMENT ON TABLE stats_latency_buckets IS 'Latency histogram counts per minute, hour and day bucket';
+COMMENT ON COLUMN stats_latency_buckets.histogram_bucket IS 'Log-linear histogram bucket index of the duration in microseconds';
+COMMENT ON TABLE stats_user_activity IS 'First-seen and last active day per user';
+COMMENT ON TABLE stats_retention IS 'Daily retention cohorts keyed by first-seen date';
+COMMENT ON TABLE stats_funnel_steps IS 'Funnel step conversions per entry day';
+COMMENT ON COLUMN stats_funnel_steps.step IS 'Zero-based index of the step in the funnel definition';
+COMMENT ON TABLE log_entries IS 'Log entries ingested by the logging server, partitioned by received day';
+COMMENT ON COLUMN log_entries.peer_ip IS 'Address of the gRPC peer that sent the log';
+COMMENT ON COLUMN log_entries.record IS 'Full log request, including fields without a column';
 COMMENT ON COLUMN users.public_id IS 'Public-facing UUID for external APIs';
 COMMENT ON COLUMN users.deleted_at IS 'Soft delete timestamp - NULL means active user';
\ No newline at end of file